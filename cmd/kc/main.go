@@ -21,9 +21,20 @@ var (
 )
 
 func main() {
+	// Exec sessions start kc as the relay between their terminal and the
+	// container.
+	if ui.ExecRelayRequested() {
+		if err := ui.RunExecRelay(); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	var (
 		showVersion = flag.Bool("version", false, "Show version information")
 		help        = flag.Bool("help", false, "Show help information")
+		snapshotDir = flag.String("snapshot", "", "Browse a directory of YAML/JSON dumps instead of live clusters")
+		kubeconfig  = flag.String("kubeconfig", "", "Path to a kubeconfig file; overrides $KUBECONFIG and ~/.kube")
 	)
 
 	flag.Parse()
@@ -43,7 +54,9 @@ func main() {
 	}
 
 	// Run the application
-	if err := ui.Run(context.Background()); err != nil {
+	// An optional argument is the path to start at, e.g. /namespaces/kube-system/pods.
+	opts := ui.Options{Snapshot: *snapshotDir, Kubeconfig: *kubeconfig, Path: flag.Arg(0)}
+	if err := ui.Run(context.Background(), opts); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -83,9 +96,13 @@ func showHelp() {
 	fmt.Println("Kubernetes Commander (kc) - A TUI for Kubernetes")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  kc [flags]")
+	fmt.Println("  kc [flags] [path]")
+	fmt.Println()
+	fmt.Println("  path        Start in this folder, e.g. /namespaces/kube-system/pods")
 	fmt.Println()
 	fmt.Println("Flags:")
+	fmt.Println("  -kubeconfig <file>  Use this kubeconfig only (default: $KUBECONFIG, else ~/.kube)")
+	fmt.Println("  -snapshot <dir>  Browse a directory of YAML/JSON dumps read-only")
 	fmt.Println("  -version    Show version information")
 	fmt.Println("  -help       Show this help message")
 	fmt.Println()
@@ -100,6 +117,8 @@ func showHelp() {
 	fmt.Println("  F8          Delete resource")
 	fmt.Println("  F9          Context menu")
 	fmt.Println("  F10         Quit")
+	fmt.Println("  Alt+Enter   Exec into container")
+	fmt.Println("  Alt+P       Port-forward to pod or service")
 	fmt.Println("  Ctrl+O      Toggle terminal")
 	fmt.Println("  Tab         Switch panels")
 	fmt.Println("  Ctrl+C      Quit")
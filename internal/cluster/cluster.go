@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
@@ -34,14 +35,35 @@ type Cluster struct {
 	baseMapper metamapper.ResettableRESTMapper
 	mapper     metamapper.RESTMapper
 	dyn        dynamic.Interface
+	meta       metadata.Interface
 
 	// tableCache serves Row/RowList objects backed by server-side Table responses.
 	tableCache crcache.Cache
 
+	// ctx ends with Stop; it bounds the loops and informers the cluster runs
+	// on its own.
+	ctx     context.Context
 	cancel  context.CancelFunc
 	refresh time.Duration
+
+	// key identifies the cluster in the Pool that created it.
+	key Key
+
+	// resources caches the preferred resource set and notifies subscribers on change.
+	resources resourceState
+
+	// watched records the informers created so far, see WatchedInformers.
+	watched watchedState
+
+	// filtered holds informers of server-side selected objects, e.g. the pods
+	// of a node, see PodInformerForNode.
+	filtered filteredState
 }
 
+// Key returns the kubeconfig path and context the cluster was acquired for
+// from a Pool. It is zero for clusters created directly via New.
+func (c *Cluster) Key() Key { return c.key }
+
 // Option configures Cluster.
 type Option func(*options)
 type options struct {
@@ -75,13 +97,16 @@ func New(cfg *rest.Config, opts ...Option) (*Cluster, error) {
 	// We initialize discovery/mapper lazily in ensureDiscovery() before first use.
 	cl, err := crcluster.New(cfg, func(co *crcluster.Options) {
 		co.Scheme = o.scheme
+		// Metadata-only informers feed counts and dependents and never show
+		// managedFields. Full objects keep what the server returned.
+		co.Cache.DefaultTransform = stripMetadataManagedFields
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	c := &Cluster{Cluster: cl, cancel: cancel, refresh: o.refresh}
+	c := &Cluster{Cluster: cl, ctx: ctx, cancel: cancel, refresh: o.refresh}
 	// Pre-initialize discovery/mapper/dynamic client lazily so methods can be used early.
 	_ = c.ensureDiscovery()
 
@@ -101,7 +126,7 @@ func New(cfg *rest.Config, opts ...Option) (*Cluster, error) {
 
 // ensureDiscovery initializes discovery, RESTMapper, and dynamic client lazily.
 func (c *Cluster) ensureDiscovery() error {
-	if c.mapper != nil && c.baseMapper != nil && c.disco != nil && c.dyn != nil {
+	if c.mapper != nil && c.baseMapper != nil && c.disco != nil && c.dyn != nil && c.meta != nil {
 		return nil
 	}
 	dc, err := discovery.NewDiscoveryClientForConfig(c.GetConfig())
@@ -115,10 +140,15 @@ func (c *Cluster) ensureDiscovery() error {
 	if err != nil {
 		return err
 	}
+	md, err := metadata.NewForConfig(c.GetConfig())
+	if err != nil {
+		return err
+	}
 	c.disco = cached
 	c.baseMapper = base
 	c.mapper = expander
 	c.dyn = dyn
+	c.meta = md
 	return nil
 }
 
@@ -137,6 +167,7 @@ func (c *Cluster) refreshLoop(ctx context.Context) {
 			if c.baseMapper != nil {
 				c.baseMapper.Reset()
 			}
+			c.refreshResources(ctx)
 		}
 	}
 }
@@ -222,6 +253,7 @@ func (c *Cluster) ListRowsByGVR(ctx context.Context, gvr schema.GroupVersionReso
 			return nil, err
 		}
 	}
+	c.watchRows(ctx, gvk, gvr, namespace)
 	return rows, nil
 }
 
@@ -238,9 +270,38 @@ func (c *Cluster) GetRowByGVR(ctx context.Context, gvr schema.GroupVersionResour
 	if err := c.tableCache.Get(ctx, key, row); err != nil {
 		return nil, err
 	}
+	c.watchRows(ctx, gvk, gvr, namespace)
 	return row, nil
 }
 
+// RowInformerByGVR returns the shared informer backing ListRowsByGVR for the
+// given resource and namespace (empty for all namespaces or cluster scope).
+// Event handlers receive *tablecache.Row objects.
+func (c *Cluster) RowInformerByGVR(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (crcache.Informer, error) {
+	_ = c.ensureDiscovery()
+	gvk, err := c.RESTMapper().KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	row := tablecache.NewRow(gvk)
+	row.SetNamespace(namespace)
+	informer, err := c.tableCache.GetInformer(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	c.watch(watchRows, gvk, gvr, namespace, informer)
+	return informer, nil
+}
+
+// watchRows records the row informer that served a read of gvr in namespace.
+func (c *Cluster) watchRows(ctx context.Context, gvk schema.GroupVersionKind, gvr schema.GroupVersionResource, namespace string) {
+	row := tablecache.NewRow(gvk)
+	row.SetNamespace(namespace)
+	if informer, err := c.tableCache.GetInformer(ctx, row); err == nil {
+		c.watch(watchRows, gvk, gvr, namespace, informer)
+	}
+}
+
 // Helpers ---------------------------------------------------------------------
 
 // GVKToGVR maps a Kind to its resource using the RESTMapper.
@@ -274,20 +335,38 @@ func (c *Cluster) ListByGVR(ctx context.Context, gvr schema.GroupVersionResource
 	return ul, nil
 }
 
+// CachedListByGVR lists objects from the shared Unstructured informer of gvr,
+// starting it on first use and waiting for it to sync. Unlike ListByGVR, which
+// the client serves with a live LIST, repeated reads cost no API requests.
+func (c *Cluster) CachedListByGVR(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+	_ = c.ensureDiscovery()
+	k, err := c.RESTMapper().KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	ul := &unstructured.UnstructuredList{}
+	ul.SetGroupVersionKind(schema.GroupVersionKind{Group: k.Group, Version: k.Version, Kind: k.Kind + "List"})
+	if err := c.GetCache().List(ctx, ul, crclient.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return ul, nil
+}
+
 // HasAnyByGVR performs a lightweight peek (limit=1) to determine if at least one object exists for the GVR.
 // It avoids starting informers so callers can filter empty resource groups cheaply.
 func (c *Cluster) HasAnyByGVR(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (bool, error) {
 	if err := c.ensureDiscovery(); err != nil {
 		return false, err
 	}
-	res := c.dyn.Resource(gvr)
-	var iface dynamic.ResourceInterface
+	res := c.meta.Resource(gvr)
+	var iface metadata.ResourceInterface
 	if namespace != "" {
 		iface = res.Namespace(namespace)
 	} else {
 		iface = res
 	}
 	// Leave ResourceVersion empty to avoid forcing a quorum read; the apiserver may serve from cache.
+	// Only metadata is requested so large objects (Secrets, ConfigMaps) are not transferred.
 	list, err := iface.List(ctx, metav1.ListOptions{Limit: 1, ResourceVersion: ""})
 	if err != nil {
 		return false, err
@@ -295,6 +374,24 @@ func (c *Cluster) HasAnyByGVR(ctx context.Context, gvr schema.GroupVersionResour
 	return len(list.Items) > 0, nil
 }
 
+// MetadataInformerByGVR returns the shared metadata-only informer for gvr.
+// Its store holds *metav1.PartialObjectMetadata objects across all namespaces.
+func (c *Cluster) MetadataInformerByGVR(ctx context.Context, gvr schema.GroupVersionResource, opts ...crcache.InformerGetOption) (crcache.Informer, error) {
+	_ = c.ensureDiscovery()
+	gvk, err := c.RESTMapper().KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+	informer, err := c.GetCache().GetInformer(ctx, obj, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.watch(watchMetadata, gvk, gvr, "", informer)
+	return informer, nil
+}
+
 // GetByGVR fetches one object as Unstructured using the cache-backed client.
 func (c *Cluster) GetByGVR(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
 	_ = c.ensureDiscovery()
@@ -311,6 +408,23 @@ func (c *Cluster) GetByGVR(ctx context.Context, gvr schema.GroupVersionResource,
 	return u, nil
 }
 
+// CachedGetByGVR is GetByGVR served from the shared Unstructured informer of
+// gvr, like CachedListByGVR.
+func (c *Cluster) CachedGetByGVR(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	_ = c.ensureDiscovery()
+	k, err := c.RESTMapper().KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(k)
+	key := crclient.ObjectKey{Namespace: namespace, Name: name}
+	if err := c.GetCache().Get(ctx, key, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
 // ResourceInfo describes a discoverable API resource kind.
 type ResourceInfo struct {
 	GVK        schema.GroupVersionKind
@@ -319,18 +433,35 @@ type ResourceInfo struct {
 	Verbs      []string
 }
 
-// GetResourceInfos returns API resource infos via discovery.
-func (c *Cluster) GetResourceInfos() ([]ResourceInfo, error) {
+// discoverResourceInfos returns the preferred and all served API resource
+// infos via discovery. Group versions that fail discovery (e.g. an unavailable
+// aggregated API) do not fail the whole call; they are returned as degraded
+// groups next to the partial result.
+func (c *Cluster) discoverResourceInfos() (preferred, served []ResourceInfo, degraded []DegradedGroup, err error) {
 	dc, err := discovery.NewDiscoveryClientForConfig(c.GetConfig())
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	lists, err := dc.ServerPreferredResources()
+	// Both views are computed from one round of discovery requests.
+	cached := memory.NewMemCacheClient(dc)
+	preferredLists, err := cached.ServerPreferredResources()
+	degraded, err = degradedGroups(err)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	_, servedLists, err := cached.ServerGroupsAndResources()
+	if _, err := degradedGroups(err); err != nil {
+		return nil, nil, nil, err
+	}
+	return resourceInfosFromLists(preferredLists), resourceInfosFromLists(servedLists), degraded, nil
+}
+
+func resourceInfosFromLists(lists []*metav1.APIResourceList) []ResourceInfo {
 	var out []ResourceInfo
 	for _, l := range lists {
+		if l == nil {
+			continue
+		}
 		gv, err := schema.ParseGroupVersion(l.GroupVersion)
 		if err != nil {
 			continue
@@ -350,5 +481,15 @@ func (c *Cluster) GetResourceInfos() ([]ResourceInfo, error) {
 			})
 		}
 	}
-	return out, nil
+	return out
+}
+
+// stripMetadataManagedFields drops metadata.managedFields from the objects of
+// metadata-only informers to keep their stores small. Other objects are left
+// untouched.
+func stripMetadataManagedFields(obj interface{}) (interface{}, error) {
+	if pom, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		pom.ManagedFields = nil
+	}
+	return obj, nil
 }
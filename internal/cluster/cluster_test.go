@@ -0,0 +1,30 @@
+package cluster
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestStripMetadataManagedFields(t *testing.T) {
+	managed := []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+
+	pom := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "a", ManagedFields: managed}}
+	if _, err := stripMetadataManagedFields(pom); err != nil {
+		t.Fatal(err)
+	}
+	if pom.ManagedFields != nil {
+		t.Errorf("metadata object kept managedFields %v", pom.ManagedFields)
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetName("b")
+	u.SetManagedFields(managed)
+	if _, err := stripMetadataManagedFields(u); err != nil {
+		t.Fatal(err)
+	}
+	if len(u.GetManagedFields()) != 1 {
+		t.Errorf("full object lost managedFields")
+	}
+}
@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+var eventGVR = schema.GroupVersionResource{Group: "events.k8s.io", Version: "v1", Resource: "events"}
+
+// eventsNamespace returns the namespace holding the events of objects in
+// namespace. Event recorders put the events of cluster-scoped objects, e.g.
+// nodes, into the default namespace.
+func eventsNamespace(namespace string) string {
+	if namespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return namespace
+}
+
+// EventInformer returns the informer of the events.k8s.io/v1 Events regarding
+// objects in namespace, or cluster-scoped objects when empty, starting it on
+// first use. It only watches the one namespace the events live in. Event
+// handlers receive *unstructured.Unstructured events. The informer runs until
+// every caller called release.
+func (c *Cluster) EventInformer(namespace string) (informer toolscache.SharedIndexInformer, release func(), err error) {
+	return c.filteredInformerFor(eventGVR, eventsNamespace(namespace), "", "")
+}
+
+// ListEventsRegarding lists the events.k8s.io/v1 Events regarding the object
+// with the given UID in namespace, empty for cluster-scoped objects, see
+// EventInformer.
+func (c *Cluster) ListEventsRegarding(ctx context.Context, namespace string, uid types.UID) (*unstructured.UnstructuredList, error) {
+	list, err := c.listFiltered(ctx, eventGVR, eventsNamespace(namespace), "", eventGVR.GroupVersion().WithKind("EventList"))
+	if err != nil {
+		return nil, err
+	}
+	items := list.Items[:0]
+	for _, item := range list.Items {
+		if regarding, _, _ := unstructured.NestedString(item.Object, "regarding", "uid"); regarding == string(uid) {
+			items = append(items, item)
+		}
+	}
+	list.Items = items
+	return list, nil
+}
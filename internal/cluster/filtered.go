@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// filteredSyncPollInterval is how often a read checks a syncing filtered
+// informer for list errors.
+const filteredSyncPollInterval = 50 * time.Millisecond
+
+// filteredKey identifies a filtered informer by resource, namespace, field
+// and label selector.
+type filteredKey struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	fields    string
+	labels    string
+}
+
+// filteredState holds the filtered informers in use.
+type filteredState struct {
+	mu        sync.Mutex
+	informers map[filteredKey]*filteredInformer
+}
+
+// filteredInformer watches the Unstructured objects of a resource selected
+// server-side by namespace, field and label selector, and remembers the last list or
+// watch error. It runs while it is referenced.
+type filteredInformer struct {
+	toolscache.SharedIndexInformer
+
+	// refs and stop are guarded by filteredState.mu.
+	refs int
+	stop context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+func (i *filteredInformer) setErr(err error) {
+	i.mu.Lock()
+	i.err = err
+	i.mu.Unlock()
+}
+
+func (i *filteredInformer) lastErr() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.err
+}
+
+// filteredInformerFor returns the informer of the gvr objects in namespace
+// (all namespaces when empty) matching the field and label selectors, either
+// of which may be empty, starting it on
+// first use. Unlike the cache informers, it only transfers and keeps the
+// selected objects. The informer is stopped when the last holder called
+// release, or when the cluster is stopped.
+func (c *Cluster) filteredInformerFor(gvr schema.GroupVersionResource, namespace, fields, labels string) (inf *filteredInformer, release func(), err error) {
+	if err := c.ensureDiscovery(); err != nil {
+		return nil, nil, err
+	}
+	key := filteredKey{gvr: gvr, namespace: namespace, fields: fields, labels: labels}
+	s := &c.filtered
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inf, ok := s.informers[key]
+	if !ok {
+		inf = &filteredInformer{}
+		inf.SharedIndexInformer = dynamicinformer.NewFilteredDynamicInformer(c.dyn, gvr, namespace, 0, toolscache.Indexers{},
+			func(opts *metav1.ListOptions) { opts.FieldSelector, opts.LabelSelector = fields, labels }).Informer()
+		_ = inf.SetWatchErrorHandler(func(r *toolscache.Reflector, err error) {
+			inf.setErr(err)
+			toolscache.DefaultWatchErrorHandler(r, err)
+		})
+		if s.informers == nil {
+			s.informers = map[filteredKey]*filteredInformer{}
+		}
+		s.informers[key] = inf
+		ctx, cancel := context.WithCancel(c.ctx)
+		inf.stop = cancel
+		go inf.Run(ctx.Done())
+	}
+	inf.refs++
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if inf.refs--; inf.refs == 0 {
+				inf.stop()
+				delete(s.informers, key)
+			}
+		})
+	}
+	return inf, release, nil
+}
+
+// listFiltered lists the objects of the filtered informer as a list of kind,
+// holding the informer for the duration of the read.
+func (c *Cluster) listFiltered(ctx context.Context, gvr schema.GroupVersionResource, namespace, fields string, kind schema.GroupVersionKind) (*unstructured.UnstructuredList, error) {
+	inf, release, err := c.filteredInformerFor(gvr, namespace, fields, "")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return inf.list(ctx, kind)
+}
+
+// list waits for the informer to sync and returns copies of its objects as a
+// list of kind.
+func (i *filteredInformer) list(ctx context.Context, kind schema.GroupVersionKind) (*unstructured.UnstructuredList, error) {
+	for !i.HasSynced() {
+		if err := i.lastErr(); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(filteredSyncPollInterval):
+		}
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(kind)
+	for _, obj := range i.GetStore().List() {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			list.Items = append(list.Items, *u.DeepCopy())
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kctesting "github.com/sttts/kc/internal/testing"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+)
+
+func TestFilteredInformerStopsWithLastRelease(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c, err := New(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	first, releaseFirst, err := c.PodInformerForNode("node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, releaseSecond, err := c.PodInformerForNode("node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatalf("expected one informer per node")
+	}
+	releaseFirst()
+	releaseFirst()
+	if first.IsStopped() {
+		t.Fatalf("informer stopped while still held")
+	}
+	releaseSecond()
+	kctesting.Eventually(t, time.Second, 10*time.Millisecond, first.IsStopped, "informer not stopped after the last release")
+
+	third, releaseThird, err := c.PodInformerForNode("node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer releaseThird()
+	if third == first {
+		t.Fatalf("expected a new informer after the last release")
+	}
+
+	web, releaseWeb, err := c.PodInformerForSelector("default", labels.SelectorFromSet(labels.Set{"app": "web"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer releaseWeb()
+	if web == third {
+		t.Fatalf("expected an informer per label selector")
+	}
+}
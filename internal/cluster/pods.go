@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+var podGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+// podsOnNode selects the pods scheduled to node.
+func podsOnNode(node string) string {
+	return fields.OneTermEqualSelector("spec.nodeName", node).String()
+}
+
+// PodInformerForNode returns the informer of the pods scheduled to node,
+// starting it on first use. Like `kubectl describe node` it selects the pods
+// server-side by spec.nodeName, so only that node's pods are transferred and
+// kept. Event handlers receive *unstructured.Unstructured pods. The informer
+// runs until every caller called release.
+func (c *Cluster) PodInformerForNode(node string) (informer toolscache.SharedIndexInformer, release func(), err error) {
+	return c.filteredInformerFor(podGVR, "", podsOnNode(node), "")
+}
+
+// PodInformerForSelector returns the informer of the pods in namespace
+// matching selector, e.g. the pods of a workload, starting it on first use.
+// Only the selected pods are transferred and kept. Event handlers receive
+// *unstructured.Unstructured pods. The informer runs until every caller
+// called release.
+func (c *Cluster) PodInformerForSelector(namespace string, selector labels.Selector) (informer toolscache.SharedIndexInformer, release func(), err error) {
+	return c.filteredInformerFor(podGVR, namespace, "", selector.String())
+}
+
+// ListPodsOnNode lists the pods scheduled to node across all namespaces from
+// the node's pod informer, see PodInformerForNode.
+func (c *Cluster) ListPodsOnNode(ctx context.Context, node string) (*unstructured.UnstructuredList, error) {
+	return c.listFiltered(ctx, podGVR, "", podsOnNode(node), podGVR.GroupVersion().WithKind("PodList"))
+}
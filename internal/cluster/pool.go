@@ -2,10 +2,15 @@ package cluster
 
 import (
     "context"
+    "errors"
     "fmt"
+    "path/filepath"
+    "strings"
     "sync"
     "time"
 
+    "k8s.io/client-go/discovery"
+    "k8s.io/client-go/rest"
     "k8s.io/client-go/tools/clientcmd"
 )
 
@@ -15,25 +20,63 @@ type Key struct {
     ContextName    string
 }
 
+// Phase is the connection phase of a pooled cluster.
+type Phase string
+
+const (
+    // PhaseConnecting means the cluster was created and has not answered a probe yet.
+    PhaseConnecting Phase = "connecting"
+    // PhaseSynced means the API server answers and discovery succeeded.
+    PhaseSynced Phase = "synced"
+    // PhaseDegraded means the API server answers but some API groups fail discovery.
+    PhaseDegraded Phase = "degraded"
+    // PhaseFailed means the API server is unreachable or the cluster stopped.
+    PhaseFailed Phase = "failed"
+)
+
+// State reports the connection health of a pooled cluster.
+type State struct {
+    Phase         Phase
+    Err           error         // last error while degraded or failed
+    ServerVersion string        // git version reported by /version
+    RTT           time.Duration // round trip of the last successful probe
+    Since         time.Time     // when Phase was entered
+}
+
 type entry struct {
     key      Key
     cl       *Cluster
     cancel   context.CancelFunc
     lastUsed time.Time
-    ready    sync.Once
-    err      error
+    state    State
 }
 
 // Pool manages controller-runtime clusters per kubeconfig+context with idle eviction.
+// Every pooled cluster is probed in the background; its State is available via
+// State and published to subscribers whenever it changes.
 type Pool struct {
     mu      sync.RWMutex
     ttl     time.Duration
     closing chan struct{}
     started bool
     items   map[Key]*entry
+
+    nextSub int
+    subs    map[int]func(Key, State)
+
+    probeInterval time.Duration
+    probeTimeout  time.Duration
+    minBackoff    time.Duration
+    maxBackoff    time.Duration
 }
 
-func NewPool(ttl time.Duration) *Pool { return &Pool{ttl: ttl, closing: make(chan struct{}), items: map[Key]*entry{}} }
+func NewPool(ttl time.Duration) *Pool {
+    return &Pool{
+        ttl: ttl, closing: make(chan struct{}), items: map[Key]*entry{}, subs: map[int]func(Key, State){},
+        probeInterval: 15 * time.Second, probeTimeout: 5 * time.Second,
+        minBackoff: time.Second, maxBackoff: time.Minute,
+    }
+}
 
 func (p *Pool) Start() {
     p.mu.Lock()
@@ -46,7 +89,7 @@ func (p *Pool) Start() {
 func (p *Pool) Stop() {
     close(p.closing)
     p.mu.Lock(); defer p.mu.Unlock()
-    for k, e := range p.items { e.cancel(); delete(p.items, k) }
+    for k, e := range p.items { e.cancel(); e.cl.Stop(); delete(p.items, k) }
 }
 
 // Get returns a running controller-runtime Cluster for the key, starting it if needed.
@@ -56,22 +99,190 @@ func (p *Pool) Get(ctx context.Context, k Key) (*Cluster, error) {
         e.lastUsed = time.Now(); p.mu.Unlock(); return e.cl, nil
     }
     cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-        &clientcmd.ClientConfigLoadingRules{ExplicitPath: k.KubeconfigPath},
+        loadingRules(k.KubeconfigPath),
         &clientcmd.ConfigOverrides{CurrentContext: k.ContextName},
     ).ClientConfig()
     if err != nil { p.mu.Unlock(); return nil, fmt.Errorf("client config: %w", err) }
-    cl, err := New(cfg)
+    cl, err := New(rest.CopyConfig(cfg))
     if err != nil { p.mu.Unlock(); return nil, fmt.Errorf("cluster: %w", err) }
+    cl.key = k
     cctx, cancel := context.WithCancel(ctx)
-    e := &entry{key: k, cl: cl, cancel: cancel, lastUsed: time.Now()}
+    e := &entry{key: k, cl: cl, cancel: cancel, lastUsed: time.Now(), state: State{Phase: PhaseConnecting, Since: time.Now()}}
     p.items[k] = e
+    st := e.state
     p.mu.Unlock()
-    go func(){ e.ready.Do(func(){ e.err = cl.Start(cctx) }) }()
+    p.publish(k, st)
+    go p.run(cctx, e, cfg)
     return cl, nil
 }
 
+// loadingRules loads the context's own kubeconfig first, so that its stanzas
+// win, followed by the other $KUBECONFIG files, which may hold the cluster or
+// user the context refers to.
+func loadingRules(path string) *clientcmd.ClientConfigLoadingRules {
+    precedence := []string{path}
+    for _, p := range clientcmd.NewDefaultClientConfigLoadingRules().Precedence {
+        if filepath.Clean(p) != filepath.Clean(path) { precedence = append(precedence, p) }
+    }
+    return &clientcmd.ClientConfigLoadingRules{Precedence: precedence}
+}
+
+// Lookup returns the pooled cluster for k without starting one or marking it
+// used. After a cluster stopped unexpectedly the pool replaces it, so callers
+// holding an older *Cluster for k should rebind.
+func (p *Pool) Lookup(k Key) (*Cluster, bool) {
+    p.mu.RLock(); defer p.mu.RUnlock()
+    e, ok := p.items[k]
+    if !ok { return nil, false }
+    return e.cl, true
+}
+
 func (p *Pool) Touch(k Key) { p.mu.Lock(); if e, ok := p.items[k]; ok { e.lastUsed = time.Now() }; p.mu.Unlock() }
 
+// Remove stops and drops the pooled cluster for k, e.g. after its context was
+// removed from the kubeconfig. It reports whether a cluster was pooled.
+func (p *Pool) Remove(k Key) bool {
+    p.mu.Lock()
+    e, ok := p.items[k]
+    if ok { delete(p.items, k) }
+    p.mu.Unlock()
+    if ok { e.cancel(); e.cl.Stop() }
+    return ok
+}
+
+// State returns the connection state of the pooled cluster for k.
+func (p *Pool) State(k Key) (State, bool) {
+    p.mu.RLock(); defer p.mu.RUnlock()
+    e, ok := p.items[k]
+    if !ok { return State{}, false }
+    return e.state, true
+}
+
+// Subscribe registers fn to be called whenever the state of a pooled cluster
+// changes. Callbacks run on the pool's probe goroutines. The returned function
+// removes the subscription.
+func (p *Pool) Subscribe(fn func(Key, State)) (unsubscribe func()) {
+    p.mu.Lock(); defer p.mu.Unlock()
+    id := p.nextSub
+    p.nextSub++
+    p.subs[id] = fn
+    return func() { p.mu.Lock(); delete(p.subs, id); p.mu.Unlock() }
+}
+
+// run starts the cluster and probes it until ctx is done. Failed probes are
+// retried with exponential backoff. A cluster whose Start returns early is
+// stopped and rebuilt from cfg with the same backoff.
+func (p *Pool) run(ctx context.Context, e *entry, cfg *rest.Config) {
+    done := startCluster(ctx, p.cluster(e))
+    backoff := p.minBackoff
+    for {
+        st := p.probe(ctx, p.cluster(e), cfg)
+        wait := p.probeInterval
+        if st.Phase == PhaseFailed {
+            wait, backoff = backoff, min(2*backoff, p.maxBackoff)
+        } else {
+            backoff = p.minBackoff
+        }
+        if ctx.Err() != nil { return }
+        p.setState(e, st)
+        select {
+        case <-ctx.Done():
+            return
+        case err := <-done:
+            if ctx.Err() != nil { return }
+            if err == nil { err = errors.New("stopped unexpectedly") }
+            p.setState(e, State{Phase: PhaseFailed, Err: fmt.Errorf("cluster: %w", err)})
+            if !sleep(ctx, backoff) { return }
+            backoff = min(2*backoff, p.maxBackoff)
+            done = p.rebuild(ctx, e, cfg)
+        case <-time.After(wait):
+        }
+    }
+}
+
+// rebuild replaces the entry's cluster with a fresh one and starts it. The
+// returned channel yields the result of Start, or the construction error.
+// The entry is marked failed before, so the next successful probe publishes a
+// state change; subscribers holding the old cluster find the replacement via
+// Lookup.
+func (p *Pool) rebuild(ctx context.Context, e *entry, cfg *rest.Config) <-chan error {
+    cl, err := New(rest.CopyConfig(cfg))
+    if err != nil {
+        done := make(chan error, 1)
+        done <- err
+        return done
+    }
+    cl.key = e.key
+    p.mu.Lock()
+    old := e.cl
+    e.cl = cl
+    p.mu.Unlock()
+    old.Stop()
+    return startCluster(ctx, cl)
+}
+
+func startCluster(ctx context.Context, cl *Cluster) <-chan error {
+    done := make(chan error, 1)
+    go func() { done <- cl.Start(ctx) }()
+    return done
+}
+
+// probe measures the API server round trip via /version and checks discovery.
+// Reachable clusters with failing API groups are reported as degraded.
+func (p *Pool) probe(ctx context.Context, cl *Cluster, cfg *rest.Config) State {
+    vcfg := rest.CopyConfig(cfg)
+    vcfg.Timeout = p.probeTimeout
+    dc, err := discovery.NewDiscoveryClientForConfig(vcfg)
+    if err != nil { return State{Phase: PhaseFailed, Err: err} }
+    start := time.Now()
+    info, err := dc.ServerVersion()
+    if err != nil { return State{Phase: PhaseFailed, Err: err} }
+    st := State{Phase: PhaseSynced, ServerVersion: info.GitVersion, RTT: time.Since(start)}
+    if ctx.Err() != nil { return st }
+    if _, err := cl.GetResourceInfos(); err != nil {
+        st.Phase, st.Err = PhaseFailed, fmt.Errorf("discovery: %w", err)
+        return st
+    }
+    if degraded := cl.DegradedGroups(); len(degraded) > 0 {
+        names := make([]string, 0, len(degraded))
+        for _, g := range degraded { names = append(names, g.GroupVersion.String()) }
+        st.Phase, st.Err = PhaseDegraded, fmt.Errorf("discovery failed for %s", strings.Join(names, ", "))
+    }
+    return st
+}
+
+func (p *Pool) cluster(e *entry) *Cluster { p.mu.RLock(); defer p.mu.RUnlock(); return e.cl }
+
+// setState records st for the entry and publishes it when the phase, error or
+// server version changed. RTT updates alone are recorded silently.
+func (p *Pool) setState(e *entry, st State) {
+    p.mu.Lock()
+    prev := e.state
+    if st.Phase == prev.Phase { st.Since = prev.Since } else { st.Since = time.Now() }
+    e.state = st
+    changed := st.Phase != prev.Phase || errString(st.Err) != errString(prev.Err) || st.ServerVersion != prev.ServerVersion
+    p.mu.Unlock()
+    if changed { p.publish(e.key, st) }
+}
+
+func (p *Pool) publish(k Key, st State) {
+    p.mu.RLock()
+    subs := make([]func(Key, State), 0, len(p.subs))
+    for _, fn := range p.subs { subs = append(subs, fn) }
+    p.mu.RUnlock()
+    for _, fn := range subs { fn(k, st) }
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+    t := time.NewTimer(d); defer t.Stop()
+    select {
+    case <-ctx.Done():
+        return false
+    case <-t.C:
+        return true
+    }
+}
+
 func (p *Pool) evictLoop() {
     t := time.NewTicker(30 * time.Second); defer t.Stop()
     for {
@@ -88,6 +299,6 @@ func (p *Pool) evictIdle() {
     cutoff := time.Now().Add(-p.ttl)
     p.mu.Lock(); defer p.mu.Unlock()
     for k, e := range p.items {
-        if e.lastUsed.Before(cutoff) { e.cancel(); delete(p.items, k) }
+        if e.lastUsed.Before(cutoff) { e.cancel(); e.cl.Stop(); delete(p.items, k) }
     }
 }
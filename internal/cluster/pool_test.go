@@ -0,0 +1,162 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestPoolReportsConnectionState(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/version":
+			_, _ = w.Write([]byte(`{"major":"1","minor":"29","gitVersion":"v1.29.0"}`))
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/api/v1":
+			_, _ = w.Write([]byte(`{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"pods","namespaced":true,"kind":"Pod","verbs":["list","watch"]}]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: `+srv.URL+`
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPool(time.Minute)
+	p.probeInterval, p.minBackoff, p.maxBackoff = 20*time.Millisecond, 10*time.Millisecond, 40*time.Millisecond
+	defer p.Stop()
+
+	var mu sync.Mutex
+	var phases []Phase
+	p.Subscribe(func(_ Key, st State) {
+		mu.Lock()
+		phases = append(phases, st.Phase)
+		mu.Unlock()
+	})
+	waitForPhase := func(k Key, want Phase) State {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			if st, ok := p.State(k); ok && st.Phase == want {
+				return st
+			}
+			if time.Now().After(deadline) {
+				st, _ := p.State(k)
+				t.Fatalf("timed out waiting for %s, state is %+v", want, st)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	k := Key{KubeconfigPath: kubeconfig, ContextName: "test"}
+	cl, err := p.Get(t.Context(), k)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cl.Key() != k {
+		t.Fatalf("expected cluster to carry its pool key, got %+v", cl.Key())
+	}
+	if got, ok := p.Lookup(k); !ok || got != cl {
+		t.Fatalf("Lookup returned %p, %v; want the pooled cluster", got, ok)
+	}
+
+	st := waitForPhase(k, PhaseSynced)
+	if st.ServerVersion != "v1.29.0" || st.RTT <= 0 {
+		t.Fatalf("unexpected synced state %+v", st)
+	}
+
+	down.Store(true)
+	if st := waitForPhase(k, PhaseFailed); st.Err == nil {
+		t.Fatalf("expected failed state to carry the probe error")
+	}
+	down.Store(false)
+	waitForPhase(k, PhaseSynced)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(phases) < 4 || phases[0] != PhaseConnecting {
+		t.Fatalf("unexpected published phases %v", phases)
+	}
+	if !p.Remove(k) {
+		t.Fatal("Remove did not find the pooled cluster")
+	}
+	if _, ok := p.Lookup(k); ok {
+		t.Fatal("Lookup found a removed cluster")
+	}
+}
+
+func TestPoolLoadsContextFromAllKubeconfigs(t *testing.T) {
+	dir := t.TempDir()
+	contexts := filepath.Join(dir, "contexts")
+	users := filepath.Join(dir, "users")
+	if err := os.WriteFile(contexts, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: me
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(users, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://other.example.com
+users:
+- name: me
+  user:
+    token: secret
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", users+string(os.PathListSeparator)+contexts)
+
+	rules := loadingRules(contexts)
+	if want := []string{contexts, users}; len(rules.Precedence) != 2 || rules.Precedence[0] != want[0] || rules.Precedence[1] != want[1] {
+		t.Fatalf("precedence %v, want %v", rules.Precedence, want)
+	}
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: "dev"}).ClientConfig()
+	if err != nil {
+		t.Fatalf("client config: %v", err)
+	}
+	if cfg.Host != "https://dev.example.com" || cfg.BearerToken != "secret" {
+		t.Fatalf("host %q, token %q", cfg.Host, cfg.BearerToken)
+	}
+}
@@ -0,0 +1,274 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/sttts/kc/internal/subscribers"
+	tablecache "github.com/sttts/kc/internal/tablecache"
+)
+
+// ResourceChange describes how the preferred resource set changed between two
+// discovery refreshes. A resource whose kind, scope or verbs changed appears in
+// both lists. DegradedChanged is set when the set of group versions failing
+// discovery changed, ServedChanged when any served version (preferred or not)
+// changed.
+type ResourceChange struct {
+	Added           []ResourceInfo
+	Removed         []ResourceInfo
+	DegradedChanged bool
+	ServedChanged   bool
+}
+
+// Empty reports whether the change carries no additions, removals, degraded group or served version updates.
+func (c ResourceChange) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && !c.DegradedChanged && !c.ServedChanged
+}
+
+// DegradedGroup is an API group version whose discovery failed, typically an
+// aggregated API whose backing service is unavailable.
+type DegradedGroup struct {
+	GroupVersion schema.GroupVersion
+	Err          error
+}
+
+// resourceState holds the last discovered resource set and change subscribers.
+type resourceState struct {
+	mu       sync.Mutex
+	infos    []ResourceInfo
+	served   []ResourceInfo
+	degraded []DegradedGroup
+	known    bool
+	subs     subscribers.List[ResourceChange]
+}
+
+// SubscribeResources registers fn to be called after a discovery refresh
+// changed the preferred resource set. Callbacks run on the refresh goroutine
+// after the new set is visible through GetResourceInfos. The returned function
+// removes the subscription.
+func (c *Cluster) SubscribeResources(fn func(ResourceChange)) (unsubscribe func()) {
+	return c.resources.subs.Subscribe(fn)
+}
+
+// GetResourceInfos returns the preferred API resources. The set is discovered
+// on first use and kept current by the refresh loop. Resources of group
+// versions that failed discovery are missing; see DegradedGroups.
+func (c *Cluster) GetResourceInfos() ([]ResourceInfo, error) {
+	if err := c.ensureResources(); err != nil {
+		return nil, err
+	}
+	s := &c.resources
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.infos), nil
+}
+
+// GetServedResourceInfos returns the API resources of every served version,
+// not only the preferred one, in discovery order. It is kept current together
+// with GetResourceInfos.
+func (c *Cluster) GetServedResourceInfos() ([]ResourceInfo, error) {
+	if err := c.ensureResources(); err != nil {
+		return nil, err
+	}
+	s := &c.resources
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.served), nil
+}
+
+// DegradedGroups returns the group versions that failed the last discovery,
+// sorted by group version. The refresh loop re-probes them on every interval.
+func (c *Cluster) DegradedGroups() []DegradedGroup {
+	if err := c.ensureResources(); err != nil {
+		return nil
+	}
+	s := &c.resources
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.degraded)
+}
+
+// ensureResources runs the initial discovery unless a snapshot exists.
+func (c *Cluster) ensureResources() error {
+	s := &c.resources
+	s.mu.Lock()
+	known := s.known
+	s.mu.Unlock()
+	if known {
+		return nil
+	}
+
+	infos, served, degraded, err := c.discoverResourceInfos()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if !s.known {
+		s.infos, s.served, s.degraded, s.known = infos, served, degraded, true
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// refreshResources re-runs discovery, publishes the difference to subscribers
+// and stops informers of resources that went away.
+func (c *Cluster) refreshResources(ctx context.Context) {
+	infos, served, degraded, err := c.discoverResourceInfos()
+	if err != nil {
+		return
+	}
+	c.applyResourceInfos(ctx, infos, served, degraded)
+}
+
+// applyResourceInfos installs infos, served and degraded as the current
+// resource set and publishes the difference to the previous one. Resources of
+// group versions that are degraded now are carried over from the previous set,
+// so a flaky aggregated API does not tear down open views and their informers.
+func (c *Cluster) applyResourceInfos(ctx context.Context, infos, served []ResourceInfo, degraded []DegradedGroup) {
+	s := &c.resources
+	s.mu.Lock()
+	if !s.known {
+		// Nobody looked at the catalog yet; there is nothing to diff against.
+		s.infos, s.served, s.degraded, s.known = infos, served, degraded, true
+		s.mu.Unlock()
+		return
+	}
+	infos = carryOverDegraded(s.infos, infos, degraded)
+	served = carryOverDegraded(s.served, served, degraded)
+	change := diffResourceInfos(s.infos, infos)
+	change.DegradedChanged = !sameDegradedGroups(s.degraded, degraded)
+	servedChange := diffResourceInfos(s.served, served)
+	change.ServedChanged = !servedChange.Empty()
+	s.infos, s.served, s.degraded = infos, served, degraded
+	s.mu.Unlock()
+
+	if change.Empty() {
+		return
+	}
+	// Informers may exist for non-preferred versions too (e.g. opened via
+	// the group hierarchy), so removals of either set stop them.
+	readded := make(map[schema.GroupVersionKind]bool, len(change.Added)+len(servedChange.Added))
+	for _, info := range slices.Concat(change.Added, servedChange.Added) {
+		readded[info.GVK] = true
+	}
+	removed := make(map[schema.GroupVersionKind]bool, len(change.Removed)+len(servedChange.Removed))
+	for _, info := range slices.Concat(change.Removed, servedChange.Removed) {
+		if !readded[info.GVK] && !removed[info.GVK] {
+			removed[info.GVK] = true
+			c.removeInformers(ctx, info)
+		}
+	}
+	s.subs.Notify(change)
+}
+
+// removeInformers stops every cache informer kept for the resource: object,
+// metadata-only and table rows across all namespaces.
+func (c *Cluster) removeInformers(ctx context.Context, info ResourceInfo) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(info.GVK)
+	_ = c.GetCache().RemoveInformer(ctx, obj)
+
+	meta := &metav1.PartialObjectMetadata{}
+	meta.SetGroupVersionKind(info.GVK)
+	_ = c.GetCache().RemoveInformer(ctx, meta)
+
+	_ = c.tableCache.RemoveInformer(ctx, tablecache.NewRow(info.GVK))
+
+	c.unwatch(info.GVK)
+}
+
+func diffResourceInfos(old, cur []ResourceInfo) ResourceChange {
+	key := func(info ResourceInfo) schema.GroupVersionResource {
+		return schema.GroupVersionResource{Group: info.GVK.Group, Version: info.GVK.Version, Resource: info.Resource}
+	}
+	prev := make(map[schema.GroupVersionResource]ResourceInfo, len(old))
+	for _, info := range old {
+		prev[key(info)] = info
+	}
+	var change ResourceChange
+	seen := make(map[schema.GroupVersionResource]bool, len(cur))
+	for _, info := range cur {
+		k := key(info)
+		seen[k] = true
+		before, ok := prev[k]
+		switch {
+		case !ok:
+			change.Added = append(change.Added, info)
+		case !sameResourceInfo(before, info):
+			change.Removed = append(change.Removed, before)
+			change.Added = append(change.Added, info)
+		}
+	}
+	for _, info := range old {
+		if !seen[key(info)] {
+			change.Removed = append(change.Removed, info)
+		}
+	}
+	return change
+}
+
+func sameResourceInfo(a, b ResourceInfo) bool {
+	if a.GVK != b.GVK || a.Resource != b.Resource || a.Namespaced != b.Namespaced {
+		return false
+	}
+	return strings.Join(a.Verbs, ",") == strings.Join(b.Verbs, ",")
+}
+
+// degradedGroups splits a discovery error into the group versions that failed.
+// Errors other than partial group failures are returned unchanged.
+func degradedGroups(err error) ([]DegradedGroup, error) {
+	if err == nil {
+		return nil, nil
+	}
+	var failed *discovery.ErrGroupDiscoveryFailed
+	if !errors.As(err, &failed) {
+		return nil, err
+	}
+	out := make([]DegradedGroup, 0, len(failed.Groups))
+	for gv, gerr := range failed.Groups {
+		out = append(out, DegradedGroup{GroupVersion: gv, Err: gerr})
+	}
+	slices.SortFunc(out, func(a, b DegradedGroup) int {
+		return strings.Compare(a.GroupVersion.String(), b.GroupVersion.String())
+	})
+	return out, nil
+}
+
+// carryOverDegraded appends the resources of degraded group versions from old
+// to cur, as discovery could not tell whether they still exist.
+func carryOverDegraded(old, cur []ResourceInfo, degraded []DegradedGroup) []ResourceInfo {
+	if len(degraded) == 0 {
+		return cur
+	}
+	failed := make(map[schema.GroupVersion]bool, len(degraded))
+	for _, g := range degraded {
+		failed[g.GroupVersion] = true
+	}
+	for _, info := range old {
+		if failed[info.GVK.GroupVersion()] {
+			cur = append(cur, info)
+		}
+	}
+	return cur
+}
+
+func sameDegradedGroups(a, b []DegradedGroup) bool {
+	return slices.EqualFunc(a, b, func(x, y DegradedGroup) bool {
+		return x.GroupVersion == y.GroupVersion && errString(x.Err) == errString(y.Err)
+	})
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
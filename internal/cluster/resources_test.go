@@ -0,0 +1,121 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+func TestDiffResourceInfos(t *testing.T) {
+	pods := ResourceInfo{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, Resource: "pods", Namespaced: true, Verbs: []string{"list", "watch"}}
+	widgets := ResourceInfo{GVK: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, Resource: "widgets", Namespaced: true, Verbs: []string{"list", "watch"}}
+	gadgets := ResourceInfo{GVK: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}, Resource: "gadgets", Verbs: []string{"list"}}
+	gadgetsWatch := gadgets
+	gadgetsWatch.Verbs = []string{"list", "watch"}
+
+	change := diffResourceInfos([]ResourceInfo{pods, widgets, gadgets}, []ResourceInfo{pods, gadgetsWatch})
+	if len(change.Removed) != 2 || change.Removed[0].Resource != "gadgets" || change.Removed[1].Resource != "widgets" {
+		t.Fatalf("unexpected removed set %+v", change.Removed)
+	}
+	if len(change.Added) != 1 || change.Added[0].Resource != "gadgets" || len(change.Added[0].Verbs) != 2 {
+		t.Fatalf("unexpected added set %+v", change.Added)
+	}
+
+	if change := diffResourceInfos([]ResourceInfo{pods}, []ResourceInfo{pods}); !change.Empty() {
+		t.Fatalf("expected no change, got %+v", change)
+	}
+}
+
+func TestApplyResourceInfosNotifiesSubscribers(t *testing.T) {
+	c := &Cluster{}
+	pods := ResourceInfo{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, Resource: "pods", Namespaced: true}
+	widgets := ResourceInfo{GVK: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, Resource: "widgets", Namespaced: true}
+
+	var changes []ResourceChange
+	unsubscribe := c.SubscribeResources(func(ch ResourceChange) { changes = append(changes, ch) })
+
+	// The first snapshot only seeds the catalog.
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods}, nil, nil)
+	if len(changes) != 0 {
+		t.Fatalf("expected no notification for the initial snapshot")
+	}
+
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods, widgets}, nil, nil)
+	if len(changes) != 1 || len(changes[0].Added) != 1 || changes[0].Added[0].Resource != "widgets" {
+		t.Fatalf("unexpected notifications %+v", changes)
+	}
+	infos, err := c.GetResourceInfos()
+	if err != nil || len(infos) != 2 {
+		t.Fatalf("expected updated catalog, got %v (err=%v)", infos, err)
+	}
+
+	unsubscribe()
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods, widgets, {GVK: schema.GroupVersionKind{Group: "x", Version: "v1", Kind: "X"}, Resource: "xs"}}, nil, nil)
+	if len(changes) != 1 {
+		t.Fatalf("expected no notification after unsubscribe")
+	}
+}
+
+func TestApplyResourceInfosDegradedGroups(t *testing.T) {
+	c := &Cluster{}
+	pods := ResourceInfo{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, Resource: "pods", Namespaced: true}
+	metrics := ResourceInfo{GVK: schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}, Resource: "pods", Namespaced: true}
+	metricsGV := schema.GroupVersion{Group: "metrics.k8s.io", Version: "v1beta1"}
+
+	var changes []ResourceChange
+	c.SubscribeResources(func(ch ResourceChange) { changes = append(changes, ch) })
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods, metrics}, nil, nil)
+
+	degraded, err := degradedGroups(&discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{
+		metricsGV: errors.New("the server is currently unable to handle the request"),
+	}})
+	if err != nil || len(degraded) != 1 || degraded[0].GroupVersion != metricsGV {
+		t.Fatalf("unexpected degraded groups %+v (err=%v)", degraded, err)
+	}
+	if _, err := degradedGroups(errors.New("connection refused")); err == nil {
+		t.Fatalf("expected non-group errors to be returned")
+	}
+
+	// The failing group keeps its previously discovered resources.
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods}, nil, degraded)
+	if len(changes) != 1 || !changes[0].DegradedChanged || len(changes[0].Removed) != 0 {
+		t.Fatalf("unexpected notifications %+v", changes)
+	}
+	if infos, _ := c.GetResourceInfos(); len(infos) != 2 {
+		t.Fatalf("expected degraded resources to be carried over, got %+v", infos)
+	}
+	if got := c.DegradedGroups(); len(got) != 1 || got[0].GroupVersion != metricsGV {
+		t.Fatalf("unexpected degraded groups %+v", got)
+	}
+
+	// Re-probing the same failure is not a change; recovery is.
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods}, nil, degraded)
+	c.applyResourceInfos(t.Context(), []ResourceInfo{pods, metrics}, nil, nil)
+	if len(changes) != 2 || !changes[1].DegradedChanged || len(c.DegradedGroups()) != 0 {
+		t.Fatalf("unexpected notifications after recovery %+v", changes)
+	}
+}
+
+func TestApplyResourceInfosServedVersions(t *testing.T) {
+	c := &Cluster{}
+	widgetsV1 := ResourceInfo{GVK: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, Resource: "widgets", Namespaced: true}
+	widgetsV2 := ResourceInfo{GVK: schema.GroupVersionKind{Group: "example.com", Version: "v2", Kind: "Widget"}, Resource: "widgets", Namespaced: true}
+
+	var changes []ResourceChange
+	c.SubscribeResources(func(ch ResourceChange) { changes = append(changes, ch) })
+	c.applyResourceInfos(t.Context(), []ResourceInfo{widgetsV1}, []ResourceInfo{widgetsV1}, nil)
+
+	// A new non-preferred version leaves the preferred set alone but is still a change.
+	c.applyResourceInfos(t.Context(), []ResourceInfo{widgetsV1}, []ResourceInfo{widgetsV1, widgetsV2}, nil)
+	if len(changes) != 1 || !changes[0].ServedChanged || len(changes[0].Added) != 0 || len(changes[0].Removed) != 0 {
+		t.Fatalf("unexpected notifications %+v", changes)
+	}
+	if infos, _ := c.GetResourceInfos(); len(infos) != 1 {
+		t.Fatalf("expected only the preferred version, got %+v", infos)
+	}
+	if served, _ := c.GetServedResourceInfos(); len(served) != 2 || served[1].GVK.Version != "v2" {
+		t.Fatalf("expected both served versions, got %+v", served)
+	}
+}
@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// WatchedInformer is an informer the cluster already runs for a resource.
+// Depending on the cache it belongs to, its objects are table rows,
+// PartialObjectMetadata or Unstructured; all of them carry ObjectMeta.
+type WatchedInformer struct {
+	GVR      schema.GroupVersionResource
+	Kind     string
+	Informer crcache.Informer
+}
+
+// watchSource is the cache a watched informer lives in.
+type watchSource int
+
+const (
+	watchMetadata watchSource = iota
+	watchRows
+)
+
+type watchKey struct {
+	source    watchSource
+	gvk       schema.GroupVersionKind
+	namespace string
+}
+
+type watchEntry struct {
+	gvr      schema.GroupVersionResource
+	informer crcache.Informer
+}
+
+// watchedState records the informers created through the Cluster helpers.
+// controller-runtime caches cannot enumerate their informers, so the cluster
+// keeps track itself to let views reuse what is watched anyway instead of
+// starting informers of their own.
+type watchedState struct {
+	mu      sync.Mutex
+	entries map[watchKey]watchEntry
+}
+
+// watch records informer as serving gvr in namespace, empty for all
+// namespaces or cluster scope.
+func (c *Cluster) watch(source watchSource, gvk schema.GroupVersionKind, gvr schema.GroupVersionResource, namespace string, informer crcache.Informer) {
+	s := &c.watched
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = map[watchKey]watchEntry{}
+	}
+	s.entries[watchKey{source: source, gvk: gvk, namespace: namespace}] = watchEntry{gvr: gvr, informer: informer}
+}
+
+// unwatch forgets every informer recorded for gvk.
+func (c *Cluster) unwatch(gvk schema.GroupVersionKind) {
+	s := &c.watched
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.entries {
+		if key.gvk == gvk {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// WatchedInformers returns one already running informer per resource whose
+// store covers namespace. With an empty namespace only informers across all
+// namespaces (or of cluster-scoped resources) qualify. No informer is
+// started.
+func (c *Cluster) WatchedInformers(namespace string) []WatchedInformer {
+	s := &c.watched
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := map[schema.GroupVersionResource]bool{}
+	var out []WatchedInformer
+	for key, e := range s.entries {
+		if key.namespace != "" && key.namespace != namespace {
+			continue
+		}
+		if seen[e.gvr] {
+			continue
+		}
+		seen[e.gvr] = true
+		out = append(out, WatchedInformer{GVR: e.gvr, Kind: key.gvk.Kind, Informer: e.informer})
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestWatchedInformers(t *testing.T) {
+	c := &Cluster{}
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	rs := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+	replicasets := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	job := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	jobs := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+
+	c.watch(watchRows, pod, pods, "default", nil)
+	c.watch(watchMetadata, pod, pods, "", nil)
+	c.watch(watchRows, rs, replicasets, "default", nil)
+	c.watch(watchRows, job, jobs, "other", nil)
+
+	resources := func(namespace string) []string {
+		var out []string
+		for _, w := range c.WatchedInformers(namespace) {
+			out = append(out, w.Kind+"/"+w.GVR.Resource)
+		}
+		slices.Sort(out)
+		return out
+	}
+	if got, want := resources("default"), []string{"Pod/pods", "ReplicaSet/replicasets"}; !slices.Equal(got, want) {
+		t.Errorf("default: got %v, want %v", got, want)
+	}
+	// Only informers across all namespaces serve cluster-wide lookups.
+	if got, want := resources(""), []string{"Pod/pods"}; !slices.Equal(got, want) {
+		t.Errorf("all namespaces: got %v, want %v", got, want)
+	}
+
+	c.unwatch(pod)
+	if got, want := resources("default"), []string{"ReplicaSet/replicasets"}; !slices.Equal(got, want) {
+		t.Errorf("after unwatch: got %v, want %v", got, want)
+	}
+}
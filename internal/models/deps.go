@@ -4,6 +4,7 @@ import (
 	"context"
 
 	kccluster "github.com/sttts/kc/internal/cluster"
+	"github.com/sttts/kc/internal/portforward"
 	"github.com/sttts/kc/pkg/appconfig"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
@@ -14,11 +15,27 @@ import (
 //   - Ctx is non-nil and used for informer/list operations.
 //   - CtxName is the human-facing context label (may be empty for cluster-scoped views).
 //   - KubeConfig always contains the discovered contexts (never nil maps).
+//   - Kubeconfigs lists the discovered kubeconfig files in discovery order.
+//   - KubeconfigSource, when set, supersedes KubeConfig contexts and Kubeconfigs
+//     with the live view that follows kubeconfig file changes.
 //   - AppConfig is non-nil and already validated by appconfig loading.
+//   - PortForwards, when set, is the app-wide manager of running
+//     port-forwards, independent of the cluster the folders browse.
 type Deps struct {
-	Cl         *kccluster.Cluster
-	Ctx        context.Context
-	CtxName    string
-	KubeConfig clientcmdapi.Config
-	AppConfig  *appconfig.Config
+	Cl          *kccluster.Cluster
+	Ctx         context.Context
+	CtxName     string
+	KubeConfig  clientcmdapi.Config
+	Kubeconfigs []KubeconfigFile
+	AppConfig   *appconfig.Config
+
+	KubeconfigSource *KubeconfigSource
+	PortForwards     *portforward.Manager
+}
+
+// KubeconfigFile is a discovered kubeconfig file. Context names are only
+// unique within one file.
+type KubeconfigFile struct {
+	Path   string
+	Config clientcmdapi.Config
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	kccluster "github.com/sttts/kc/internal/cluster"
 	table "github.com/sttts/kc/internal/table"
 )
 
@@ -51,6 +52,9 @@ func (b *BaseFolder) IsDirty() bool {
 // Columns returns the configured columns.
 func (b *BaseFolder) Columns() []table.Column { return append([]table.Column(nil), b.columns...) }
 
+// Cluster returns the cluster the folder lists from.
+func (b *BaseFolder) Cluster() *kccluster.Cluster { return b.Deps.Cl }
+
 // Path returns a copy of the breadcrumb path segments.
 func (b *BaseFolder) Path() []string { return append([]string(nil), b.path...) }
 
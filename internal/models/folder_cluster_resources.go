@@ -73,5 +73,6 @@ func (f *ClusterResourcesFolder) resourceGroupSpecs() ([]resourceGroupSpec, erro
 			},
 		})
 	}
+	specs = append(specs, degradedGroupSpecs(f.Deps, "", f.Path())...)
 	return specs, nil
 }
@@ -49,7 +49,8 @@ func (f *ConfigMapKeysFolder) buildRows(ctx context.Context) ([]table.Row, error
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
-	rows := make([]table.Row, 0, len(keys))
+	rows := make([]table.Row, 0, len(keys)+2)
+	rows = append(rows, newUsedByItem(f.Deps, gvr, f.Namespace, f.Name, f.Path()), newEventsItem(f.Deps, gvr, f.Namespace, f.Name, f.Path()))
 	style := WhiteStyle()
 	for _, k := range keys {
 		rowPath := append(append([]string{}, f.Path()...), k)
@@ -48,5 +48,9 @@ func (f *ContextRootFolder) populate(ctx context.Context) ([]table.Row, error) {
 	groupItems = append(groupItems, clusterItems...)
 
 	rows := f.ResourcesFolder.finalize(ctx, groupItems)
+	rows = withAllNamespaces(rows, allNamespacesItem(f.Deps, f.Path()))
+	if f.Deps.AppConfig.Resources.ShowGroups {
+		rows = insertAfterID(rows, "all-namespaces", groupsItem(f.Deps, f.Path()))
+	}
 	return rows, nil
 }
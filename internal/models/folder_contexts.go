@@ -8,28 +8,42 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// EnterContextFunc opens the root folder of a context. An empty kubeconfig
+// path looks the context up by name across all discovered kubeconfigs.
+type EnterContextFunc func(kubeconfigPath, name string, basePath []string) (Folder, error)
+
 // ContextsFolder lists available kubeconfig contexts (if provided).
 type ContextsFolder struct {
 	*BaseFolder
-	enter func(name string, basePath []string) (Folder, error)
+	enter EnterContextFunc
 }
 
 // NewContextsFolder constructs the contexts folder with default single-column layout.
-func NewContextsFolder(deps Deps, enter func(name string, basePath []string) (Folder, error)) *ContextsFolder {
+func NewContextsFolder(deps Deps, enter EnterContextFunc) *ContextsFolder {
 	path := []string{"contexts"}
 	cols := []table.Column{{Title: " Name"}}
 	base := NewBaseFolder(deps, cols, path)
 	folder := &ContextsFolder{BaseFolder: base, enter: enter}
 	base.SetPopulate(folder.populate)
+	base.subscribeKubeconfigChanges()
 	return folder
 }
 
 func (f *ContextsFolder) populate(context.Context) ([]table.Row, error) {
 	rows := make([]table.Row, 0, 16)
-	cfg := f.Deps.KubeConfig
+	cfg := f.Deps.kubeConfig()
 	if len(cfg.Contexts) == 0 {
 		return rows, nil
 	}
+	// Like entering, actions and the view go to the first file defining a name.
+	files := map[string]KubeconfigFile{}
+	for _, file := range f.Deps.kubeconfigFiles() {
+		for name := range file.Config.Contexts {
+			if _, ok := files[name]; !ok {
+				files[name] = file
+			}
+		}
+	}
 	names := contextNames(cfg)
 	nameStyle := WhiteStyle()
 	for _, name := range names {
@@ -38,10 +52,13 @@ func (f *ContextsFolder) populate(context.Context) ([]table.Row, error) {
 		if f.enter != nil {
 			nameCopy := name
 			enter = func() (Folder, error) {
-				return f.enter(nameCopy, itemPath)
+				return f.enter("", nameCopy, itemPath)
 			}
 		}
 		item := NewContextItem(name, []string{name}, itemPath, nameStyle, enter)
+		if file, ok := files[name]; ok {
+			item.WithKubeconfig(file.Path).WithViewContent(contextViewContent(file.Config, name))
+		}
 		rows = append(rows, item)
 	}
 	return rows, nil
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	table "github.com/sttts/kc/internal/table"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CRDVersionsFolder lists the served versions of a CustomResourceDefinition,
+// each opening the custom resources of that version.
+type CRDVersionsFolder struct {
+	*BaseFolder
+	CRD string
+}
+
+// NewCRDVersionsFolder constructs the version picker of a CRD.
+func NewCRDVersionsFolder(deps Deps, parentPath []string, name string) *CRDVersionsFolder {
+	cols := []table.Column{{Title: " Name"}, {Title: "Storage"}, {Title: "Kind"}}
+	base := NewBaseFolder(deps, cols, append([]string{}, parentPath...))
+	folder := &CRDVersionsFolder{BaseFolder: base, CRD: name}
+	rows := newLiveKeyRowSource(deps, crdGVR, "", name, folder.buildRows, folder.BaseFolder.markDirtyFromSource)
+	base.SetRowSource(rows)
+	return folder
+}
+
+func (f *CRDVersionsFolder) Parent() (schema.GroupVersionResource, string, string) {
+	return crdGVR, "", f.CRD
+}
+
+func (f *CRDVersionsFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	crd, err := fetchCRD(ctx, f.Deps, f.CRD)
+	if err != nil {
+		return nil, err
+	}
+	return f.rowsFor(crd), nil
+}
+
+func (f *CRDVersionsFolder) rowsFor(crd *apiextensionsv1.CustomResourceDefinition) []table.Row {
+	var rows []table.Row
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		version := v.Name
+		mark := ""
+		if v.Storage {
+			mark = "*"
+		}
+		itemPath := append(f.Path(), version)
+		cells := []string{"/" + version, mark, crd.Spec.Names.Kind}
+		item := NewFolderItem(version, cells, itemPath, WhiteStyle(), func() (Folder, error) {
+			return crdObjectsFolder(f.Deps, crd, version, itemPath), nil
+		})
+		item.RowItem.details = fmt.Sprintf("%s/%s", crd.Spec.Group, version)
+		rows = append(rows, item)
+	}
+	return rows
+}
+
+// newCRDChild opens the custom resources of a CRD directly when it serves a
+// single version, and the version picker otherwise.
+func newCRDChild(deps Deps, name string, basePath []string) Folder {
+	if deps.Cl != nil {
+		if crd, err := fetchCRD(deps.Ctx, deps, name); err == nil {
+			if served := servedCRDVersions(crd); len(served) == 1 {
+				return crdObjectsFolder(deps, crd, served[0], basePath)
+			}
+		}
+	}
+	return NewCRDVersionsFolder(deps, basePath, name)
+}
+
+// crdObjectsFolder lists the custom resources of crd in version, across all
+// namespaces for namespaced CRDs.
+func crdObjectsFolder(deps Deps, crd *apiextensionsv1.CustomResourceDefinition, version string, path []string) Folder {
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: version, Resource: crd.Spec.Names.Plural}
+	if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
+		return NewNamespacedObjectsFolder(deps, gvr, "", path)
+	}
+	return NewClusterObjectsFolder(deps, gvr, path)
+}
+
+func servedCRDVersions(crd *apiextensionsv1.CustomResourceDefinition) []string {
+	var served []string
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			served = append(served, v.Name)
+		}
+	}
+	return served
+}
+
+func fetchCRD(ctx context.Context, deps Deps, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	obj, err := deps.Cl.GetByGVR(ctx, crdGVR, "", name)
+	if err != nil {
+		return nil, err
+	}
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+		return nil, err
+	}
+	return &crd, nil
+}
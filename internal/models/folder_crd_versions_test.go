@@ -0,0 +1,61 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCRDVersionsFolder(t *testing.T) {
+	crd := &apiextensionsv1.CustomResourceDefinition{Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+		Group: "example.com",
+		Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+		Scope: apiextensionsv1.NamespaceScoped,
+		Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1alpha1", Served: false},
+			{Name: "v1beta1", Served: true},
+			{Name: "v1", Served: true, Storage: true},
+		},
+	}}
+	if served := servedCRDVersions(crd); !slices.Equal(served, []string{"v1beta1", "v1"}) {
+		t.Fatalf("served versions %v", served)
+	}
+
+	path := []string{"customresourcedefinitions", "widgets.example.com"}
+	folder := &CRDVersionsFolder{BaseFolder: NewBaseFolder(Deps{}, nil, path), CRD: "widgets.example.com"}
+	rows := folder.rowsFor(crd)
+	var got [][]string
+	for _, row := range rows {
+		_, cells, _, _ := row.Columns()
+		got = append(got, cells)
+	}
+	want := [][]string{{"/v1beta1", "", "Widget"}, {"/v1", "*", "Widget"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("rows %v, want %v", got, want)
+	}
+
+	child, err := rows[1].(Enterable).Enter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, ok := child.(*NamespacedObjectsFolder)
+	if !ok {
+		t.Fatalf("version enters %T, want namespaced objects", child)
+	}
+	if gvr := objs.GVR(); gvr != (schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}) {
+		t.Errorf("unexpected resource %v", gvr)
+	}
+	if !objs.allNamespaces {
+		t.Error("namespaced custom resources should be listed across all namespaces")
+	}
+	if want := append(slices.Clone(path), "v1"); !slices.Equal(objs.Path(), want) {
+		t.Errorf("path %v, want %v", objs.Path(), want)
+	}
+
+	crd.Spec.Scope = apiextensionsv1.ClusterScoped
+	if _, ok := crdObjectsFolder(Deps{}, crd, "v1", path).(*ClusterObjectsFolder); !ok {
+		t.Error("cluster-scoped custom resources should use the cluster objects folder")
+	}
+}
@@ -11,7 +11,11 @@ import (
 	kctesting "github.com/sttts/kc/internal/testing"
 	"github.com/sttts/kc/pkg/appconfig"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -34,6 +38,9 @@ func TestFoldersProduceExpectedRows(t *testing.T) {
 
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+	_ = eventsv1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
 	cli, err := crclient.New(cfg, crclient.Options{Scheme: scheme})
 	if err != nil {
 		t.Fatalf("new client: %v", err)
@@ -66,8 +73,9 @@ func TestFoldersProduceExpectedRows(t *testing.T) {
 	root := NewRootFolder(deps, nil)
 	waitFolder(t, root)
 	assertRows(t, "root", root, map[string][]string{
-		"namespaces": {"/namespaces", "v1"},
-		"/v1/nodes":  {"/nodes", "v1"},
+		"namespaces":     {"/namespaces", "v1"},
+		"all-namespaces": {"/all-namespaces"},
+		"/v1/nodes":      {"/nodes", "v1"},
 	})
 
 	groupsPath := []string{"namespaces", "testns"}
@@ -86,6 +94,42 @@ func TestFoldersProduceExpectedRows(t *testing.T) {
 		"cm1": {"/cm1"},
 	})
 
+	allObjs := NewNamespacedObjectsFolder(deps, gvrCM, "", []string{"all-namespaces", gvrCM.Resource})
+	waitFolder(t, allObjs)
+	assertRows(t, "all-namespaces-configmap-objects", allObjs, map[string][]string{
+		"testns/cm1": {"/cm1", "testns"},
+	})
+	if cols := allObjs.Columns(); len(cols) < 2 || cols[1].Title != "Namespace" {
+		t.Fatalf("all-namespaces: expected Namespace column, got %v", cols)
+	}
+
+	apiGroups := NewGroupsFolder(deps, []string{"groups"})
+	waitFolder(t, apiGroups)
+	assertRows(t, "api-groups", apiGroups, map[string][]string{
+		"core": {"/core", "v1"},
+		"apps": {"/apps", "v1"},
+	})
+
+	versions := NewGroupVersionsFolder(deps, "", []string{"groups", "core"})
+	waitFolder(t, versions)
+	assertRows(t, "core-versions", versions, map[string][]string{
+		"v1": {"/v1", "*"},
+	})
+
+	coreV1 := schema.GroupVersion{Version: "v1"}
+	gvResources := NewGroupVersionResourcesFolder(deps, coreV1, "", []string{"groups", "core", "v1"})
+	waitFolder(t, gvResources)
+	assertRows(t, "core-v1-resources", gvResources, map[string][]string{
+		"namespaces": {"/namespaces", "v1"},
+		"//v1/nodes": {"/nodes", "v1"},
+	})
+
+	gvNamespaced := NewGroupVersionResourcesFolder(deps, coreV1, "testns", []string{"groups", "core", "v1", "namespaces", "testns"})
+	waitFolder(t, gvNamespaced)
+	assertRows(t, "core-v1-namespaced-resources", gvNamespaced, map[string][]string{
+		"testns//v1/configmaps": {"/configmaps", "v1"},
+	})
+
 	keysPath := []string{"namespaces", "testns", "configmaps", "cm1"}
 	keys := NewConfigMapKeysFolder(deps, keysPath, "testns", "cm1")
 	waitFolder(t, keys)
@@ -101,6 +145,61 @@ func TestFoldersProduceExpectedRows(t *testing.T) {
 		"x": {"x"},
 		"y": {"y"},
 	})
+
+	// Entered objects get their folders from the child registry.
+	enter := func(gvr schema.GroupVersionResource, ns, name string, path []string) Folder {
+		t.Helper()
+		ctor, ok := ChildFor(gvr)
+		if !ok {
+			t.Fatalf("no child registered for %s", gvr)
+		}
+		return ctor(deps, ns, name, path)
+	}
+	enterEvents := func(f Folder) Folder {
+		t.Helper()
+		waitRowIDs(t, f, eventsID)
+		item, _ := f.ItemByID(ctx, eventsID)
+		entry, ok := item.(Enterable)
+		if !ok {
+			t.Fatalf("%v: /events is not enterable", f.Path())
+		}
+		child, err := entry.Enter()
+		if err != nil {
+			t.Fatalf("%v: enter /events: %v", f.Path(), err)
+		}
+		return child
+	}
+
+	svc := enter(servicesGVR, "testns", "web", []string{"namespaces", "testns", "services", "web"})
+	waitRowIDs(t, svc, eventsID, notReadyID, "web-abc/10.0.0.1")
+	assertRows(t, "service-endpoints", svc, map[string][]string{
+		notReadyID:         {notReadyID, "1 pods"},
+		"web-abc/10.0.0.1": {"/web-0", "10.0.0.1", "true", "true", "false", "node1", "web-abc"},
+	})
+
+	node := enter(nodesGVR, "", "node1", []string{"nodes", "node1"})
+	waitRowIDs(t, node, eventsID, "testns/web-0")
+	assertRows(t, "node-pods", node, map[string][]string{
+		"testns/web-0": {"/web-0", "testns"},
+	})
+	nodeEvents := enterEvents(node)
+	waitRowIDs(t, nodeEvents, "default/node1.ready")
+	assertRows(t, "node-events", nodeEvents, map[string][]string{
+		"default/node1.ready": {"Normal", "NodeReady", "1"},
+	})
+
+	crd := enter(crdGVR, "", "widgets.example.com", []string{"customresourcedefinitions", "widgets.example.com"})
+	waitRowIDs(t, crd, "testns/w1")
+	assertRows(t, "crd-objects", crd, map[string][]string{
+		"testns/w1": {"/w1", "testns"},
+	})
+
+	pod := enter(podsGVR, "testns", "web-0", []string{"namespaces", "testns", "pods", "web-0"})
+	podEvents := enterEvents(pod)
+	waitRowIDs(t, podEvents, "testns/web-0.scheduled")
+	assertRows(t, "pod-events", podEvents, map[string][]string{
+		"testns/web-0.scheduled": {"Normal", "Scheduled", "1"},
+	})
 }
 
 func seedData(t *testing.T, cli crclient.Client) {
@@ -108,7 +207,79 @@ func seedData(t *testing.T, cli crclient.Client) {
 	mustCreate(t, cli, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns"}})
 	mustCreate(t, cli, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "testns"}, Data: map[string]string{"a": "A", "b": "B"}})
 	mustCreate(t, cli, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "sec1", Namespace: "testns"}, Data: map[string][]byte{"x": []byte("xx"), "y": []byte("yy")}})
-	mustCreate(t, cli, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	mustCreate(t, cli, node)
+
+	mustCreate(t, cli, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports:    []corev1.ServicePort{{Port: 80}},
+		},
+	})
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "testns", Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			NodeName:   "node1",
+			Containers: []corev1.Container{{Name: "web", Image: "nginx"}},
+		},
+	}
+	mustCreate(t, cli, pod)
+	ready, nodeName := true, "node1"
+	mustCreate(t, cli, &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: "web-abc", Namespace: "testns", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+			NodeName:   &nodeName,
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "testns", Name: "web-0"},
+		}},
+	})
+
+	event := func(ns, name, reason string, regarding corev1.ObjectReference) *eventsv1.Event {
+		return &eventsv1.Event{
+			ObjectMeta:          metav1.ObjectMeta{Name: name, Namespace: ns},
+			EventTime:           metav1.NewMicroTime(time.Now()),
+			ReportingController: "kc.test/envtest",
+			ReportingInstance:   "envtest",
+			Action:              reason,
+			Reason:              reason,
+			Type:                corev1.EventTypeNormal,
+			Regarding:           regarding,
+		}
+	}
+	mustCreate(t, cli, event("testns", "web-0.scheduled", "Scheduled", corev1.ObjectReference{Kind: "Pod", Namespace: "testns", Name: "web-0", UID: pod.UID}))
+	// Events of cluster-scoped objects live in the default namespace.
+	mustCreate(t, cli, event(metav1.NamespaceDefault, "node1.ready", "NodeReady", corev1.ObjectReference{Kind: "Node", Name: "node1", UID: node.UID}))
+
+	preserve := true
+	mustCreate(t, cli, &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Type:                   "object",
+					XPreserveUnknownFields: &preserve,
+				}},
+			}},
+		},
+	})
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetNamespace("testns")
+	widget.SetName("w1")
+	// The CRD is served once it is established.
+	kctesting.Eventually(t, 10*time.Second, 100*time.Millisecond, func() bool {
+		return cli.Create(t.Context(), widget) == nil
+	})
 }
 
 func mustCreate(t *testing.T, cli crclient.Client, obj crclient.Object) {
@@ -125,6 +296,28 @@ func waitFolder(t *testing.T, f interface{ Len(context.Context) int }) {
 	})
 }
 
+// waitRowIDs waits until f lists rows with all ids.
+func waitRowIDs(t *testing.T, f interface {
+	Len(context.Context) int
+	Lines(context.Context, int, int) []table.Row
+}, ids ...string) {
+	t.Helper()
+	kctesting.Eventually(t, 10*time.Second, 50*time.Millisecond, func() bool {
+		ctx := t.Context()
+		seen := map[string]bool{}
+		for _, r := range f.Lines(ctx, 0, f.Len(ctx)) {
+			id, _, _, _ := r.Columns()
+			seen[id] = true
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 func assertRows(t *testing.T, name string, f interface {
 	Len(context.Context) int
 	Lines(context.Context, int, int) []table.Row
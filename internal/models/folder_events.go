@@ -0,0 +1,174 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	table "github.com/sttts/kc/internal/table"
+	eventsv1 "k8s.io/api/events/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// eventsID is the row ID of the /events entry. The slash keeps it apart from
+// object names and data keys.
+const eventsID = "/events"
+
+var eventsGVR = schema.GroupVersionResource{Group: "events.k8s.io", Version: "v1", Resource: "events"}
+
+// EventsFolder lists the events.k8s.io/v1 Events regarding an object as a
+// timeline, oldest first. Warning events are highlighted.
+type EventsFolder struct {
+	*BaseFolder
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+// NewEventsFolder constructs the events folder of the object namespace/name
+// of resource gvr. It is kept up to date from the Event informer of the
+// namespace the object's events live in, see cluster.EventInformer.
+func NewEventsFolder(deps Deps, gvr schema.GroupVersionResource, namespace, name string, parentPath []string) *EventsFolder {
+	path := append(append([]string{}, parentPath...), "events")
+	cols := []table.Column{{Title: " Type"}, {Title: "Reason"}, {Title: "Count"}, {Title: "Age"}, {Title: "Message"}}
+	base := NewBaseFolder(deps, cols, path)
+	folder := &EventsFolder{BaseFolder: base, gvr: gvr, namespace: namespace, name: name}
+	rows := newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, gvr, namespace, name, src)
+		startEventInformer(deps, namespace, src)
+	})
+	base.SetRowSource(rows)
+	return folder
+}
+
+// newEventsItem returns the /events entry shown under the object.
+func newEventsItem(deps Deps, gvr schema.GroupVersionResource, namespace, name string, parentPath []string) *FolderItem {
+	path := append(append([]string{}, parentPath...), "events")
+	item := NewFolderItem(eventsID, []string{eventsID}, path, WhiteStyle(), func() (Folder, error) {
+		return NewEventsFolder(deps, gvr, namespace, name, parentPath), nil
+	})
+	item.RowItem.details = fmt.Sprintf("events regarding %s", name)
+	return item
+}
+
+// Parent returns the resource, namespace and name of the regarded object.
+func (f *EventsFolder) Parent() (schema.GroupVersionResource, string, string) {
+	return f.gvr, f.namespace, f.name
+}
+
+func (f *EventsFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	if f.Deps.Cl == nil {
+		return nil, nil
+	}
+	obj, err := f.Deps.Cl.GetByGVR(ctx, f.gvr, f.namespace, f.name)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, nil
+	}
+	events, err := eventsRegarding(ctx, f.Deps, f.namespace, obj.GetUID())
+	if err != nil {
+		return nil, err
+	}
+	return f.rowsFor(events, time.Now()), nil
+}
+
+// eventsRegarding returns the events regarding the object with the given UID
+// in namespace, sorted by the time they were last seen.
+func eventsRegarding(ctx context.Context, deps Deps, namespace string, uid types.UID) ([]eventsv1.Event, error) {
+	list, err := deps.Cl.ListEventsRegarding(ctx, namespace, uid)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]eventsv1.Event, 0, len(list.Items))
+	for i := range list.Items {
+		var ev eventsv1.Event
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &ev); err != nil {
+			continue
+		}
+		events = append(events, ev)
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// startEventInformer marks src dirty on every change of the events in the
+// namespace the events of objects in namespace live in. The Event informer is
+// held until src has been garbage collected.
+func startEventInformer(deps Deps, namespace string, src *liveObjectRowSource) {
+	if deps.Cl == nil {
+		return
+	}
+	informer, release, err := deps.Cl.EventInformer(namespace)
+	if err != nil {
+		return
+	}
+	markDirtyWhileAlive(src, informer, nil, release)
+}
+
+// sortEvents sorts events by the time they were last seen, oldest first.
+func sortEvents(events []eventsv1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, tj := eventLastSeen(&events[i]), eventLastSeen(&events[j])
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return events[i].Name < events[j].Name
+	})
+}
+
+// eventCells returns the type, reason, count, age and message of ev.
+func eventCells(ev *eventsv1.Event, now time.Time) []string {
+	return []string{ev.Type, ev.Reason, strconv.Itoa(int(eventCount(ev))), ageCell(eventLastSeen(ev), now), ev.Note}
+}
+
+// rowsFor builds one row per event, sorted by the time it was last seen.
+func (f *EventsFolder) rowsFor(events []eventsv1.Event, now time.Time) []table.Row {
+	sortEvents(events)
+	rows := make([]table.Row, 0, len(events))
+	for i := range events {
+		ev := &events[i]
+		style := WhiteStyle()
+		if ev.Type == "Warning" {
+			style = ErrorStyle()
+		}
+		ns, name := ev.Namespace, ev.Name
+		cells := eventCells(ev, now)
+		rowPath := append(append([]string{}, f.Path()...), name)
+		row := objectRow(f.Deps, ns+"/"+name, eventsGVR, ns, name, "Event", cells, rowPath, ev.CreationTimestamp.Time)
+		row.setStyle(style)
+		row.RowItem.details = ev.Note
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// eventLastSeen returns when ev was last observed, falling back from the
+// series over the deprecated core timestamps to the event time.
+func eventLastSeen(ev *eventsv1.Event) time.Time {
+	switch {
+	case ev.Series != nil && !ev.Series.LastObservedTime.IsZero():
+		return ev.Series.LastObservedTime.Time
+	case !ev.DeprecatedLastTimestamp.IsZero():
+		return ev.DeprecatedLastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	}
+	return ev.CreationTimestamp.Time
+}
+
+// eventCount returns how often ev occurred.
+func eventCount(ev *eventsv1.Event) int32 {
+	switch {
+	case ev.Series != nil && ev.Series.Count > 0:
+		return ev.Series.Count
+	case ev.DeprecatedCount > 0:
+		return ev.DeprecatedCount
+	}
+	return 1
+}
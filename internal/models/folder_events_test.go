@@ -0,0 +1,123 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestEventsRows(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	folder := &EventsFolder{BaseFolder: NewBaseFolder(Deps{}, nil, []string{"namespaces", "default", "pods", "web", "events"}), gvr: pods, namespace: "default", name: "web"}
+	now := time.Now()
+	events := []eventsv1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web.backoff"},
+			Type:       "Warning",
+			Reason:     "BackOff",
+			Note:       "Back-off restarting failed container",
+			Series:     &eventsv1.EventSeries{Count: 5, LastObservedTime: metav1.NewMicroTime(now.Add(-time.Minute))},
+		},
+		{
+			ObjectMeta:              metav1.ObjectMeta{Namespace: "default", Name: "web.pulled"},
+			Type:                    "Normal",
+			Reason:                  "Pulled",
+			Note:                    "Container image pulled",
+			DeprecatedCount:         2,
+			DeprecatedLastTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web.scheduled"},
+			Type:       "Normal",
+			Reason:     "Scheduled",
+			Note:       "Successfully assigned default/web to node-1",
+			EventTime:  metav1.NewMicroTime(now.Add(-2 * time.Hour)),
+		},
+	}
+
+	rows := folder.rowsFor(events, now)
+	var got [][]string
+	for _, row := range rows {
+		_, cells, _, _ := row.Columns()
+		got = append(got, cells)
+	}
+	want := [][]string{
+		{"Normal", "Scheduled", "1", "120m", "Successfully assigned default/web to node-1"},
+		{"Normal", "Pulled", "2", "60m", "Container image pulled"},
+		{"Warning", "BackOff", "5", "60s", "Back-off restarting failed container"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("rows %v, want %v", got, want)
+	}
+
+	id, _, styles, _ := rows[2].Columns()
+	if id != "default/web.backoff" {
+		t.Errorf("unexpected id %q", id)
+	}
+	if len(styles) == 0 || styles[0].GetForeground() != ErrorStyle().GetForeground() {
+		t.Error("warning events should be highlighted")
+	}
+	if _, _, styles, _ := rows[0].Columns(); len(styles) == 0 || styles[0].GetForeground() == ErrorStyle().GetForeground() {
+		t.Error("normal events should not be highlighted")
+	}
+}
+
+func TestEventsEntryOfDependents(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	path := []string{"namespaces", "default", "deployments", "web"}
+	folder := NewOwnedObjectsFolder(Deps{}, deployments, "default", "web", path)
+	if len(folder.leading) != 1 {
+		t.Fatalf("expected the /events entry, got %d leading rows", len(folder.leading))
+	}
+	id, _, _, _ := folder.leading[0].Columns()
+	if id != eventsID {
+		t.Fatalf("unexpected leading row %q", id)
+	}
+	child, err := folder.leading[0].(Enterable).Enter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, ok := child.(*EventsFolder)
+	if !ok {
+		t.Fatalf("/events enters %T", child)
+	}
+	if gvr, ns, name := events.Parent(); gvr != deployments || ns != "default" || name != "web" {
+		t.Errorf("unexpected regarded object %v %s/%s", gvr, ns, name)
+	}
+	if want := append(slices.Clone(path), "events"); !slices.Equal(events.Path(), want) {
+		t.Errorf("path %v, want %v", events.Path(), want)
+	}
+}
+
+func TestEventsComment(t *testing.T) {
+	now := time.Now()
+	events := []eventsv1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1.ready"},
+			Type:       "Normal",
+			Reason:     "NodeReady",
+			Note:       "Node node-1 status is now: NodeReady",
+			EventTime:  metav1.NewMicroTime(now.Add(-time.Hour)),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1.pressure"},
+			Type:       "Warning",
+			Reason:     "EvictionThresholdMet",
+			Note:       "Attempting to reclaim\nmemory",
+			Series:     &eventsv1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(now.Add(-time.Minute))},
+		},
+	}
+	want := `
+# Events:
+#   Type     Reason                Count  Age  Message
+#   Normal   NodeReady             1      60m  Node node-1 status is now: NodeReady
+#   Warning  EvictionThresholdMet  3      60s  Attempting to reclaim memory
+`
+	if got := eventsComment(events, now); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	table "github.com/sttts/kc/internal/table"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// coreGroupLabel names the legacy core API group in group paths.
+const coreGroupLabel = "core"
+
+// GroupsFolder lists the served API groups under /groups. The group hierarchy
+// (/groups/<group>/<version>/[namespaces/<ns>/]<resource>) shows every served
+// version, not only the preferred one, and disambiguates resources sharing a
+// plural across groups. Resources resolve to the same object folders as the
+// classic paths.
+type GroupsFolder struct {
+	*BaseFolder
+}
+
+// NewGroupsFolder constructs the API groups folder.
+func NewGroupsFolder(deps Deps, path []string) *GroupsFolder {
+	base := NewBaseFolder(deps, []table.Column{{Title: " Name"}, {Title: "Versions"}}, path)
+	folder := &GroupsFolder{BaseFolder: base}
+	base.SetPopulate(folder.populate)
+	base.subscribeResourceChanges()
+	return folder
+}
+
+func (f *GroupsFolder) populate(context.Context) ([]table.Row, error) {
+	groups, err := servedGroups(f.Deps)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]table.Row, 0, len(groups))
+	for _, g := range groups {
+		label := groupLabel(g.name)
+		itemPath := append(f.Path(), label)
+		group := g.name
+		cells := []string{"/" + label, strings.Join(g.versions, ",")}
+		rows = append(rows, NewFolderItem(label, cells, itemPath, WhiteStyle(), func() (Folder, error) {
+			return NewGroupVersionsFolder(f.Deps, group, itemPath), nil
+		}))
+	}
+	return rows, nil
+}
+
+// GroupVersionsFolder lists the served versions of one API group.
+type GroupVersionsFolder struct {
+	*BaseFolder
+	Group string
+}
+
+// NewGroupVersionsFolder constructs the versions folder of group.
+func NewGroupVersionsFolder(deps Deps, group string, path []string) *GroupVersionsFolder {
+	base := NewBaseFolder(deps, []table.Column{{Title: " Name"}, {Title: "Preferred"}, {Title: "Resources"}}, path)
+	folder := &GroupVersionsFolder{BaseFolder: base, Group: group}
+	base.SetPopulate(folder.populate)
+	base.subscribeResourceChanges()
+	return folder
+}
+
+func (f *GroupVersionsFolder) populate(context.Context) ([]table.Row, error) {
+	groups, err := servedGroups(f.Deps)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(groups, func(g servedGroup) bool { return g.name == f.Group })
+	if i < 0 {
+		return nil, nil
+	}
+	preferred, err := f.Deps.Cl.GetResourceInfos()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]table.Row, 0, len(groups[i].versions))
+	for _, version := range groups[i].versions {
+		gv := schema.GroupVersion{Group: f.Group, Version: version}
+		mark := ""
+		if slices.ContainsFunc(preferred, func(info ResourceInfo) bool { return info.GVK.GroupVersion() == gv }) {
+			mark = "*"
+		}
+		itemPath := append(f.Path(), version)
+		cells := []string{"/" + version, mark, fmt.Sprintf("%d", groups[i].resources[version])}
+		rows = append(rows, NewFolderItem(version, cells, itemPath, WhiteStyle(), func() (Folder, error) {
+			return NewGroupVersionResourcesFolder(f.Deps, gv, "", itemPath), nil
+		}))
+	}
+	return rows, nil
+}
+
+// GroupVersionResourcesFolder lists the resources of one API group version.
+// Without a namespace it shows the cluster-scoped resources plus a namespaces
+// entry leading to the namespaced ones.
+type GroupVersionResourcesFolder struct {
+	*ResourcesFolder
+	GroupVersion schema.GroupVersion
+	Namespace    string
+}
+
+// NewGroupVersionResourcesFolder constructs the resources folder of gv, scoped
+// to namespace when set.
+func NewGroupVersionResourcesFolder(deps Deps, gv schema.GroupVersion, namespace string, path []string) *GroupVersionResourcesFolder {
+	base := NewBaseFolder(deps, nil, path)
+	folder := &GroupVersionResourcesFolder{
+		ResourcesFolder: NewResourcesFolder(base),
+		GroupVersion:    gv,
+		Namespace:       namespace,
+	}
+	base.SetPopulate(folder.populate)
+	return folder
+}
+
+func (f *GroupVersionResourcesFolder) populate(ctx context.Context) ([]table.Row, error) {
+	specs, err := f.resourceGroupSpecs()
+	if err != nil {
+		return nil, err
+	}
+	return f.ResourcesFolder.finalize(ctx, specs), nil
+}
+
+func (f *GroupVersionResourcesFolder) resourceGroupSpecs() ([]resourceGroupSpec, error) {
+	cfg := f.Deps.AppConfig
+	infos, err := f.Deps.Cl.GetServedResourceInfos()
+	if err != nil {
+		return nil, err
+	}
+	namespaced := f.Namespace != ""
+	hasNamespaced := false
+	entries := make([]resourceEntry, 0, len(infos))
+	for _, info := range infos {
+		if info.GVK.GroupVersion() != f.GroupVersion {
+			continue
+		}
+		if !verbsInclude(info.Verbs, "list") || !verbsInclude(info.Verbs, "watch") {
+			continue
+		}
+		hasNamespaced = hasNamespaced || info.Namespaced
+		if info.Namespaced != namespaced || (info.GVK.Group == "" && info.Resource == "namespaces") {
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: info.GVK.Group, Version: info.GVK.Version, Resource: info.Resource}
+		entries = append(entries, resourceEntry{info: info, gvr: gvr})
+	}
+	sortResourceEntries(entries, cfg.Resources.Order, favoritesMap(cfg.Resources.Favorites))
+
+	specs := make([]resourceGroupSpec, 0, len(entries)+1)
+	nameStyle := WhiteStyle()
+	if !namespaced && hasNamespaced {
+		gvrNamespaces := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}
+		nsPath := append(f.Path(), "namespaces")
+		gv := f.GroupVersion
+		specs = append(specs, resourceGroupSpec{
+			id:        "namespaces",
+			cells:     []string{"/namespaces", "v1", ""},
+			path:      nsPath,
+			detail:    "namespaces (v1)",
+			style:     nameStyle,
+			gvr:       gvrNamespaces,
+			watchable: true,
+			enter: func() (Folder, error) {
+				return NewGroupNamespacesFolder(f.Deps, gv, nsPath), nil
+			},
+		})
+	}
+	for _, entry := range entries {
+		id := fmt.Sprintf("%s/%s/%s/%s", f.Namespace, entry.gvr.Group, entry.gvr.Version, entry.gvr.Resource)
+		gvLabel := groupVersionString(entry.info.GVK.Group, entry.info.GVK.Version)
+		pathCopy := append(f.Path(), entry.info.Resource)
+		gvr := entry.gvr
+		ns := f.Namespace
+		specs = append(specs, resourceGroupSpec{
+			id:        id,
+			cells:     []string{"/" + entry.info.Resource, gvLabel, ""},
+			path:      pathCopy,
+			detail:    fmt.Sprintf("%s (%s)", entry.info.Resource, gvLabel),
+			style:     nameStyle,
+			gvr:       gvr,
+			namespace: ns,
+			watchable: true,
+			enter: func() (Folder, error) {
+				if ns == "" {
+					return NewClusterObjectsFolder(f.Deps, gvr, pathCopy), nil
+				}
+				return NewNamespacedObjectsFolder(f.Deps, gvr, ns, pathCopy), nil
+			},
+		})
+	}
+	return specs, nil
+}
+
+// NewGroupNamespacesFolder lists the namespaces of the cluster; entering one
+// opens the namespaced resources of gv in it.
+func NewGroupNamespacesFolder(deps Deps, gv schema.GroupVersion, path []string) *ClusterObjectsFolder {
+	gvrNamespaces := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}
+	child := func(deps Deps, _ string, name string, basePath []string) Folder {
+		return NewGroupVersionResourcesFolder(deps, gv, name, basePath)
+	}
+	return &ClusterObjectsFolder{
+		ObjectsFolder: newObjectsFolder(deps, gvrNamespaces, "", false, child, path),
+	}
+}
+
+// groupsItem opens the API group hierarchy.
+func groupsItem(deps Deps, parent []string) *FolderItem {
+	path := append(append([]string{}, parent...), "groups")
+	return NewFolderItem("groups", []string{"/groups", "", ""}, path, WhiteStyle(), func() (Folder, error) {
+		return NewGroupsFolder(deps, path), nil
+	})
+}
+
+type servedGroup struct {
+	name      string
+	versions  []string
+	resources map[string]int
+}
+
+// servedGroups collects the served API groups sorted by name, the core group
+// first, with versions in discovery priority order.
+func servedGroups(deps Deps) ([]servedGroup, error) {
+	infos, err := deps.Cl.GetServedResourceInfos()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int)
+	var groups []servedGroup
+	for _, info := range infos {
+		i, ok := index[info.GVK.Group]
+		if !ok {
+			i = len(groups)
+			index[info.GVK.Group] = i
+			groups = append(groups, servedGroup{name: info.GVK.Group, resources: map[string]int{}})
+		}
+		g := &groups[i]
+		if !slices.Contains(g.versions, info.GVK.Version) {
+			g.versions = append(g.versions, info.GVK.Version)
+		}
+		g.resources[info.GVK.Version]++
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	return groups, nil
+}
+
+func groupLabel(group string) string {
+	if group == "" {
+		return coreGroupLabel
+	}
+	return group
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	table "github.com/sttts/kc/internal/table"
+)
+
+// KubeconfigsFolder lists the discovered kubeconfig files. Unlike /contexts,
+// which merges all files by context name, each file keeps its own contexts.
+type KubeconfigsFolder struct {
+	*BaseFolder
+	enter EnterContextFunc
+}
+
+// NewKubeconfigsFolder constructs the kubeconfigs folder.
+func NewKubeconfigsFolder(deps Deps, enter EnterContextFunc, path []string) *KubeconfigsFolder {
+	cols := []table.Column{{Title: " Name"}, {Title: "Contexts"}, {Title: "Current"}, {Title: "Path"}}
+	base := NewBaseFolder(deps, cols, path)
+	folder := &KubeconfigsFolder{BaseFolder: base, enter: enter}
+	base.SetPopulate(folder.populate)
+	base.subscribeKubeconfigChanges()
+	return folder
+}
+
+func (f *KubeconfigsFolder) populate(context.Context) ([]table.Row, error) {
+	files := f.Deps.kubeconfigFiles()
+	rows := make([]table.Row, 0, len(files))
+	nameStyle := WhiteStyle()
+	segments := kubeconfigSegments(files)
+	for i, file := range files {
+		itemPath := append(f.Path(), segments[i])
+		cells := []string{
+			"/" + segments[i],
+			fmt.Sprintf("%d", len(file.Config.Contexts)),
+			file.Config.CurrentContext,
+			file.Path,
+		}
+		enter := func() (Folder, error) {
+			return NewKubeconfigContextsFolder(f.Deps, file, f.enter, itemPath), nil
+		}
+		rows = append(rows, NewContextListItem(file.Path, cells, itemPath, nameStyle, len(file.Config.Contexts), enter))
+	}
+	return rows, nil
+}
+
+// kubeconfigSegments returns the path segment of each file: its base name,
+// numbered when several files share it, e.g. "config" and "config~2".
+func kubeconfigSegments(files []KubeconfigFile) []string {
+	segments := make([]string, len(files))
+	used := make(map[string]bool, len(files))
+	for i, file := range files {
+		base := filepath.Base(file.Path)
+		seg := base
+		for n := 2; used[seg]; n++ {
+			seg = fmt.Sprintf("%s~%d", base, n)
+		}
+		used[seg] = true
+		segments[i] = seg
+	}
+	return segments
+}
+
+// KubeconfigContextsFolder lists the contexts of a single kubeconfig file.
+// Entering one connects to exactly that file and context. The file content
+// follows the kubeconfig source when one is set.
+type KubeconfigContextsFolder struct {
+	*BaseFolder
+	file  KubeconfigFile
+	enter EnterContextFunc
+}
+
+// NewKubeconfigContextsFolder constructs the contexts folder of file.
+func NewKubeconfigContextsFolder(deps Deps, file KubeconfigFile, enter EnterContextFunc, path []string) *KubeconfigContextsFolder {
+	cols := []table.Column{{Title: " Name"}, {Title: "Cluster"}, {Title: "Namespace"}}
+	base := NewBaseFolder(deps, cols, path)
+	folder := &KubeconfigContextsFolder{BaseFolder: base, file: file, enter: enter}
+	base.SetPopulate(folder.populate)
+	base.subscribeKubeconfigChanges()
+	return folder
+}
+
+// KubeconfigPath returns the file the folder lists contexts of.
+func (f *KubeconfigContextsFolder) KubeconfigPath() string { return f.file.Path }
+
+func (f *KubeconfigContextsFolder) populate(context.Context) ([]table.Row, error) {
+	file := f.file
+	if f.Deps.KubeconfigSource != nil {
+		files := f.Deps.kubeconfigFiles()
+		i := slices.IndexFunc(files, func(kf KubeconfigFile) bool { return kf.Path == f.file.Path })
+		if i < 0 {
+			return nil, nil
+		}
+		file = files[i]
+	}
+	names := contextNames(file.Config)
+	rows := make([]table.Row, 0, len(names))
+	for _, name := range names {
+		kctx := file.Config.Contexts[name]
+		style := WhiteStyle()
+		if name == file.Config.CurrentContext {
+			style = GreenStyle()
+		}
+		itemPath := append(f.Path(), name)
+		var enter func() (Folder, error)
+		if f.enter != nil {
+			nameCopy := name
+			enter = func() (Folder, error) {
+				return f.enter(f.file.Path, nameCopy, itemPath)
+			}
+		}
+		cells := []string{name, "", ""}
+		if kctx != nil {
+			cells[1], cells[2] = kctx.Cluster, kctx.Namespace
+		}
+		item := NewContextItem(name, cells, itemPath, style, enter).WithKubeconfig(file.Path)
+		rows = append(rows, item.WithViewContent(contextViewContent(file.Config, name)))
+	}
+	return rows, nil
+}
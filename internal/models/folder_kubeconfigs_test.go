@@ -0,0 +1,145 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestKubeconfigsFolderKeepsFilesApart(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	mk := func(current string, names ...string) clientcmdapi.Config {
+		cfg := clientcmdapi.Config{CurrentContext: current, Contexts: map[string]*clientcmdapi.Context{}}
+		for _, name := range names {
+			cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name + "-cluster", Namespace: "ns"}
+		}
+		return cfg
+	}
+	deps := Deps{
+		Ctx: ctx,
+		Kubeconfigs: []KubeconfigFile{
+			{Path: "/home/me/.kube/config", Config: mk("dev", "dev", "prod")},
+			{Path: "/home/me/.kube/other", Config: mk("dev", "dev")},
+		},
+	}
+
+	type entered struct{ path, name string }
+	var got []entered
+	enter := func(kubeconfigPath, name string, basePath []string) (Folder, error) {
+		got = append(got, entered{kubeconfigPath, name})
+		return nil, nil
+	}
+
+	files := NewKubeconfigsFolder(deps, enter, []string{"kubeconfigs"})
+	rows := files.Lines(ctx, 0, files.Len(ctx))
+	var ids []string
+	for _, row := range rows {
+		id, cells, _, _ := row.Columns()
+		ids = append(ids, id)
+		if id == "/home/me/.kube/config" && !slices.Equal(cells, []string{"/config", "2", "dev", "/home/me/.kube/config"}) {
+			t.Fatalf("unexpected cells %v", cells)
+		}
+	}
+	if !slices.Equal(ids, []string{"__back__", "/home/me/.kube/config", "/home/me/.kube/other"}) {
+		t.Fatalf("unexpected rows %v", ids)
+	}
+
+	// The same context name opens the file it was listed in.
+	for _, path := range []string{"/home/me/.kube/config", "/home/me/.kube/other"} {
+		item, ok := files.ItemByID(ctx, path)
+		if !ok {
+			t.Fatalf("missing kubeconfig %s", path)
+		}
+		folder, err := item.(Enterable).Enter()
+		if err != nil {
+			t.Fatalf("enter %s: %v", path, err)
+		}
+		ctxItem, ok := folder.ItemByID(ctx, "dev")
+		if !ok {
+			t.Fatalf("%s: missing context dev", path)
+		}
+		if got := ctxItem.(KubeconfigContext).KubeconfigPath(); got != path {
+			t.Fatalf("context dev of %s points to %s", path, got)
+		}
+		if _, err := ctxItem.(Enterable).Enter(); err != nil {
+			t.Fatalf("enter dev: %v", err)
+		}
+	}
+	want := []entered{{"/home/me/.kube/config", "dev"}, {"/home/me/.kube/other", "dev"}}
+	if !slices.Equal(got, want) {
+		t.Fatalf("entered %v, want %v", got, want)
+	}
+}
+
+func TestKubeconfigsFolderNumbersSameNames(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	deps := Deps{
+		Ctx: ctx,
+		Kubeconfigs: []KubeconfigFile{
+			{Path: "/home/me/.kube/config"},
+			{Path: "/etc/kube/config"},
+			{Path: "/home/me/other"},
+		},
+	}
+	files := NewKubeconfigsFolder(deps, nil, []string{"kubeconfigs"})
+	var names []string
+	for _, row := range files.Lines(ctx, 0, files.Len(ctx)) {
+		if id, cells, _, _ := row.Columns(); id != "__back__" {
+			names = append(names, cells[0])
+		}
+	}
+	if want := []string{"/config", "/config~2", "/other"}; !slices.Equal(names, want) {
+		t.Fatalf("names %v, want %v", names, want)
+	}
+}
+
+func TestKubeconfigSourceRepopulatesFolders(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	cfg := func(names ...string) clientcmdapi.Config {
+		c := clientcmdapi.Config{Contexts: map[string]*clientcmdapi.Context{}}
+		for _, name := range names {
+			c.Contexts[name] = &clientcmdapi.Context{Cluster: name}
+		}
+		return c
+	}
+	source := NewKubeconfigSource(cfg("dev", "prod"), []KubeconfigFile{{Path: "/a", Config: cfg("dev", "prod")}})
+	deps := Deps{Ctx: ctx, KubeconfigSource: source}
+
+	ids := func(f Folder) []string {
+		var ids []string
+		for _, row := range f.Lines(ctx, 0, f.Len(ctx)) {
+			id, _, _, _ := row.Columns()
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	contexts := NewContextsFolder(deps, nil)
+	files := NewKubeconfigContextsFolder(deps, KubeconfigFile{Path: "/a"}, nil, []string{"kubeconfigs", "a"})
+	if got := ids(contexts); !slices.Equal(got, []string{"__back__", "dev", "prod"}) {
+		t.Fatalf("unexpected contexts %v", got)
+	}
+	if got := ids(files); !slices.Equal(got, []string{"__back__", "dev", "prod"}) {
+		t.Fatalf("unexpected file contexts %v", got)
+	}
+
+	source.Set(cfg("dev"), []KubeconfigFile{{Path: "/a", Config: cfg("dev")}})
+	if got := ids(contexts); !slices.Equal(got, []string{"__back__", "dev"}) {
+		t.Fatalf("contexts after reload %v", got)
+	}
+	if got := ids(files); !slices.Equal(got, []string{"__back__", "dev"}) {
+		t.Fatalf("file contexts after reload %v", got)
+	}
+
+	// A file that disappeared lists nothing.
+	source.Set(cfg(), nil)
+	if got := ids(files); !slices.Equal(got, []string{"__back__"}) {
+		t.Fatalf("file contexts after removal %v", got)
+	}
+}
@@ -9,10 +9,11 @@ type NamespacedObjectsFolder struct {
 	*ObjectsFolder
 }
 
-// NewNamespacedObjectsFolder constructs a namespaced objects folder.
+// NewNamespacedObjectsFolder constructs a namespaced objects folder. An empty
+// namespace lists the resource across all namespaces.
 func NewNamespacedObjectsFolder(deps Deps, gvr schema.GroupVersionResource, namespace string, path []string) *NamespacedObjectsFolder {
 	folder := &NamespacedObjectsFolder{
-		ObjectsFolder: NewObjectsFolder(deps, gvr, namespace, path),
+		ObjectsFolder: newObjectsFolder(deps, gvr, namespace, namespace == "", nil, path),
 	}
 	return folder
 }
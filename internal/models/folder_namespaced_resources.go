@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	table "github.com/sttts/kc/internal/table"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -14,7 +15,8 @@ type NamespacedResourcesFolder struct {
 	Namespace string
 }
 
-// NewNamespacedResourcesFolder creates a namespace-scoped resources folder.
+// NewNamespacedResourcesFolder creates a namespace-scoped resources folder. An
+// empty namespace groups the namespaced resources across all namespaces.
 func NewNamespacedResourcesFolder(deps Deps, namespace string, path []string) *NamespacedResourcesFolder {
 	base := NewBaseFolder(deps, nil, path)
 	folder := &NamespacedResourcesFolder{
@@ -78,5 +80,33 @@ func (f *NamespacedResourcesFolder) resourceGroupSpecs() ([]resourceGroupSpec, e
 			},
 		})
 	}
+	specs = append(specs, degradedGroupSpecs(f.Deps, f.Namespace+"/", f.Path())...)
 	return specs, nil
 }
+
+// allNamespacesItem opens the namespaced resources across all namespaces.
+func allNamespacesItem(deps Deps, parent []string) *FolderItem {
+	path := append(append([]string{}, parent...), "all-namespaces")
+	return NewFolderItem("all-namespaces", []string{"/all-namespaces", "", ""}, path, WhiteStyle(), func() (Folder, error) {
+		return NewNamespacedResourcesFolder(deps, "", path), nil
+	})
+}
+
+// withAllNamespaces places the all-namespaces entry right after the
+// namespaces row, or first when that row is hidden.
+func withAllNamespaces(rows []table.Row, item *FolderItem) []table.Row {
+	return insertAfterID(rows, "namespaces", item)
+}
+
+// insertAfterID inserts item right after the row with the given ID, or first
+// when there is no such row.
+func insertAfterID(rows []table.Row, id string, item table.Row) []table.Row {
+	pos := 0
+	for i, row := range rows {
+		if rowID, _, _, _ := row.Columns(); rowID == id {
+			pos = i + 1
+			break
+		}
+	}
+	return slices.Insert(rows, pos, item)
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	table "github.com/sttts/kc/internal/table"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var nodesGVR = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+
+// NodePodsFolder lists the pods scheduled to a node across all namespaces
+// with their requests and limits. Like `kubectl describe node`, it starts with
+// the node's allocatable resources and what the non-terminated pods allocate
+// of them.
+type NodePodsFolder struct {
+	*BaseFolder
+	Node string
+}
+
+// NewNodePodsFolder constructs the scheduled pods folder of a node.
+func NewNodePodsFolder(deps Deps, parentPath []string, node string) *NodePodsFolder {
+	cols := []table.Column{{Title: " Name"}, {Title: "Namespace"}, {Title: "CPU Requests"}, {Title: "CPU Limits"}, {Title: "Memory Requests"}, {Title: "Memory Limits"}, {Title: "Age"}}
+	base := NewBaseFolder(deps, cols, append([]string{}, parentPath...))
+	folder := &NodePodsFolder{BaseFolder: base, Node: node}
+	rows := newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, nodesGVR, "", node, src)
+		startPodInformerForNode(deps, node, src)
+	})
+	base.SetRowSource(rows)
+	return folder
+}
+
+func (f *NodePodsFolder) Parent() (schema.GroupVersionResource, string, string) {
+	return nodesGVR, "", f.Node
+}
+
+func (f *NodePodsFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	obj, err := f.Deps.Cl.GetByGVR(ctx, nodesGVR, "", f.Node)
+	if err != nil {
+		return nil, err
+	}
+	var node corev1.Node
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &node); err != nil {
+		return nil, err
+	}
+	list, err := f.Deps.Cl.ListPodsOnNode(ctx, f.Node)
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for i := range list.Items {
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &pod); err != nil {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	rows := []table.Row{newEventsItem(f.Deps, nodesGVR, "", f.Node, f.Path())}
+	return append(rows, f.rowsFor(&node, pods, time.Now())...), nil
+}
+
+// rowsFor builds the allocatable and allocated summary rows followed by one
+// row per pod. Terminated pods are listed dimmed and do not allocate.
+func (f *NodePodsFolder) rowsFor(node *corev1.Node, pods []corev1.Pod, now time.Time) []table.Row {
+	alloc := node.Status.Allocatable
+	if len(alloc) == 0 {
+		alloc = node.Status.Capacity
+	}
+	allocCPU, allocMem, allocPods := alloc[corev1.ResourceCPU], alloc[corev1.ResourceMemory], alloc[corev1.ResourcePods]
+
+	var podRows []table.Row
+	totalReqs, totalLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	running := 0
+	for i := range pods {
+		pod := &pods[i]
+		reqs, limits := podRequestsAndLimits(pod)
+		style := WhiteStyle()
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			style = DimStyle()
+		} else {
+			running++
+			addResourceList(totalReqs, reqs)
+			addResourceList(totalLimits, limits)
+		}
+		ns, name := pod.Namespace, pod.Name
+		cells := []string{
+			"/" + name,
+			ns,
+			allocationCell(reqs[corev1.ResourceCPU], allocCPU, true),
+			allocationCell(limits[corev1.ResourceCPU], allocCPU, true),
+			allocationCell(reqs[corev1.ResourceMemory], allocMem, false),
+			allocationCell(limits[corev1.ResourceMemory], allocMem, false),
+			ageCell(pod.CreationTimestamp.Time, now),
+		}
+		rowPath := append(append([]string{}, f.Path()...), name)
+		row := objectRowWithChild(f.Deps, ns+"/"+name, podsGVR, ns, name, "Pod", cells, rowPath, pod.CreationTimestamp.Time)
+		row.setStyle(style)
+		podRows = append(podRows, row)
+	}
+	sortObjectRows(f.Deps, podRows)
+
+	allocatable := NewSimpleItem("allocatable", []string{
+		"Allocatable",
+		fmt.Sprintf("%s pods", allocPods.String()),
+		allocCPU.String(), "",
+		allocMem.String(), "",
+		"",
+	}, append(append([]string{}, f.Path()...), "allocatable"), GreenStyle())
+	allocatable.RowItem.details = fmt.Sprintf("allocatable of %s", f.Node)
+	allocated := NewSimpleItem("allocated", []string{
+		"Allocated",
+		fmt.Sprintf("%d/%s pods", running, allocPods.String()),
+		allocationCell(totalReqs[corev1.ResourceCPU], allocCPU, true),
+		allocationCell(totalLimits[corev1.ResourceCPU], allocCPU, true),
+		allocationCell(totalReqs[corev1.ResourceMemory], allocMem, false),
+		allocationCell(totalLimits[corev1.ResourceMemory], allocMem, false),
+		"",
+	}, append(append([]string{}, f.Path()...), "allocated"), GreenStyle())
+	allocated.RowItem.details = fmt.Sprintf("requests and limits of the %d non-terminated pods on %s", running, f.Node)
+	return append([]table.Row{allocatable, allocated}, podRows...)
+}
+
+// podRequestsAndLimits returns the effective requests and limits of pod.
+// Init containers run one after another, so only the largest of them counts
+// against the sum of the regular containers; the pod overhead adds to both.
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	reqs, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		addResourceList(reqs, c.Resources.Requests)
+		addResourceList(limits, c.Resources.Limits)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		maxResourceList(reqs, c.Resources.Requests)
+		maxResourceList(limits, c.Resources.Limits)
+	}
+	addResourceList(reqs, pod.Spec.Overhead)
+	for name, q := range pod.Spec.Overhead {
+		// Overhead only raises limits that are set.
+		if l, ok := limits[name]; ok {
+			l.Add(q)
+			limits[name] = l
+		}
+	}
+	return reqs, limits
+}
+
+func addResourceList(list, add corev1.ResourceList) {
+	for name, q := range add {
+		v := list[name]
+		v.Add(q)
+		list[name] = v
+	}
+}
+
+func maxResourceList(list, other corev1.ResourceList) {
+	for name, q := range other {
+		if v, ok := list[name]; !ok || q.Cmp(v) > 0 {
+			list[name] = q.DeepCopy()
+		}
+	}
+}
+
+// allocationCell renders q with its share of allocatable, e.g. "250m (12%)".
+func allocationCell(q, allocatable resource.Quantity, milli bool) string {
+	pct := int64(0)
+	if milli && allocatable.MilliValue() > 0 {
+		pct = q.MilliValue() * 100 / allocatable.MilliValue()
+	} else if !milli && allocatable.Value() > 0 {
+		pct = q.Value() * 100 / allocatable.Value()
+	}
+	return fmt.Sprintf("%s (%d%%)", q.String(), pct)
+}
+
+// startPodInformerForNode marks src dirty on changes of pods scheduled to
+// node. The node's pod informer is held until src has been garbage collected.
+func startPodInformerForNode(deps Deps, node string, src *liveObjectRowSource) {
+	if deps.Cl == nil {
+		return
+	}
+	informer, release, err := deps.Cl.PodInformerForNode(node)
+	if err != nil {
+		return
+	}
+	markDirtyWhileAlive(src, informer, nil, release)
+}
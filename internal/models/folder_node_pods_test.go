@@ -0,0 +1,82 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodePodsRows(t *testing.T) {
+	folder := &NodePodsFolder{BaseFolder: NewBaseFolder(Deps{}, nil, []string{"nodes", "node-1"}), Node: "node-1"}
+	node := &corev1.Node{Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}}}
+	container := func(cpu, mem string) corev1.Container {
+		return corev1.Container{Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(mem)},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(mem)},
+		}}
+	}
+	now := time.Now()
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "web", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("1", "64Mi")},
+				Containers:     []corev1.Container{container("250m", "512Mi"), container("250m", "512Mi")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "job"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{container("1", "1Gi")}},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+
+	var got [][]string
+	var ids []string
+	for _, row := range folder.rowsFor(node, pods, now) {
+		id, cells, _, _ := row.Columns()
+		ids = append(ids, id)
+		got = append(got, cells)
+	}
+	want := [][]string{
+		{"Allocatable", "110 pods", "2", "", "4Gi", "", ""},
+		{"Allocated", "1/110 pods", "1 (50%)", "0 (0%)", "1Gi (25%)", "1Gi (25%)", ""},
+		{"/job", "a", "1 (50%)", "0 (0%)", "1Gi (25%)", "1Gi (25%)", "<unknown>"},
+		{"/web", "b", "1 (50%)", "0 (0%)", "1Gi (25%)", "1Gi (25%)", "60m"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("rows %v, want %v", got, want)
+	}
+	if want := []string{"allocatable", "allocated", "a/job", "b/web"}; !slices.Equal(ids, want) {
+		t.Errorf("ids %v, want %v", ids, want)
+	}
+}
+
+func TestPodRequestsAndLimits(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}}}},
+		Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+		}}},
+		Overhead: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m"), corev1.ResourceMemory: resource.MustParse("1Mi")},
+	}}
+	reqs, limits := podRequestsAndLimits(pod)
+	if cpu := reqs[corev1.ResourceCPU]; cpu.String() != "510m" {
+		t.Errorf("cpu request %s, want 510m", cpu.String())
+	}
+	if cpu := limits[corev1.ResourceCPU]; cpu.String() != "210m" {
+		t.Errorf("cpu limit %s, want 210m", cpu.String())
+	}
+	if _, ok := limits[corev1.ResourceMemory]; ok {
+		t.Error("overhead must not add a memory limit")
+	}
+}
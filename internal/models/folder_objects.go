@@ -3,9 +3,13 @@ package models
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
+	"weak"
 
 	table "github.com/sttts/kc/internal/table"
 	"github.com/sttts/kc/internal/tablecache"
@@ -14,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
 	toolscache "k8s.io/client-go/tools/cache"
 )
 
@@ -23,16 +28,36 @@ type ObjectsFolder struct {
 	gvr       schema.GroupVersionResource
 	namespace string
 	rows      *liveObjectRowSource
+
+	// allNamespaces lists a namespaced resource across every namespace. Rows
+	// then carry their own namespace, shown in an extra Namespace column.
+	allNamespaces bool
+
+	// child overrides the registered child constructor for object rows,
+	// e.g. namespaces that open a group version's resources.
+	child ChildConstructor
+
+	// tableColumns and vis describe the server columns of the last table
+	// populate; incremental row events are only applied while they match.
+	// Both are guarded by the row source lock.
+	tableColumns []metav1.TableColumnDefinition
+	vis          []int
 }
 
 // NewObjectsFolder constructs an object-list folder with the provided metadata.
 func NewObjectsFolder(deps Deps, gvr schema.GroupVersionResource, namespace string, path []string) *ObjectsFolder {
+	return newObjectsFolder(deps, gvr, namespace, false, nil, path)
+}
+
+func newObjectsFolder(deps Deps, gvr schema.GroupVersionResource, namespace string, allNamespaces bool, child ChildConstructor, path []string) *ObjectsFolder {
 	base := NewBaseFolder(deps, nil, path)
 	base.SetColumns([]table.Column{{Title: " Name"}})
 	folder := &ObjectsFolder{
-		BaseFolder: base,
-		gvr:        gvr,
-		namespace:  namespace,
+		BaseFolder:    base,
+		gvr:           gvr,
+		namespace:     namespace,
+		allNamespaces: allNamespaces,
+		child:         child,
 	}
 	rows := newLiveObjectRowSource(folder)
 	folder.rows = rows
@@ -47,11 +72,29 @@ func (o *ObjectsFolder) populateRows(ctx context.Context) ([]table.Row, error) {
 	if rl, err := o.Deps.Cl.ListRowsByGVR(ctx, o.gvr, o.namespace); err == nil && rl != nil && len(rl.Items) > 0 {
 		return o.rowsFromRowList(rl, columnsMode, order), nil
 	}
+	// No server-side table: render the columns locally from the objects.
 	list, err := o.Deps.Cl.ListByGVR(ctx, o.gvr, o.namespace)
 	if err != nil {
+		o.tableColumns, o.vis = nil, nil
 		return nil, err
 	}
-	return o.rowsFromList(list, order), nil
+	return o.rowsFromRowList(o.localPrinter(ctx, len(list.Items) > 0).RowList(list), columnsMode, order), nil
+}
+
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// localPrinter returns the client-side table printer for the folder's
+// resource. Custom resources use the additionalPrinterColumns of their CRD
+// when lookupCRD is set; CRD groups always contain a dot.
+func (o *ObjectsFolder) localPrinter(ctx context.Context, lookupCRD bool) *tablecache.Printer {
+	gvk := o.gvr.GroupVersion().WithKind(o.kindString())
+	var crd *unstructured.Unstructured
+	if lookupCRD && strings.Contains(o.gvr.Group, ".") {
+		if u, err := o.Deps.Cl.GetByGVR(ctx, crdGVR, "", o.gvr.Resource+"."+o.gvr.Group); err == nil {
+			crd = u
+		}
+	}
+	return tablecache.NewPrinter(gvk, crd)
 }
 
 // GVR exposes the folder's group-version-resource identifier.
@@ -71,71 +114,125 @@ func (o *ObjectsFolder) rowsFromRowList(rl *tablecache.RowList, columnsMode, ord
 		c := rl.Columns[vis[i]]
 		cols[i] = table.Column{Title: c.Name}
 	}
+	if o.allNamespaces && len(cols) > 0 {
+		cols = slices.Insert(cols, 1, table.Column{Title: "Namespace"})
+	}
 	o.SetColumns(cols)
+	o.tableColumns = append([]metav1.TableColumnDefinition(nil), rl.Columns...)
+	o.vis = vis
 
 	idxs := orderRowIndices(rl.Items, order)
 	rows := make([]table.Row, 0, len(idxs))
-	nameStyle := WhiteStyle()
-	gvStr := o.gvr.GroupVersion().String()
-	kind := o.kindString()
-	ctor, hasChild := o.childConstructor()
-
 	for _, ii := range idxs {
-		rr := &rl.Items[ii]
-		name := rowName(rr)
-		id := name
-		cells := buildCells(rr.Cells, vis, hasChild)
-		basePath := append(append([]string{}, o.Path()...), name)
-		obj := NewObjectRow(id, cells, basePath, o.gvr, o.namespace, name, nameStyle)
-		obj.WithViewContent(objectViewContent(o.Deps, o.gvr, o.namespace, name))
-		obj.RowItem.details = objectDetails(o.namespace, name, kind, gvStr)
-		if hasChild && ctor != nil {
-			ns := o.namespace
-			nm := name
-			rows = append(rows, NewObjectWithChildItem(obj, func() (Folder, error) {
-				return ctor(o.Deps, ns, nm, basePath), nil
-			}))
-		} else {
-			rows = append(rows, obj)
-		}
+		rows = append(rows, o.rowFromTableRow(&rl.Items[ii], vis))
 	}
 	return rows
 }
 
-func (o *ObjectsFolder) rowsFromList(list *unstructured.UnstructuredList, order string) []table.Row {
-	names := make([]string, 0, len(list.Items))
-	for i := range list.Items {
-		names = append(names, list.Items[i].GetName())
-	}
-	sort.Strings(names)
-	rows := make([]table.Row, 0, len(names))
-	nameStyle := WhiteStyle()
-	gvStr := o.gvr.GroupVersion().String()
-	kind := o.kindString()
+// rowFromTableRow builds the navigation item for a single server-side table row.
+func (o *ObjectsFolder) rowFromTableRow(rr *tablecache.Row, vis []int) table.Row {
 	ctor, hasChild := o.childConstructor()
-	for _, name := range names {
-		basePath := append(append([]string{}, o.Path()...), name)
-		title := name
-		if hasChild {
-			title = "/" + name
+	name := rowName(rr)
+	ns := o.namespace
+	cells := buildCells(rr.Cells, vis, hasChild)
+	basePath := append([]string{}, o.Path()...)
+	if o.allNamespaces {
+		ns = rr.Namespace
+		if len(cells) > 0 {
+			cells = slices.Insert(cells, 1, ns)
 		}
-		obj := NewObjectRow(name, []string{title}, basePath, o.gvr, o.namespace, name, nameStyle)
-		obj.WithViewContent(objectViewContent(o.Deps, o.gvr, o.namespace, name))
-		obj.RowItem.details = objectDetails(o.namespace, name, kind, gvStr)
-		if hasChild && ctor != nil {
-			ns := o.namespace
-			nm := name
-			rows = append(rows, NewObjectWithChildItem(obj, func() (Folder, error) {
-				return ctor(o.Deps, ns, nm, basePath), nil
-			}))
-		} else {
-			rows = append(rows, obj)
+		basePath = append(basePath, ns)
+	}
+	basePath = append(basePath, name)
+	obj := objectRow(o.Deps, o.rowID(ns, name), o.gvr, ns, name, o.kindString(), cells, basePath, rr.CreationTimestamp.Time)
+	if hasChild && ctor != nil {
+		return NewObjectWithChildItem(obj, func() (Folder, error) {
+			return ctor(o.Deps, ns, name, basePath), nil
+		})
+	}
+	return obj
+}
+
+// rowForEvent converts an informer object into a row when it matches the
+// columns currently shown. It must be called with the row source lock held.
+func (o *ObjectsFolder) rowForEvent(obj interface{}) (table.Row, bool) {
+	rr, ok := obj.(*tablecache.Row)
+	if !ok || o.vis == nil || !sameColumnNames(rr.Columns, o.tableColumns) {
+		return nil, false
+	}
+	return o.rowFromTableRow(rr, o.vis), true
+}
+
+// rowID identifies object rows by name, or by namespace/name when the folder
+// lists all namespaces.
+func (o *ObjectsFolder) rowID(namespace, name string) string {
+	if o.allNamespaces {
+		return namespace + "/" + name
+	}
+	return name
+}
+
+func (o *ObjectsFolder) order() string {
+	return objectsOrder(o.Deps)
+}
+
+// objectsOrder returns the configured objects order.
+func objectsOrder(deps Deps) string {
+	if deps.AppConfig == nil {
+		return ""
+	}
+	return deps.AppConfig.Objects.Order
+}
+
+// sortObjectRows sorts rows by the configured objects order, see objectRowLess.
+func sortObjectRows(deps Deps, rows []table.Row) {
+	order := objectsOrder(deps)
+	sort.SliceStable(rows, func(i, j int) bool { return objectRowLess(order, rows[i], rows[j]) })
+}
+
+// objectRow returns the viewable row of the object namespace/name of resource
+// gvr, created at created.
+func objectRow(deps Deps, id string, gvr schema.GroupVersionResource, namespace, name, kind string, cells []string, path []string, created time.Time) *ObjectRow {
+	obj := NewObjectRow(id, cells, path, gvr, namespace, name, WhiteStyle())
+	obj.created = created
+	obj.WithViewContent(objectViewContent(deps, gvr, namespace, name))
+	obj.RowItem.details = objectDetails(namespace, name, kind, gvr.GroupVersion().String())
+	return obj
+}
+
+// objectRowWithChild returns an object row like objectRow that enters the
+// folder registered for gvr, like the rows of object lists.
+func objectRowWithChild(deps Deps, id string, gvr schema.GroupVersionResource, namespace, name, kind string, cells []string, path []string, created time.Time) *ObjectWithChildItem {
+	ctor := childConstructorFor(gvr)
+	return NewObjectWithChildItem(objectRow(deps, id, gvr, namespace, name, kind, cells, path, created), func() (Folder, error) {
+		return ctor(deps, namespace, name, path), nil
+	})
+}
+
+// ageCell renders the age of an object created at created, or <unknown>.
+func ageCell(created, now time.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(created))
+}
+
+func sameColumnNames(a, b []metav1.TableColumnDefinition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
 		}
 	}
-	return rows
+	return true
 }
 
 func (o *ObjectsFolder) kindString() string {
+	if o.Deps.Cl == nil {
+		return ""
+	}
 	if mapper := o.Deps.Cl.RESTMapper(); mapper != nil {
 		if k, err := mapper.KindFor(o.gvr); err == nil {
 			return k.Kind
@@ -145,7 +242,10 @@ func (o *ObjectsFolder) kindString() string {
 }
 
 func (o *ObjectsFolder) childConstructor() (ChildConstructor, bool) {
-	return ChildFor(o.gvr)
+	if o.child != nil {
+		return o.child, true
+	}
+	return childConstructorFor(o.gvr), true
 }
 
 func visibleColumns(cols []metav1.TableColumnDefinition, mode string) []int {
@@ -167,29 +267,61 @@ func orderRowIndices(items []tablecache.Row, order string) []int {
 		if rr == nil {
 			return ""
 		}
-		n := rr.Name
-		if n == "" && len(rr.Cells) > 0 {
-			if s, ok := rr.Cells[0].(string); ok {
-				n = strings.TrimPrefix(s, "/")
-			}
-		}
-		return strings.ToLower(n)
+		return sortName(rr.Namespace, rowName(rr))
 	}
+	sort.Slice(idxs, func(i, j int) bool {
+		a, b := &items[idxs[i]], &items[idxs[j]]
+		return objectLess(order, nameOf(a), a.CreationTimestamp.Time, nameOf(b), b.CreationTimestamp.Time)
+	})
+	return idxs
+}
+
+// objectLess orders objects by the configured objects order. Names break ties
+// so that the order is total and incremental inserts land deterministically.
+func objectLess(order, nameA string, createdA time.Time, nameB string, createdB time.Time) bool {
+	la, lb := strings.ToLower(nameA), strings.ToLower(nameB)
 	switch order {
 	case appconfig.ObjectsOrderNameDesc:
-		sort.Slice(idxs, func(i, j int) bool { return nameOf(&items[idxs[i]]) > nameOf(&items[idxs[j]]) })
+		if la != lb {
+			return la > lb
+		}
+		return nameA > nameB
 	case appconfig.ObjectsOrderCreation:
-		sort.Slice(idxs, func(i, j int) bool {
-			return items[idxs[i]].ObjectMeta.CreationTimestamp.Time.Before(items[idxs[j]].ObjectMeta.CreationTimestamp.Time)
-		})
+		if !createdA.Equal(createdB) {
+			return createdA.Before(createdB)
+		}
 	case appconfig.ObjectsOrderCreationDesc:
-		sort.Slice(idxs, func(i, j int) bool {
-			return items[idxs[i]].ObjectMeta.CreationTimestamp.Time.After(items[idxs[j]].ObjectMeta.CreationTimestamp.Time)
-		})
-	default:
-		sort.Slice(idxs, func(i, j int) bool { return nameOf(&items[idxs[i]]) < nameOf(&items[idxs[j]]) })
+		if !createdA.Equal(createdB) {
+			return createdA.After(createdB)
+		}
 	}
-	return idxs
+	if la != lb {
+		return la < lb
+	}
+	return nameA < nameB
+}
+
+// objectRowLess compares two object rows using objectLess. Rows that do not
+// carry an ObjectRow sort by ID.
+func objectRowLess(order string, a, b table.Row) bool {
+	oa, okA := a.(interface{ objectRow() *ObjectRow })
+	ob, okB := b.(interface{ objectRow() *ObjectRow })
+	if !okA || !okB {
+		ida, _, _, _ := a.Columns()
+		idb, _, _, _ := b.Columns()
+		return ida < idb
+	}
+	ra, rb := oa.objectRow(), ob.objectRow()
+	return objectLess(order, sortName(ra.namespace, ra.name), ra.created, sortName(rb.namespace, rb.name), rb.created)
+}
+
+// sortName orders objects by namespace first. Within a single namespace the
+// shared prefix leaves the name order unchanged.
+func sortName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
 }
 
 func buildCells(cells []interface{}, vis []int, hasChild bool) []string {
@@ -240,33 +372,84 @@ type liveObjectRowSource struct {
 	items         map[string]Item
 	dirty         bool
 	once          sync.Once
+
+	// convert and less enable per-object updates via Upsert/Delete. convert
+	// reports false when an object cannot be applied incrementally, which
+	// falls back to a full repopulate.
+	convert func(obj interface{}) (table.Row, bool)
+	less    func(a, b table.Row) bool
 }
 
 func newLiveObjectRowSource(owner *ObjectsFolder) *liveObjectRowSource {
-	return newLiveObjectRowSourceWithHooks(
+	src := newLiveObjectRowSourceWithHooks(
 		func(ctx context.Context) ([]table.Row, error) { return owner.populateRows(ctx) },
 		owner.BaseFolder.markDirtyFromSource,
-		func(cb func()) { startInformerForObjectsFolder(owner, cb) },
+		nil,
 	)
+	src.convert = owner.rowForEvent
+	src.less = func(a, b table.Row) bool { return objectRowLess(owner.order(), a, b) }
+	startRowInformerForObjectsFolder(owner, src)
+	return src
 }
 
-func newLiveObjectRowSourceWithHooks(populate func(context.Context) ([]table.Row, error), onDirty func(), startInformer func(func())) *liveObjectRowSource {
+func newLiveObjectRowSourceWithHooks(populate func(context.Context) ([]table.Row, error), onDirty func(), startInformer func(*liveObjectRowSource)) *liveObjectRowSource {
 	src := &liveObjectRowSource{
 		populate:      populate,
 		onFolderDirty: onDirty,
 		dirty:         true,
 	}
 	if startInformer != nil {
-		startInformer(src.MarkDirty)
+		startInformer(src)
 	}
 	return src
 }
 
-func startInformerForObjectsFolder(owner *ObjectsFolder, onEvent func()) {
-	if owner == nil {
+// startRowInformerForObjectsFolder feeds table row events of the folder's
+// resource into src. When no row informer is available it falls back to the
+// object informer and full repopulation.
+func startRowInformerForObjectsFolder(owner *ObjectsFolder, src *liveObjectRowSource) {
+	if owner == nil || owner.Deps.Cl == nil {
+		return
+	}
+	ctx := owner.Deps.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	informer, err := owner.Deps.Cl.RowInformerByGVR(ctx, owner.gvr, owner.namespace)
+	if err != nil {
+		startInformerForResource(owner.Deps, owner.gvr, owner.namespace, "", src)
 		return
 	}
-	startInformerForResource(owner.Deps, owner.gvr, owner.namespace, "", onEvent)
+	matches := func(obj interface{}) (*tablecache.Row, bool) {
+		if tomb, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tomb.Obj
+		}
+		row, ok := obj.(*tablecache.Row)
+		if !ok {
+			return nil, false
+		}
+		if owner.namespace != "" && row.Namespace != owner.namespace {
+			return nil, false
+		}
+		return row, true
+	}
+	_, _ = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if row, ok := matches(obj); ok {
+				src.Upsert(row)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if row, ok := matches(newObj); ok {
+				src.Upsert(row)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if row, ok := matches(obj); ok {
+				src.Delete(owner.rowID(row.Namespace, rowName(row)))
+			}
+		},
+	})
 }
 
 func (s *liveObjectRowSource) ensureLocked(ctx context.Context) {
@@ -288,7 +471,13 @@ func (s *liveObjectRowSource) ensureLocked(ctx context.Context) {
 func (s *liveObjectRowSource) rebuildIndexLocked() {
 	s.index = make(map[string]int, len(s.rows))
 	s.items = make(map[string]Item, len(s.rows))
-	for i, row := range s.rows {
+	s.reindexFromLocked(0)
+}
+
+// reindexFromLocked refreshes index entries for rows at positions >= from.
+func (s *liveObjectRowSource) reindexFromLocked(from int) {
+	for i := from; i < len(s.rows); i++ {
+		row := s.rows[i]
 		if row == nil {
 			continue
 		}
@@ -303,25 +492,106 @@ func (s *liveObjectRowSource) rebuildIndexLocked() {
 	}
 }
 
+// Upsert applies a single added or updated object. The row is updated in
+// place when its sort position is unchanged, and otherwise moved to its
+// sorted position. Objects that cannot be converted trigger a full refresh.
+func (s *liveObjectRowSource) Upsert(obj interface{}) {
+	s.mu.Lock()
+	if s.dirty || s.convert == nil || s.less == nil {
+		s.mu.Unlock()
+		s.MarkDirty()
+		return
+	}
+	row, ok := s.convert(obj)
+	if !ok || row == nil {
+		s.mu.Unlock()
+		s.MarkDirty()
+		return
+	}
+	id, cells, _, _ := row.Columns()
+	if idx, exists := s.index[id]; exists {
+		if _, oldCells, _, _ := s.rows[idx].Columns(); slices.Equal(oldCells, cells) {
+			s.mu.Unlock()
+			return
+		}
+		if s.inOrderLocked(idx, row) {
+			s.rows[idx] = row
+			s.reindexFromLocked(idx)
+			s.mu.Unlock()
+			s.notifyFolder()
+			return
+		}
+		s.removeAtLocked(idx)
+	}
+	pos := sort.Search(len(s.rows), func(i int) bool { return s.less(row, s.rows[i]) })
+	s.rows = slices.Insert(s.rows, pos, row)
+	s.reindexFromLocked(pos)
+	s.mu.Unlock()
+	s.notifyFolder()
+}
+
+// Delete removes the row with the given ID, if present.
+func (s *liveObjectRowSource) Delete(id string) {
+	s.mu.Lock()
+	if s.dirty {
+		s.mu.Unlock()
+		s.MarkDirty()
+		return
+	}
+	idx, ok := s.index[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	s.removeAtLocked(idx)
+	s.mu.Unlock()
+	s.notifyFolder()
+}
+
+func (s *liveObjectRowSource) inOrderLocked(idx int, row table.Row) bool {
+	if idx > 0 && s.less(row, s.rows[idx-1]) {
+		return false
+	}
+	if idx+1 < len(s.rows) && s.less(s.rows[idx+1], row) {
+		return false
+	}
+	return true
+}
+
+func (s *liveObjectRowSource) removeAtLocked(idx int) {
+	if id, _, _, ok := s.rows[idx].Columns(); ok {
+		delete(s.index, id)
+		delete(s.items, id)
+	}
+	s.rows = slices.Delete(s.rows, idx, idx+1)
+	s.reindexFromLocked(idx)
+}
+
+func (s *liveObjectRowSource) notifyFolder() {
+	if s.onFolderDirty != nil {
+		s.onFolderDirty()
+	}
+}
+
 func (s *liveObjectRowSource) Lines(ctx context.Context, top, num int) []table.Row {
 	if num <= 0 {
 		return nil
 	}
 	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.ensureLocked(ctx)
-	rows := s.rows
-	s.mu.Unlock()
-	if len(rows) == 0 || top >= len(rows) {
+	if len(s.rows) == 0 || top >= len(s.rows) {
 		return nil
 	}
 	if top < 0 {
 		top = 0
 	}
 	end := top + num
-	if end > len(rows) {
-		end = len(rows)
+	if end > len(s.rows) {
+		end = len(s.rows)
 	}
-	return rows[top:end]
+	// Copy the window: rows are updated in place by Upsert/Delete.
+	return append([]table.Row(nil), s.rows[top:end]...)
 }
 
 func (s *liveObjectRowSource) Above(ctx context.Context, id string, n int) []table.Row {
@@ -405,19 +675,69 @@ func (s *liveObjectRowSource) MarkDirty() {
 	s.mu.Lock()
 	s.dirty = true
 	s.mu.Unlock()
-	if s.onFolderDirty != nil {
-		s.onFolderDirty()
-	}
+	s.notifyFolder()
 }
 
 func newLiveKeyRowSource(deps Deps, gvr schema.GroupVersionResource, namespace, name string, populate func(context.Context) ([]table.Row, error), onDirty func()) *liveObjectRowSource {
-	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(cb func()) {
-		startInformerForResource(deps, gvr, namespace, name, cb)
+	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, gvr, namespace, name, src)
 	})
 }
 
-func startInformerForResource(deps Deps, gvr schema.GroupVersionResource, namespace, name string, onEvent func()) {
-	if onEvent == nil || deps.Cl == nil {
+// eventInformer is an informer that event handlers can be added to and removed
+// from, e.g. a controller-runtime cache informer or a SharedIndexInformer.
+type eventInformer interface {
+	AddEventHandler(toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error)
+	RemoveEventHandler(toolscache.ResourceEventHandlerRegistration) error
+}
+
+// addHandlerWhileAlive adds handler to informer and removes it again once
+// owner has been garbage collected. The handler must only hold owner weakly.
+func addHandlerWhileAlive[T any](owner *T, informer eventInformer, handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	reg, err := informer.AddEventHandler(handler)
+	if err != nil {
+		return nil, err
+	}
+	runtime.AddCleanup(owner, func(reg toolscache.ResourceEventHandlerRegistration) {
+		_ = informer.RemoveEventHandler(reg)
+	}, reg)
+	return reg, nil
+}
+
+// markDirtyWhileAlive marks src dirty on every event of informer about an
+// object accepted by matches, all objects when nil. Once src has been garbage
+// collected, the handler is removed and release, if set, is called, e.g. to
+// stop an informer nobody else holds.
+func markDirtyWhileAlive(src *liveObjectRowSource, informer eventInformer, matches func(obj interface{}) bool, release func()) {
+	if release == nil {
+		release = func() {}
+	}
+	wr := weak.Make(src)
+	onEvent := func(obj interface{}) {
+		if matches != nil && !matches(obj) {
+			return
+		}
+		if s := wr.Value(); s != nil {
+			s.MarkDirty()
+		}
+	}
+	_, err := addHandlerWhileAlive(src, informer, toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    onEvent,
+		UpdateFunc: func(_, newObj interface{}) { onEvent(newObj) },
+		DeleteFunc: onEvent,
+	})
+	if err != nil {
+		release()
+		return
+	}
+	runtime.AddCleanup(src, func(release func()) { release() }, release)
+}
+
+// startInformerForResource marks src dirty on changes of the gvr objects in
+// namespace, restricted to name when set, until src has been garbage
+// collected.
+func startInformerForResource(deps Deps, gvr schema.GroupVersionResource, namespace, name string, src *liveObjectRowSource) {
+	if src == nil || deps.Cl == nil {
 		return
 	}
 	ctx := deps.Ctx
@@ -447,23 +767,7 @@ func startInformerForResource(deps Deps, gvr schema.GroupVersionResource, namesp
 		}
 		return true
 	}
-	_, _ = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			if matches(obj) {
-				onEvent()
-			}
-		},
-		UpdateFunc: func(_, newObj interface{}) {
-			if matches(newObj) {
-				onEvent()
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			if matches(obj) {
-				onEvent()
-			}
-		},
-	})
+	markDirtyWhileAlive(src, informer, matches, nil)
 }
 
 func accessorForEvent(obj interface{}) (metav1.Object, bool) {
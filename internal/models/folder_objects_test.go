@@ -2,10 +2,20 @@ package models
 
 import (
 	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	table "github.com/sttts/kc/internal/table"
+	"github.com/sttts/kc/internal/tablecache"
+	kctesting "github.com/sttts/kc/internal/testing"
+	"github.com/sttts/kc/pkg/appconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
 )
 
 func TestLiveObjectRowSourceRefresh(t *testing.T) {
@@ -32,7 +42,7 @@ func TestLiveObjectRowSourceRefresh(t *testing.T) {
 			return rows, nil
 		},
 		func() { folderDirty++ },
-		func(cb func()) { informer = cb },
+		func(src *liveObjectRowSource) { informer = src.MarkDirty },
 	)
 
 	if informer == nil {
@@ -88,3 +98,197 @@ func TestLiveObjectRowSourceRefresh(t *testing.T) {
 		t.Fatalf("unexpected row count on cached read")
 	}
 }
+
+func TestLiveObjectRowSourceIncrementalUpdates(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	mk := func(name, status string) *ObjectRow {
+		return NewObjectRow(name, []string{name, status}, []string{"pods", name}, gvr, "default", name, WhiteStyle())
+	}
+
+	populateCalls := 0
+	folderDirty := 0
+	src := newLiveObjectRowSourceWithHooks(
+		func(context.Context) ([]table.Row, error) {
+			populateCalls++
+			return []table.Row{mk("a", "Running"), mk("c", "Running"), mk("e", "Running")}, nil
+		},
+		func() { folderDirty++ },
+		nil,
+	)
+	src.convert = func(obj interface{}) (table.Row, bool) {
+		row, ok := obj.(*ObjectRow)
+		return row, ok
+	}
+	src.less = func(a, b table.Row) bool { return objectRowLess("", a, b) }
+
+	ctx := t.Context()
+	ids := func() []string {
+		var out []string
+		for _, row := range src.Lines(ctx, 0, 10) {
+			id, _, _, _ := row.Columns()
+			out = append(out, id)
+		}
+		return out
+	}
+	if got := ids(); !slices.Equal(got, []string{"a", "c", "e"}) {
+		t.Fatalf("unexpected initial rows %v", got)
+	}
+
+	src.Upsert(mk("d", "Pending"))
+	if got := ids(); !slices.Equal(got, []string{"a", "c", "d", "e"}) {
+		t.Fatalf("expected d inserted in order, got %v", got)
+	}
+	if idx, _, ok := src.Find(ctx, "e"); !ok || idx != 3 {
+		t.Fatalf("expected e reindexed to 3, got %d", idx)
+	}
+
+	src.Upsert(mk("c", "Terminating"))
+	_, row, ok := src.Find(ctx, "c")
+	if !ok {
+		t.Fatalf("expected c to remain")
+	}
+	if _, cells, _, _ := row.Columns(); cells[1] != "Terminating" {
+		t.Fatalf("expected c updated in place, got %v", cells)
+	}
+
+	src.Delete("a")
+	if got := ids(); !slices.Equal(got, []string{"c", "d", "e"}) {
+		t.Fatalf("expected a removed, got %v", got)
+	}
+	if below := src.Below(ctx, "c", 1); len(below) != 1 {
+		t.Fatalf("expected one row below c")
+	}
+	if _, ok := src.ItemByID(ctx, "a"); ok {
+		t.Fatalf("expected a to be gone from items")
+	}
+
+	if populateCalls != 1 {
+		t.Fatalf("expected incremental updates without repopulate, got %d populates", populateCalls)
+	}
+	if folderDirty != 3 {
+		t.Fatalf("expected folder notified per change, got %d", folderDirty)
+	}
+
+	src.Upsert("not a row")
+	src.Lines(ctx, 0, 10)
+	if populateCalls != 2 {
+		t.Fatalf("expected unconvertible event to trigger repopulate, got %d", populateCalls)
+	}
+}
+
+func TestAllNamespacesObjectRows(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	folder := newObjectsFolder(Deps{}, gvr, "", true, nil, []string{"all-namespaces", "configmaps"})
+
+	mk := func(ns, name string) tablecache.Row {
+		var r tablecache.Row
+		r.Namespace = ns
+		r.Name = name
+		r.Cells = []interface{}{name, "1"}
+		return r
+	}
+	rl := tablecache.NewRowList(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	rl.Columns = []metav1.TableColumnDefinition{{Name: "Name"}, {Name: "Data"}}
+	rl.Items = []tablecache.Row{mk("b", "cm"), mk("a", "cm"), mk("a", "other")}
+
+	rows := folder.rowsFromRowList(rl, appconfig.ColumnsModeNormal, appconfig.ObjectsOrderName)
+
+	var titles []string
+	for _, c := range folder.Columns() {
+		titles = append(titles, c.Title)
+	}
+	if !slices.Equal(titles, []string{"Name", "Namespace", "Data"}) {
+		t.Fatalf("unexpected columns %v", titles)
+	}
+
+	var ids []string
+	for _, row := range rows {
+		id, cells, _, _ := row.Columns()
+		ids = append(ids, id)
+		obj := row.(interface{ objectRow() *ObjectRow }).objectRow()
+		if len(cells) < 2 || cells[1] != obj.Namespace() {
+			t.Fatalf("row %s: namespace cell %v does not match %q", id, cells, obj.Namespace())
+		}
+		if want := []string{"all-namespaces", "configmaps", obj.Namespace(), obj.Name()}; !slices.Equal(obj.Path(), want) {
+			t.Fatalf("row %s: path %v, want %v", id, obj.Path(), want)
+		}
+	}
+	if !slices.Equal(ids, []string{"a/cm", "a/other", "b/cm"}) {
+		t.Fatalf("unexpected row order %v", ids)
+	}
+}
+
+// fakeEventInformer counts the handlers added and not yet removed.
+type fakeEventInformer struct {
+	mu       sync.Mutex
+	handlers map[toolscache.ResourceEventHandlerRegistration]toolscache.ResourceEventHandler
+}
+
+type fakeRegistration struct{ id int }
+
+func (fakeRegistration) HasSynced() bool { return true }
+
+func (i *fakeEventInformer) AddEventHandler(h toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.handlers == nil {
+		i.handlers = map[toolscache.ResourceEventHandlerRegistration]toolscache.ResourceEventHandler{}
+	}
+	reg := &fakeRegistration{id: len(i.handlers)}
+	i.handlers[reg] = h
+	return reg, nil
+}
+
+func (i *fakeEventInformer) RemoveEventHandler(reg toolscache.ResourceEventHandlerRegistration) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.handlers, reg)
+	return nil
+}
+
+func (i *fakeEventInformer) len() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return len(i.handlers)
+}
+
+func TestAddHandlerWhileAlive(t *testing.T) {
+	informer := &fakeEventInformer{}
+	func() {
+		owner := &ResourceGroupItem{}
+		if _, err := addHandlerWhileAlive(owner, informer, toolscache.ResourceEventHandlerFuncs{}); err != nil {
+			t.Fatal(err)
+		}
+		if informer.len() != 1 {
+			t.Fatalf("got %d handlers, want 1", informer.len())
+		}
+	}()
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		runtime.GC()
+		return informer.len() == 0
+	}, "handler of the collected owner was not removed")
+}
+
+func TestMarkDirtyWhileAlive(t *testing.T) {
+	informer := &fakeEventInformer{}
+	var released atomic.Int32
+	func() {
+		src := newLiveObjectRowSourceWithHooks(func(context.Context) ([]table.Row, error) { return nil, nil }, nil, nil)
+		markDirtyWhileAlive(src, informer, nil, func() { released.Add(1) })
+		src.mu.Lock()
+		src.dirty = false
+		src.mu.Unlock()
+		for _, h := range informer.handlers {
+			h.OnAdd(nil, false)
+		}
+		src.mu.Lock()
+		defer src.mu.Unlock()
+		if !src.dirty {
+			t.Fatalf("event did not mark the source dirty")
+		}
+	}()
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		runtime.GC()
+		return informer.len() == 0 && released.Load() == 1
+	}, "handler of the collected source was not removed and released")
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+	"weak"
+
+	table "github.com/sttts/kc/internal/table"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// OwnedObjectsFolder lists the objects whose ownerReferences point at one
+// owner object, across all resources the cluster already watches. Dependents of a
+// namespaced owner live in its namespace; dependents of a cluster-scoped owner
+// may live anywhere and get an extra Namespace column.
+type OwnedObjectsFolder struct {
+	*BaseFolder
+	gvr       schema.GroupVersionResource
+	kind      string
+	namespace string
+	name      string
+
+	// leading rows are shown before the dependents, e.g. the /events entry.
+	leading []table.Row
+
+	mu    sync.Mutex
+	owned map[string]ownedObject
+
+	rows *liveObjectRowSource
+
+	// registrations are the informer event handlers feeding owned; synced is
+	// set once all of them replayed the existing objects.
+	registrations []ownedRegistration
+	synced        atomic.Bool
+}
+
+// ownedRegistration is an event handler added to a watched informer.
+type ownedRegistration struct {
+	informer crcache.Informer
+	reg      toolscache.ResourceEventHandlerRegistration
+}
+
+// ownedObject is a dependent as seen by an informer of its resource.
+type ownedObject struct {
+	gvr       schema.GroupVersionResource
+	kind      string
+	namespace string
+	name      string
+	created   time.Time
+}
+
+// NewOwnedObjectsFolder constructs the folder listing the dependents of the
+// object namespace/name of resource gvr.
+func NewOwnedObjectsFolder(deps Deps, gvr schema.GroupVersionResource, namespace, name string, parentPath []string) *OwnedObjectsFolder {
+	kind := ""
+	if deps.Cl != nil {
+		if gvk, err := deps.Cl.RESTMapper().KindFor(gvr); err == nil {
+			kind = gvk.Kind
+		}
+	}
+	folder := newOwnedObjectsFolder(deps, gvr, kind, namespace, name, parentPath)
+	folder.leading = []table.Row{newEventsItem(deps, gvr, namespace, name, parentPath)}
+	folder.rows = newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, nil)
+	folder.SetRowSource(folder.rows)
+	folder.startInformers()
+	return folder
+}
+
+func newOwnedObjectsFolder(deps Deps, gvr schema.GroupVersionResource, kind, namespace, name string, parentPath []string) *OwnedObjectsFolder {
+	cols := []table.Column{{Title: " Name"}, {Title: "Kind"}, {Title: "Age"}}
+	if namespace == "" {
+		cols = []table.Column{{Title: " Name"}, {Title: "Namespace"}, {Title: "Kind"}, {Title: "Age"}}
+	}
+	base := NewBaseFolder(deps, cols, append([]string{}, parentPath...))
+	return &OwnedObjectsFolder{
+		BaseFolder: base,
+		gvr:        gvr,
+		kind:       kind,
+		namespace:  namespace,
+		name:       name,
+		owned:      map[string]ownedObject{},
+	}
+}
+
+// Owner returns the resource, namespace and name of the owner object.
+func (f *OwnedObjectsFolder) Owner() (schema.GroupVersionResource, string, string) {
+	return f.gvr, f.namespace, f.name
+}
+
+// startInformers feeds the dependents from the informers the cluster already
+// runs, without starting new ones. The informers replay their existing
+// objects as Add events; changes are only reported once all of them did, and
+// buildRows waits for that. Like resource subscriptions, the handlers only
+// hold the folder weakly and are removed once it is collected.
+func (f *OwnedObjectsFolder) startInformers() {
+	if f.Deps.Cl == nil || f.kind == "" {
+		return
+	}
+	ctx := f.Deps.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	wf := weak.Make(f)
+	changed := func(fn func(f *OwnedObjectsFolder) bool) {
+		if folder := wf.Value(); folder != nil && fn(folder) && folder.synced.Load() {
+			folder.rows.MarkDirty()
+		}
+	}
+	for _, watched := range f.Deps.Cl.WatchedInformers(f.namespace) {
+		gvr, kind := watched.GVR, watched.Kind
+		reg, err := watched.Informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				changed(func(f *OwnedObjectsFolder) bool { return f.track(gvr, kind, obj) })
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				changed(func(f *OwnedObjectsFolder) bool { return f.track(gvr, kind, newObj) })
+			},
+			DeleteFunc: func(obj interface{}) {
+				changed(func(f *OwnedObjectsFolder) bool { return f.untrack(gvr, obj) })
+			},
+		})
+		if err != nil {
+			continue
+		}
+		f.registrations = append(f.registrations, ownedRegistration{informer: watched.Informer, reg: reg})
+	}
+	runtime.AddCleanup(f, func(regs []ownedRegistration) {
+		for _, r := range regs {
+			_ = r.informer.RemoveEventHandler(r.reg)
+		}
+	}, f.registrations)
+	hasSynced := f.hasSynced()
+	go func() {
+		if toolscache.WaitForCacheSync(ctx.Done(), hasSynced...) {
+			if folder := wf.Value(); folder != nil {
+				folder.synced.Store(true)
+				folder.rows.MarkDirty()
+			}
+		}
+	}()
+}
+
+// hasSynced returns the sync checks of all informer registrations.
+func (f *OwnedObjectsFolder) hasSynced() []toolscache.InformerSynced {
+	hasSynced := make([]toolscache.InformerSynced, 0, len(f.registrations))
+	for _, r := range f.registrations {
+		hasSynced = append(hasSynced, r.reg.HasSynced)
+	}
+	return hasSynced
+}
+
+// track records obj when it is owned by the folder's owner and forgets it
+// when it no longer is. It reports whether the set of dependents changed.
+func (f *OwnedObjectsFolder) track(gvr schema.GroupVersionResource, kind string, obj interface{}) bool {
+	accessor, ok := accessorForEvent(obj)
+	if !ok {
+		return false
+	}
+	key := ownedKey(gvr, accessor.GetNamespace(), accessor.GetName())
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, known := f.owned[key]
+	if !f.ownedBy(accessor) {
+		delete(f.owned, key)
+		return known
+	}
+	f.owned[key] = ownedObject{
+		gvr:       gvr,
+		kind:      kind,
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+		created:   accessor.GetCreationTimestamp().Time,
+	}
+	return !known
+}
+
+func (f *OwnedObjectsFolder) untrack(gvr schema.GroupVersionResource, obj interface{}) bool {
+	accessor, ok := accessorForEvent(obj)
+	if !ok {
+		return false
+	}
+	key := ownedKey(gvr, accessor.GetNamespace(), accessor.GetName())
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, known := f.owned[key]
+	delete(f.owned, key)
+	return known
+}
+
+// ownedBy reports whether obj has an owner reference to the folder's owner.
+// References are matched by group, kind and name, so no lookup of the owner's
+// UID is needed.
+func (f *OwnedObjectsFolder) ownedBy(obj metav1.Object) bool {
+	if f.namespace != "" && obj.GetNamespace() != f.namespace {
+		return false
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind != f.kind || ref.Name != f.name {
+			continue
+		}
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil && gv.Group == f.gvr.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *OwnedObjectsFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	// Rows built before the sync completed are refreshed once it does.
+	if !f.synced.Load() {
+		toolscache.WaitForCacheSync(ctx.Done(), f.hasSynced()...)
+	}
+	f.mu.Lock()
+	objs := make([]ownedObject, 0, len(f.owned))
+	for _, o := range f.owned {
+		objs = append(objs, o)
+	}
+	f.mu.Unlock()
+	// Kinds break ties between equally named dependents.
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].kind != objs[j].kind {
+			return objs[i].kind < objs[j].kind
+		}
+		return objs[i].gvr.Group < objs[j].gvr.Group
+	})
+	now := time.Now()
+	rows := make([]table.Row, 0, len(objs))
+	for _, o := range objs {
+		rows = append(rows, f.rowFor(o, now))
+	}
+	sortObjectRows(f.Deps, rows)
+	return append(slices.Clone(f.leading), rows...), nil
+}
+
+func (f *OwnedObjectsFolder) rowFor(o ownedObject, now time.Time) table.Row {
+	age := ageCell(o.created, now)
+	cells := []string{"/" + o.name, o.kind, age}
+	if f.namespace == "" {
+		cells = []string{"/" + o.name, o.namespace, o.kind, age}
+	}
+	basePath := append(append([]string{}, f.Path()...), o.name)
+	return objectRowWithChild(f.Deps, ownedKey(o.gvr, o.namespace, o.name), o.gvr, o.namespace, o.name, o.kind, cells, basePath, o.created)
+}
+
+// ownedKey identifies a dependent across resources, e.g. "replicasets.apps/ns/name".
+func ownedKey(gvr schema.GroupVersionResource, namespace, name string) string {
+	return gvr.GroupResource().String() + "/" + sortName(namespace, name)
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestOwnedObjectsFolderTracksDependents(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	replicasets := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	path := []string{"namespaces", "default", "deployments", "web"}
+	folder := newOwnedObjectsFolder(Deps{}, deployments, "Deployment", "default", "web", path)
+	var markDirty func()
+	folder.SetRowSource(newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, func(src *liveObjectRowSource) { markDirty = src.MarkDirty }))
+
+	mk := func(ns, name string, refs ...metav1.OwnerReference) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, OwnerReferences: refs}}
+	}
+	byWeb := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	changes := 0
+	track := func(gvr schema.GroupVersionResource, kind string, obj *metav1.PartialObjectMetadata) {
+		if folder.track(gvr, kind, obj) {
+			changes++
+			markDirty()
+		}
+	}
+
+	track(replicasets, "ReplicaSet", mk("default", "web-1", byWeb))
+	track(pods, "Pod", mk("default", "web", byWeb))
+	track(replicasets, "ReplicaSet", mk("other", "web-2", byWeb))
+	track(replicasets, "ReplicaSet", mk("default", "api-1", metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "api"}))
+	track(replicasets, "ReplicaSet", mk("default", "web-3", metav1.OwnerReference{APIVersion: "v1", Kind: "Deployment", Name: "web"}))
+	if changes != 2 {
+		t.Fatalf("expected 2 dependents, got %d changes", changes)
+	}
+
+	ctx := t.Context()
+	var ids []string
+	for _, row := range folder.Lines(ctx, 0, folder.Len(ctx)) {
+		if back, ok := row.(Back); ok && back.IsBack() {
+			continue
+		}
+		id, cells, _, _ := row.Columns()
+		ids = append(ids, id)
+		if len(cells) != 3 || cells[0] != "/web" && cells[0] != "/web-1" {
+			t.Errorf("row %s: unexpected cells %v", id, cells)
+		}
+		if _, ok := row.(Enterable); !ok {
+			t.Errorf("row %s is not enterable", id)
+		}
+	}
+	if want := []string{"pods/default/web", "replicasets.apps/default/web-1"}; !slices.Equal(ids, want) {
+		t.Fatalf("rows %v, want %v", ids, want)
+	}
+
+	item, ok := folder.ItemByID(ctx, "pods/default/web")
+	if !ok {
+		t.Fatal("pod row not found")
+	}
+	child, err := item.(Enterable).Enter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := child.(*PodContainersFolder); !ok {
+		t.Errorf("pod row enters %T, want its containers", child)
+	}
+	item, _ = folder.ItemByID(ctx, "replicasets.apps/default/web-1")
+	child, _ = item.(Enterable).Enter()
+	owned, ok := child.(*OwnedObjectsFolder)
+	if !ok {
+		t.Fatalf("replica set row enters %T, want its dependents", child)
+	}
+	if gvr, ns, name := owned.Owner(); gvr != replicasets || ns != "default" || name != "web-1" {
+		t.Errorf("unexpected owner %v %s/%s", gvr, ns, name)
+	}
+	if want := append(slices.Clone(path), "web-1"); !slices.Equal(owned.Path(), want) {
+		t.Errorf("path %v, want %v", owned.Path(), want)
+	}
+
+	// Dropping the owner reference or deleting the object removes the row.
+	track(replicasets, "ReplicaSet", mk("default", "web-1"))
+	if folder.untrack(pods, mk("default", "web")) {
+		changes++
+		markDirty()
+	}
+	if changes != 4 || folder.Len(ctx) != 1 {
+		t.Fatalf("expected only the back row left, got %d rows after %d changes", folder.Len(ctx), changes)
+	}
+}
@@ -33,7 +33,7 @@ func (f *PodContainersFolder) buildRows(ctx context.Context) ([]table.Row, error
 	if err != nil {
 		return nil, err
 	}
-	rows := make([]table.Row, 0, 3)
+	rows := make([]table.Row, 0, 4)
 	sections := []struct {
 		id     string
 		label  string
@@ -57,6 +57,7 @@ func (f *PodContainersFolder) buildRows(ctx context.Context) ([]table.Row, error
 		item.RowItem.details = detail
 		rows = append(rows, item)
 	}
+	rows = append(rows, newEventsItem(f.Deps, podsGVR, f.Namespace, f.Pod, f.Path()))
 	return rows, nil
 }
 
@@ -115,6 +116,7 @@ func (f *PodContainerListFolder) buildRows(ctx context.Context) ([]table.Row, er
 			logsPath := append(append([]string{}, sectionPath...), "logs")
 			return NewPodContainerLogsFolder(f.Deps, logsPath, f.Namespace, f.Pod, c.Name), nil
 		})
+		item.WithExec(fmt.Sprintf("exec:%s/%s", f.Pod, c.Name), containerExec(f.Deps, f.Namespace, f.Pod, c.Name))
 		item.RowItem.details = c.Detail
 		rows = append(rows, item)
 	}
@@ -180,28 +182,31 @@ func NewPodContainerLogsFolder(deps Deps, path []string, namespace, pod, contain
 
 func (f *PodContainerLogsFolder) buildRows(context.Context) ([]table.Row, error) {
 	rows := make([]table.Row, 0, 1)
-	item := NewContainerLogItem("latest", []string{"logs"}, append(append([]string{}, f.Path()...), "latest"), containerLogsViewContent(f.Deps, f.Namespace, f.Pod, f.Container, 200))
+	title := fmt.Sprintf("logs:%s/%s", f.Pod, f.Container)
+	item := NewContainerLogItem("latest", []string{"logs"}, append(append([]string{}, f.Path()...), "latest"), title,
+		containerLogsViewContent(f.Deps, f.Namespace, f.Pod, f.Container, 200),
+		containerLogStream(f.Deps, f.Namespace, f.Pod, f.Container))
 	rows = append(rows, item)
 	return rows, nil
 }
 
 func newPodSectionRowSource(deps Deps, namespace, pod string, populate func(context.Context) ([]table.Row, error), onDirty func()) rowSource {
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
-	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(cb func()) {
-		startInformerForResource(deps, gvr, namespace, pod, cb)
+	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, gvr, namespace, pod, src)
 	})
 }
 
 func newPodContainerRowSource(deps Deps, namespace, pod string, kind containerKind, populate func(context.Context) ([]table.Row, error), onDirty func()) rowSource {
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
-	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(cb func()) {
-		startInformerForResource(deps, gvr, namespace, pod, cb)
+	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, gvr, namespace, pod, src)
 	})
 }
 
 func newPodContainerLogRowSource(deps Deps, namespace, pod, container string, populate func(context.Context) ([]table.Row, error), onDirty func()) rowSource {
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
-	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(cb func()) {
-		startInformerForResource(deps, gvr, namespace, pod, cb)
+	return newLiveObjectRowSourceWithHooks(populate, onDirty, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, gvr, namespace, pod, src)
 	})
 }
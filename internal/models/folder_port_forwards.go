@@ -0,0 +1,120 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sttts/kc/internal/portforward"
+	table "github.com/sttts/kc/internal/table"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// PortForwardsFolder lists the running port-forwards of all contexts. It
+// repaints whenever a forward changes, including its transfer counters.
+type PortForwardsFolder struct {
+	*BaseFolder
+	version atomic.Int64
+}
+
+// NewPortForwardsFolder constructs the port-forwards folder.
+func NewPortForwardsFolder(deps Deps, path []string) *PortForwardsFolder {
+	cols := []table.Column{{Title: " Local"}, {Title: "Target"}, {Title: "Context"}, {Title: "Namespace"}, {Title: "Pod"}, {Title: "Status"}, {Title: "Sent"}, {Title: "Received"}, {Title: "Age"}, {Title: "Error"}}
+	base := NewBaseFolder(deps, cols, path)
+	folder := &PortForwardsFolder{BaseFolder: base}
+	base.SetPopulate(folder.populate)
+	return folder
+}
+
+// IsDirty reports a repaint when forwards changed since the last populate.
+func (f *PortForwardsFolder) IsDirty() bool {
+	if pf := f.Deps.PortForwards; pf != nil && pf.Version() != f.version.Load() {
+		f.markDirty()
+	}
+	return f.BaseFolder.IsDirty()
+}
+
+func (f *PortForwardsFolder) populate(context.Context) ([]table.Row, error) {
+	pf := f.Deps.PortForwards
+	if pf == nil {
+		return nil, nil
+	}
+	f.version.Store(pf.Version())
+	return f.rowsFor(pf.List(), time.Now()), nil
+}
+
+func (f *PortForwardsFolder) rowsFor(forwards []portforward.Info, now time.Time) []table.Row {
+	rows := make([]table.Row, 0, len(forwards))
+	for _, info := range forwards {
+		pod, errMsg := "", ""
+		if info.Pod != "" {
+			pod = fmt.Sprintf("%s:%d", info.Pod, info.PodPort)
+		}
+		if info.Err != nil {
+			errMsg = info.Err.Error()
+		}
+		cells := []string{
+			fmt.Sprintf("localhost:%d", info.LocalPort),
+			info.Target.String(),
+			info.Target.Context,
+			info.Target.Namespace,
+			pod,
+			string(info.Status),
+			formatBytes(info.Sent),
+			formatBytes(info.Received),
+			duration.HumanDuration(now.Sub(info.Started)),
+			errMsg,
+		}
+		styles := make([]*lipgloss.Style, len(cells))
+		for i := range styles {
+			styles[i] = WhiteStyle()
+		}
+		if info.Status != portforward.StatusActive {
+			styles[5] = ErrorStyle()
+		}
+		styles[len(styles)-1] = ErrorStyle()
+		id := strconv.Itoa(info.ID)
+		item := &PortForwardItem{
+			RowItem: NewRowItemStyled(id, cells, append(f.Path(), id), styles),
+			id:      info.ID,
+		}
+		item.RowItem.details = fmt.Sprintf("localhost:%d → %s in %s", info.LocalPort, info.Target, info.Target.Namespace)
+		rows = append(rows, item)
+	}
+	return rows
+}
+
+// PortForwardItem is a row of a running port-forward.
+type PortForwardItem struct {
+	*RowItem
+	id int
+}
+
+// PortForwardID returns the manager ID of the forward.
+func (p *PortForwardItem) PortForwardID() int { return p.id }
+
+// formatBytes renders n with a binary unit, e.g. 1.5 KiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// subscribePortForwardChanges repopulates the folder when forwards start, stop
+// or change their status.
+func (b *BaseFolder) subscribePortForwardChanges() {
+	if b.Deps.PortForwards == nil {
+		return
+	}
+	b.subscribeWeakly(b.Deps.PortForwards.Subscribe)
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sttts/kc/internal/portforward"
+)
+
+func TestPortForwardsFolderRows(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	folder := NewPortForwardsFolder(Deps{Ctx: t.Context()}, []string{"port-forwards"})
+	rows := folder.rowsFor([]portforward.Info{
+		{
+			ID:        3,
+			Target:    portforward.Target{Context: "kind", Namespace: "default", Kind: portforward.KindService, Name: "web", Port: "http"},
+			LocalPort: 8080,
+			Pod:       "web-7d9f",
+			PodPort:   80,
+			Status:    portforward.StatusActive,
+			Sent:      512,
+			Received:  3 << 20,
+			Started:   now.Add(-2 * time.Minute),
+		},
+		{
+			ID:        4,
+			Target:    portforward.Target{Context: "kind", Namespace: "db", Kind: portforward.KindPod, Name: "pg-0", Port: "5432"},
+			LocalPort: 5432,
+			Status:    portforward.StatusReconnecting,
+			Err:       errors.New("lost connection to pod"),
+			Started:   now,
+		},
+	}, now)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	id, cells, _, _ := rows[0].Columns()
+	want := []string{"localhost:8080", "svc/web:http", "kind", "default", "web-7d9f:80", "Active", "512 B", "3.0 MiB", "2m", ""}
+	if id != "3" || !slices.Equal(cells, want) {
+		t.Errorf("got %s %q, want %q", id, cells, want)
+	}
+	if entry, ok := rows[1].(PortForwardEntry); !ok || entry.PortForwardID() != 4 {
+		t.Errorf("row does not identify forward 4")
+	}
+	if _, cells, _, _ := rows[1].Columns(); cells[5] != "Reconnecting" || cells[9] != "lost connection to pod" {
+		t.Errorf("unexpected cells %q", cells)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+
+	for n, want := range map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 5 << 30: "5.0 GiB"} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
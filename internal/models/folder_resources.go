@@ -3,8 +3,10 @@ package models
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"sort"
 	"strings"
+	"weak"
 
 	"github.com/charmbracelet/lipgloss/v2"
 	kccluster "github.com/sttts/kc/internal/cluster"
@@ -24,11 +26,38 @@ type ResourcesFolder struct {
 // NewResourcesFolder constructs a ResourcesFolder with default columns and caller-provided metadata.
 func NewResourcesFolder(base *BaseFolder) *ResourcesFolder {
 	base.SetColumns([]table.Column{{Title: " Name"}, {Title: "Group"}, {Title: "Count"}})
-	return &ResourcesFolder{
+	folder := &ResourcesFolder{
 		BaseFolder: base,
 		items:      make(map[string]*ResourceGroupItem),
 		lastSpecs:  make(map[string]resourceGroupSignature),
 	}
+	base.subscribeResourceChanges()
+	return folder
+}
+
+// subscribeResourceChanges repopulates the folder when discovery reports added
+// or removed resources. The subscription only holds the folder weakly and is
+// dropped once the folder has been garbage collected.
+func (b *BaseFolder) subscribeResourceChanges() {
+	if b.Deps.Cl == nil {
+		return
+	}
+	b.subscribeWeakly(func(fn func()) func() {
+		return b.Deps.Cl.SubscribeResources(func(kccluster.ResourceChange) { fn() })
+	})
+}
+
+// subscribeWeakly marks the folder dirty whenever subscribe reports a change.
+// The subscription only holds the folder weakly and is removed once the
+// folder has been garbage collected.
+func (b *BaseFolder) subscribeWeakly(subscribe func(func()) (unsubscribe func())) {
+	wb := weak.Make(b)
+	unsubscribe := subscribe(func() {
+		if folder := wb.Value(); folder != nil {
+			folder.markDirty()
+		}
+	})
+	runtime.AddCleanup(b, func(unsubscribe func()) { unsubscribe() }, unsubscribe)
 }
 
 func (f *ResourcesFolder) finalize(ctx context.Context, specs []resourceGroupSpec) []table.Row {
@@ -63,7 +92,7 @@ func (f *ResourcesFolder) finalize(ctx context.Context, specs []resourceGroupSpe
 		item.ComputeCountAsync(nil)
 		visible := true
 		if showNonEmpty && item.Empty() {
-			visible = false
+			visible = spec.degraded
 		}
 		sig := makeResourceGroupSignature(spec, visible)
 		sigs[spec.id] = sig
@@ -113,6 +142,29 @@ func (f *ResourcesFolder) finalize(ctx context.Context, specs []resourceGroupSpe
 	return rows
 }
 
+// degradedGroupSpecs lists the group versions that failed discovery so users
+// see why their resources are missing. The entries carry the discovery error
+// as detail, cannot be entered and are never hidden as empty.
+func degradedGroupSpecs(deps Deps, idPrefix string, basePath []string) []resourceGroupSpec {
+	if deps.Cl == nil {
+		return nil
+	}
+	groups := deps.Cl.DegradedGroups()
+	specs := make([]resourceGroupSpec, 0, len(groups))
+	for _, g := range groups {
+		gvLabel := groupVersionString(g.GroupVersion.Group, g.GroupVersion.Version)
+		specs = append(specs, resourceGroupSpec{
+			id:       idPrefix + "degraded/" + gvLabel,
+			cells:    []string{gvLabel, "unavailable", ""},
+			path:     append(append([]string(nil), basePath...), gvLabel),
+			detail:   fmt.Sprintf("%s unavailable: %v", gvLabel, g.Err),
+			style:    ErrorStyle(),
+			degraded: true,
+		})
+	}
+	return specs
+}
+
 func groupVersionString(group, version string) string {
 	if group == "" {
 		return version
@@ -188,6 +240,7 @@ type resourceGroupSpec struct {
 	gvr       schema.GroupVersionResource
 	namespace string
 	watchable bool
+	degraded  bool
 	enter     func() (Folder, error)
 }
 
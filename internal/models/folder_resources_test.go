@@ -104,3 +104,41 @@ func TestResourcesFolderFinalizeVisibilityChanges(t *testing.T) {
 		t.Fatalf("expected finalize to remain clean when filtered result unchanged")
 	}
 }
+
+func TestResourcesFolderFinalizeKeepsDegradedGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	cfg := appconfig.Default()
+	cfg.Resources.ShowNonEmptyOnly = true
+
+	deps := Deps{
+		AppConfig: cfg,
+		Ctx:       ctx,
+	}
+
+	base := NewBaseFolder(deps, nil, nil)
+	folder := NewResourcesFolder(base)
+
+	spec := resourceGroupSpec{
+		id:       "degraded/metrics.k8s.io/v1beta1",
+		cells:    []string{"metrics.k8s.io/v1beta1", "unavailable", ""},
+		path:     []string{"metrics.k8s.io/v1beta1"},
+		detail:   "metrics.k8s.io/v1beta1 unavailable: service unavailable",
+		degraded: true,
+	}
+
+	rows := folder.finalize(ctx, []resourceGroupSpec{spec})
+	if len(rows) != 1 {
+		t.Fatalf("expected degraded group to stay visible with ShowNonEmptyOnly, got %d rows", len(rows))
+	}
+	if details := rows[0].(*ResourceGroupItem).Details(); details != spec.detail {
+		t.Fatalf("expected discovery error as details, got %q", details)
+	}
+	if enterable, ok := rows[0].(Enterable); ok {
+		if next, err := enterable.Enter(); next != nil || err != nil {
+			t.Fatalf("expected degraded group not to be enterable")
+		}
+	}
+}
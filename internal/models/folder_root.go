@@ -8,17 +8,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// RootFolder represents the "/" entry point listing contexts, namespaces, and cluster resources.
+// RootFolder represents the "/" entry point listing contexts, port-forwards,
+// namespaces, and cluster resources. Without a cluster, e.g. after the last
+// context was deleted, it lists only the contexts, kubeconfigs and
+// port-forwards.
 type RootFolder struct {
 	*ClusterResourcesFolder
-	enterContext func(name string, basePath []string) (Folder, error)
+	enterContext EnterContextFunc
 }
 
 // NewRootFolder scaffolds a root folder with default columns.
-func NewRootFolder(deps Deps, enterContext func(name string, basePath []string) (Folder, error)) *RootFolder {
+func NewRootFolder(deps Deps, enterContext EnterContextFunc) *RootFolder {
 	cluster := NewClusterResourcesFolder(deps, nil)
 	root := &RootFolder{ClusterResourcesFolder: cluster, enterContext: enterContext}
 	cluster.BaseFolder.SetPopulate(root.populate)
+	cluster.BaseFolder.subscribeKubeconfigChanges()
+	cluster.BaseFolder.subscribePortForwardChanges()
 	return root
 }
 
@@ -29,7 +34,7 @@ func (f *RootFolder) populate(ctx context.Context) ([]table.Row, error) {
 	rows := make([]table.Row, 0, 64)
 	nameStyle := WhiteStyle()
 
-	if count := len(f.Deps.KubeConfig.Contexts); count > 0 {
+	if count := len(f.Deps.kubeConfig().Contexts); count > 0 {
 		itemPath := append(append([]string{}, f.Path()...), "contexts")
 		enter := func() (Folder, error) {
 			return NewContextsFolder(f.Deps, f.enterContext), nil
@@ -41,6 +46,35 @@ func (f *RootFolder) populate(ctx context.Context) ([]table.Row, error) {
 		}
 	}
 
+	if count := len(f.Deps.kubeconfigFiles()); count > 0 {
+		itemPath := append(append([]string{}, f.Path()...), "kubeconfigs")
+		enter := func() (Folder, error) {
+			return NewKubeconfigsFolder(f.Deps, f.enterContext, itemPath), nil
+		}
+		item := NewContextListItem("kubeconfigs", []string{"/kubeconfigs", "", ""}, itemPath, GreenStyle(), count, enter)
+		if !(showNonEmpty && item.Empty()) {
+			item.Cells[2] = fmt.Sprintf("%d", item.Count())
+			rows = append(rows, item)
+		}
+	}
+
+	if f.Deps.PortForwards != nil {
+		itemPath := append(append([]string{}, f.Path()...), "port-forwards")
+		enter := func() (Folder, error) {
+			return NewPortForwardsFolder(f.Deps, itemPath), nil
+		}
+		count := len(f.Deps.PortForwards.List())
+		item := NewContextListItem("port-forwards", []string{"/port-forwards", "", ""}, itemPath, GreenStyle(), count, enter)
+		if !(showNonEmpty && item.Empty()) {
+			item.Cells[2] = fmt.Sprintf("%d", item.Count())
+			rows = append(rows, item)
+		}
+	}
+
+	if f.Deps.Cl == nil {
+		return rows, nil
+	}
+
 	gvrNamespaces := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}
 	nsPath := append(append([]string{}, f.Path()...), "namespaces")
 	nsPathCopy := append([]string(nil), nsPath...)
@@ -63,7 +97,12 @@ func (f *RootFolder) populate(ctx context.Context) ([]table.Row, error) {
 		return nil, err
 	}
 	groupItems = append(groupItems, clusterItems...)
-	rows = append(rows, f.ResourcesFolder.finalize(ctx, groupItems)...)
+	groupRows := f.ResourcesFolder.finalize(ctx, groupItems)
+	groupRows = withAllNamespaces(groupRows, allNamespacesItem(f.Deps, f.Path()))
+	if cfg.Resources.ShowGroups {
+		groupRows = insertAfterID(groupRows, "all-namespaces", groupsItem(f.Deps, f.Path()))
+	}
+	rows = append(rows, groupRows...)
 
 	return rows, nil
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sttts/kc/pkg/appconfig"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRootFolderWithoutCluster(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	cfg := appconfig.Default()
+	cfg.Resources.ShowNonEmptyOnly = false
+	kubeCfg := clientcmdapi.Config{Contexts: map[string]*clientcmdapi.Context{"dev": {Cluster: "dev"}}}
+	deps := Deps{
+		Ctx:         ctx,
+		AppConfig:   cfg,
+		KubeConfig:  kubeCfg,
+		Kubeconfigs: []KubeconfigFile{{Path: "/home/me/.kube/config", Config: kubeCfg}},
+	}
+
+	root := NewRootFolder(deps, nil)
+	var ids []string
+	for _, row := range root.Lines(ctx, 0, root.Len(ctx)) {
+		id, _, _, _ := row.Columns()
+		ids = append(ids, id)
+	}
+	if want := []string{"contexts", "kubeconfigs"}; !slices.Equal(ids, want) {
+		t.Fatalf("rows %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,250 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	table "github.com/sttts/kc/internal/table"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	servicesGVR       = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	endpointSlicesGVR = schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}
+	podsGVR           = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+// notReadyID is the row ID of the /not-ready entry of a service.
+const notReadyID = "/not-ready"
+
+// ServiceEndpointsFolder lists the endpoint addresses of a service from its
+// EndpointSlices with their conditions. Addresses backed by a pod enter the
+// pod's containers. For services with a selector, a /not-ready entry leads to
+// the matching pods that are not ready. Rows are read from the informers that
+// keep the folder up to date.
+type ServiceEndpointsFolder struct {
+	*BaseFolder
+	Namespace string
+	Service   string
+}
+
+// NewServiceEndpointsFolder constructs the endpoints folder of a service.
+func NewServiceEndpointsFolder(deps Deps, parentPath []string, namespace, service string) *ServiceEndpointsFolder {
+	cols := []table.Column{{Title: " Name"}, {Title: "Address"}, {Title: "Ready"}, {Title: "Serving"}, {Title: "Terminating"}, {Title: "Node"}, {Title: "Slice"}}
+	base := NewBaseFolder(deps, cols, append([]string{}, parentPath...))
+	folder := &ServiceEndpointsFolder{BaseFolder: base, Namespace: namespace, Service: service}
+	rows := newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, servicesGVR, namespace, service, src)
+		startInformerForResource(deps, endpointSlicesGVR, namespace, "", src)
+		startInformerForResource(deps, podsGVR, namespace, "", src)
+	})
+	base.SetRowSource(rows)
+	return folder
+}
+
+func (f *ServiceEndpointsFolder) Parent() (schema.GroupVersionResource, string, string) {
+	return servicesGVR, f.Namespace, f.Service
+}
+
+func (f *ServiceEndpointsFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	obj, err := f.Deps.Cl.CachedGetByGVR(ctx, servicesGVR, f.Namespace, f.Service)
+	if err != nil {
+		return nil, err
+	}
+	var svc corev1.Service
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+		return nil, err
+	}
+	list, err := f.Deps.Cl.CachedListByGVR(ctx, endpointSlicesGVR, f.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var endpointSlices []discoveryv1.EndpointSlice
+	for i := range list.Items {
+		if list.Items[i].GetLabels()[discoveryv1.LabelServiceName] != f.Service {
+			continue
+		}
+		var es discoveryv1.EndpointSlice
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &es); err != nil {
+			continue
+		}
+		endpointSlices = append(endpointSlices, es)
+	}
+	var notReady []corev1.Pod
+	if len(svc.Spec.Selector) > 0 {
+		pods, err := f.Deps.Cl.CachedListByGVR(ctx, podsGVR, f.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		for i := range pods.Items {
+			if !selector.Matches(labels.Set(pods.Items[i].GetLabels())) {
+				continue
+			}
+			var pod corev1.Pod
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(pods.Items[i].Object, &pod); err != nil {
+				continue
+			}
+			if !podReady(&pod) {
+				notReady = append(notReady, pod)
+			}
+		}
+	}
+	rows := []table.Row{newEventsItem(f.Deps, servicesGVR, f.Namespace, f.Service, f.Path())}
+	return append(rows, f.rowsFor(&svc, endpointSlices, notReady)...), nil
+}
+
+// rowsFor builds the /not-ready entry, for services with a selector, followed
+// by one row per endpoint ordered by name and address.
+func (f *ServiceEndpointsFolder) rowsFor(svc *corev1.Service, endpointSlices []discoveryv1.EndpointSlice, notReady []corev1.Pod) []table.Row {
+	var rows []table.Row
+	if len(svc.Spec.Selector) > 0 {
+		path := append(append([]string{}, f.Path()...), "not-ready")
+		item := NewFolderItem(notReadyID, []string{notReadyID, fmt.Sprintf("%d pods", len(notReady)), "", "", "", "", ""}, path, WhiteStyle(), func() (Folder, error) {
+			return NewServiceNotReadyPodsFolder(f.Deps, f.Path(), f.Namespace, f.Service), nil
+		})
+		item.RowItem.details = "pods matching the selector that are not ready"
+		rows = append(rows, item)
+	}
+
+	type endpointRow struct {
+		name, id string
+		row      table.Row
+	}
+	var endpoints []endpointRow
+	for _, es := range endpointSlices {
+		for _, ep := range es.Endpoints {
+			address := strings.Join(ep.Addresses, ",")
+			ready := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+			serving := ready
+			if ep.Conditions.Serving != nil {
+				serving = *ep.Conditions.Serving
+			}
+			terminating := ep.Conditions.Terminating != nil && *ep.Conditions.Terminating
+			node := ""
+			if ep.NodeName != nil {
+				node = *ep.NodeName
+			}
+			id := es.Name + "/" + address
+			name := address
+			if ref := ep.TargetRef; ref != nil && ref.Kind == "Pod" {
+				name = ref.Name
+			}
+			style := WhiteStyle()
+			if !ready {
+				style = DimStyle()
+			}
+			rowPath := append(append([]string{}, f.Path()...), name)
+			details := fmt.Sprintf("%s of %s", address, es.Name)
+			if ref := ep.TargetRef; ref == nil || ref.Kind != "Pod" {
+				cells := []string{name, address, strconv.FormatBool(ready), strconv.FormatBool(serving), strconv.FormatBool(terminating), node, es.Name}
+				item := NewSimpleItem(id, cells, rowPath, style)
+				item.RowItem.details = details
+				endpoints = append(endpoints, endpointRow{name: name, id: id, row: item})
+				continue
+			}
+			podNS := ep.TargetRef.Namespace
+			if podNS == "" {
+				podNS = es.Namespace
+			}
+			cells := []string{"/" + name, address, strconv.FormatBool(ready), strconv.FormatBool(serving), strconv.FormatBool(terminating), node, es.Name}
+			row := objectRowWithChild(f.Deps, id, podsGVR, podNS, name, "Pod", cells, rowPath, time.Time{})
+			row.setStyle(style)
+			row.RowItem.details = details
+			endpoints = append(endpoints, endpointRow{name: name, id: id, row: row})
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].name != endpoints[j].name {
+			return endpoints[i].name < endpoints[j].name
+		}
+		return endpoints[i].id < endpoints[j].id
+	})
+	for _, ep := range endpoints {
+		rows = append(rows, ep.row)
+	}
+	return rows
+}
+
+// ServiceNotReadyPodsFolder lists the pods matching a service's selector
+// whose Ready condition is not true.
+type ServiceNotReadyPodsFolder struct {
+	*BaseFolder
+	Namespace string
+	Service   string
+}
+
+// NewServiceNotReadyPodsFolder constructs the not-ready pods folder of a service.
+func NewServiceNotReadyPodsFolder(deps Deps, parentPath []string, namespace, service string) *ServiceNotReadyPodsFolder {
+	path := append(append([]string{}, parentPath...), "not-ready")
+	cols := []table.Column{{Title: " Name"}, {Title: "Phase"}, {Title: "Reason"}, {Title: "Age"}}
+	base := NewBaseFolder(deps, cols, path)
+	folder := &ServiceNotReadyPodsFolder{BaseFolder: base, Namespace: namespace, Service: service}
+	rows := newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, func(src *liveObjectRowSource) {
+		startInformerForResource(deps, servicesGVR, namespace, service, src)
+		startInformerForResource(deps, podsGVR, namespace, "", src)
+	})
+	base.SetRowSource(rows)
+	return folder
+}
+
+func (f *ServiceNotReadyPodsFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	obj, err := f.Deps.Cl.CachedGetByGVR(ctx, servicesGVR, f.Namespace, f.Service)
+	if err != nil {
+		return nil, err
+	}
+	var svc corev1.Service
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+		return nil, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	list, err := f.Deps.Cl.CachedListByGVR(ctx, podsGVR, f.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	now := time.Now()
+	var rows []table.Row
+	for i := range list.Items {
+		if !selector.Matches(labels.Set(list.Items[i].GetLabels())) {
+			continue
+		}
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &pod); err != nil || podReady(&pod) {
+			continue
+		}
+		name := pod.Name
+		reason := ""
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady {
+				reason = c.Reason
+			}
+		}
+		created := pod.CreationTimestamp.Time
+		cells := []string{"/" + name, string(pod.Status.Phase), reason, ageCell(created, now)}
+		rowPath := append(append([]string{}, f.Path()...), name)
+		rows = append(rows, objectRowWithChild(f.Deps, name, podsGVR, f.Namespace, name, "Pod", cells, rowPath, created))
+	}
+	sortObjectRows(f.Deps, rows)
+	return rows, nil
+}
+
+// podReady reports whether the pod's Ready condition is true.
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
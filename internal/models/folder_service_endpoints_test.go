@@ -0,0 +1,78 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceEndpointsRows(t *testing.T) {
+	folder := &ServiceEndpointsFolder{
+		BaseFolder: NewBaseFolder(Deps{}, nil, []string{"namespaces", "default", "services", "web"}),
+		Namespace:  "default",
+		Service:    "web",
+	}
+	no := false
+	yes := true
+	node := "node-1"
+	slice := discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc"},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &no, Serving: &yes, Terminating: &yes}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+			{Addresses: []string{"10.0.0.1"}, NodeName: &node, TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"}},
+			{Addresses: []string{"192.168.0.1"}},
+		},
+	}
+	svc := &corev1.Service{Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "web"}}}
+	rows := folder.rowsFor(svc, []discoveryv1.EndpointSlice{slice}, []corev1.Pod{{}})
+
+	var got [][]string
+	for _, row := range rows {
+		_, cells, _, _ := row.Columns()
+		got = append(got, cells)
+	}
+	want := [][]string{
+		{"/not-ready", "1 pods", "", "", "", "", ""},
+		{"192.168.0.1", "192.168.0.1", "true", "true", "false", "", "web-abc"},
+		{"/web-1", "10.0.0.1", "true", "true", "false", "node-1", "web-abc"},
+		{"/web-2", "10.0.0.2", "false", "true", "true", "", "web-abc"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("rows %v, want %v", got, want)
+	}
+
+	if _, ok := rows[1].(Enterable); ok {
+		t.Error("address without a pod should not be enterable")
+	}
+	child, err := rows[2].(Enterable).Enter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	containers, ok := child.(*PodContainersFolder)
+	if !ok {
+		t.Fatalf("pod address enters %T, want its containers", child)
+	}
+	if containers.Namespace != "default" || containers.Pod != "web-1" {
+		t.Errorf("unexpected pod %s/%s", containers.Namespace, containers.Pod)
+	}
+
+	// Services without a selector have no not-ready pods to show.
+	rows = folder.rowsFor(&corev1.Service{}, nil, nil)
+	if len(rows) != 0 {
+		t.Errorf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestPodReady(t *testing.T) {
+	pod := &corev1.Pod{}
+	if podReady(pod) {
+		t.Error("pod without conditions is not ready")
+	}
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	if !podReady(pod) {
+		t.Error("expected pod to be ready")
+	}
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	table "github.com/sttts/kc/internal/table"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// usedByID is the row ID of the /used-by entry. The slash keeps it apart from
+// data keys.
+const usedByID = "/used-by"
+
+// podTemplateResources are the resources whose pod specs UsedByFolder scans,
+// with the field path of the pod spec within their objects.
+var podTemplateResources = []struct {
+	gvr  schema.GroupVersionResource
+	kind string
+	spec []string
+}{
+	{gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, kind: "Pod", spec: []string{"spec"}},
+	{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, kind: "Deployment", spec: []string{"spec", "template", "spec"}},
+	{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, kind: "StatefulSet", spec: []string{"spec", "template", "spec"}},
+	{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, kind: "DaemonSet", spec: []string{"spec", "template", "spec"}},
+	{gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, kind: "Job", spec: []string{"spec", "template", "spec"}},
+	{gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, kind: "CronJob", spec: []string{"spec", "jobTemplate", "spec", "template", "spec"}},
+}
+
+// UsedByFolder lists the pods and workloads whose pod specs reference a
+// ConfigMap, Secret, PersistentVolumeClaim or ServiceAccount, together with
+// how they reference it.
+type UsedByFolder struct {
+	*BaseFolder
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+// NewUsedByFolder constructs the folder of consumers of the object
+// namespace/name of resource gvr. It is kept up to date from the informers of
+// the scanned resources.
+func NewUsedByFolder(deps Deps, gvr schema.GroupVersionResource, namespace, name string, parentPath []string) *UsedByFolder {
+	path := append(append([]string{}, parentPath...), "used-by")
+	cols := []table.Column{{Title: " Name"}, {Title: "Kind"}, {Title: "Reference"}, {Title: "Age"}}
+	base := NewBaseFolder(deps, cols, path)
+	folder := &UsedByFolder{BaseFolder: base, gvr: gvr, namespace: namespace, name: name}
+	rows := newLiveObjectRowSourceWithHooks(folder.buildRows, folder.BaseFolder.markDirtyFromSource, func(src *liveObjectRowSource) {
+		for _, res := range podTemplateResources {
+			startInformerForResource(deps, res.gvr, namespace, "", src)
+		}
+	})
+	base.SetRowSource(rows)
+	return folder
+}
+
+// newUsedByItem returns the /used-by entry shown under the object.
+func newUsedByItem(deps Deps, gvr schema.GroupVersionResource, namespace, name string, parentPath []string) *FolderItem {
+	path := append(append([]string{}, parentPath...), "used-by")
+	item := NewFolderItem(usedByID, []string{usedByID}, path, WhiteStyle(), func() (Folder, error) {
+		return NewUsedByFolder(deps, gvr, namespace, name, parentPath), nil
+	})
+	item.RowItem.details = fmt.Sprintf("pods and workloads referencing %s", name)
+	return item
+}
+
+func (f *UsedByFolder) buildRows(ctx context.Context) ([]table.Row, error) {
+	if f.Deps.Cl == nil {
+		return nil, nil
+	}
+	now := time.Now()
+	var rows []table.Row
+	for _, res := range podTemplateResources {
+		// Resources the cluster does not serve have nothing to contribute.
+		list, err := f.Deps.Cl.CachedListByGVR(ctx, res.gvr, f.namespace)
+		if err != nil {
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			m, found, err := unstructured.NestedMap(obj.Object, res.spec...)
+			if err != nil || !found {
+				continue
+			}
+			var spec corev1.PodSpec
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &spec); err != nil {
+				continue
+			}
+			refs := podSpecReferences(&spec, f.gvr.Resource, f.name)
+			if len(refs) == 0 {
+				continue
+			}
+			rows = append(rows, f.rowFor(res.gvr, res.kind, obj, refs, now))
+		}
+	}
+	sortObjectRows(f.Deps, rows)
+	return rows, nil
+}
+
+func (f *UsedByFolder) rowFor(gvr schema.GroupVersionResource, kind string, obj *unstructured.Unstructured, refs []string, now time.Time) table.Row {
+	ns, name := obj.GetNamespace(), obj.GetName()
+	created := obj.GetCreationTimestamp().Time
+	cells := []string{"/" + name, kind, strings.Join(refs, ", "), ageCell(created, now)}
+	basePath := append(append([]string{}, f.Path()...), name)
+	return objectRowWithChild(f.Deps, ownedKey(gvr, ns, name), gvr, ns, name, kind, cells, basePath, created)
+}
+
+// podSpecReferences describes how spec references the object name of the
+// given resource, e.g. "volume config" or "env app/PASSWORD". Pods without a
+// service account name run as "default".
+func podSpecReferences(spec *corev1.PodSpec, resource, name string) []string {
+	var refs []string
+	add := func(ref string) {
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	if resource == "serviceaccounts" {
+		sa := spec.ServiceAccountName
+		if sa == "" {
+			sa = spec.DeprecatedServiceAccount
+		}
+		if sa == "" {
+			sa = "default"
+		}
+		if sa == name {
+			add("serviceAccountName")
+		}
+		return refs
+	}
+
+	for _, v := range spec.Volumes {
+		switch {
+		case resource == "configmaps" && v.ConfigMap != nil && v.ConfigMap.Name == name,
+			resource == "secrets" && v.Secret != nil && v.Secret.SecretName == name,
+			resource == "persistentvolumeclaims" && v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == name:
+			add("volume " + v.Name)
+		case v.Projected != nil:
+			for _, src := range v.Projected.Sources {
+				if resource == "configmaps" && src.ConfigMap != nil && src.ConfigMap.Name == name ||
+					resource == "secrets" && src.Secret != nil && src.Secret.Name == name {
+					add("volume " + v.Name)
+				}
+			}
+		}
+	}
+	if resource == "secrets" {
+		for _, s := range spec.ImagePullSecrets {
+			if s.Name == name {
+				add("imagePullSecrets")
+			}
+		}
+	}
+
+	envRefs := func(container string, envFrom []corev1.EnvFromSource, env []corev1.EnvVar) {
+		for _, ef := range envFrom {
+			if resource == "configmaps" && ef.ConfigMapRef != nil && ef.ConfigMapRef.Name == name ||
+				resource == "secrets" && ef.SecretRef != nil && ef.SecretRef.Name == name {
+				add("envFrom " + container)
+			}
+		}
+		for _, e := range env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if resource == "configmaps" && e.ValueFrom.ConfigMapKeyRef != nil && e.ValueFrom.ConfigMapKeyRef.Name == name ||
+				resource == "secrets" && e.ValueFrom.SecretKeyRef != nil && e.ValueFrom.SecretKeyRef.Name == name {
+				add("env " + container + "/" + e.Name)
+			}
+		}
+	}
+	for _, c := range spec.InitContainers {
+		envRefs(c.Name, c.EnvFrom, c.Env)
+	}
+	for _, c := range spec.Containers {
+		envRefs(c.Name, c.EnvFrom, c.Env)
+	}
+	for _, c := range spec.EphemeralContainers {
+		envRefs(c.Name, c.EnvFrom, c.Env)
+	}
+	return refs
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestPodSpecReferences(t *testing.T) {
+	spec := &corev1.PodSpec{
+		ServiceAccountName: "builder",
+		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}},
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}}},
+			{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "tls"}}},
+			{Name: "bundle", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}},
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "registry"}}},
+			}}}},
+			{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-0"}}},
+		},
+		InitContainers: []corev1.Container{{
+			Name:    "setup",
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "main",
+			Env: []corev1.EnvVar{
+				{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "key"}}},
+				{Name: "PLAIN", Value: "tls"},
+			},
+		}},
+	}
+
+	tests := []struct {
+		resource, name string
+		want           []string
+	}{
+		{"configmaps", "app", []string{"volume config", "volume bundle", "envFrom setup"}},
+		{"secrets", "tls", []string{"volume certs", "env main/PASSWORD"}},
+		{"secrets", "registry", []string{"volume bundle", "imagePullSecrets"}},
+		{"persistentvolumeclaims", "data-0", []string{"volume data"}},
+		{"serviceaccounts", "builder", []string{"serviceAccountName"}},
+		{"serviceaccounts", "default", nil},
+		{"configmaps", "tls", nil},
+	}
+	for _, tt := range tests {
+		if got := podSpecReferences(spec, tt.resource, tt.name); !slices.Equal(got, tt.want) {
+			t.Errorf("%s %s: got %v, want %v", tt.resource, tt.name, got, tt.want)
+		}
+	}
+
+	if got := podSpecReferences(&corev1.PodSpec{}, "serviceaccounts", "default"); !slices.Equal(got, []string{"serviceAccountName"}) {
+		t.Errorf("pods without a service account name should use default, got %v", got)
+	}
+}
+
+func TestUsedByEntryOfClaims(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}
+	ctor, ok := ChildFor(gvr)
+	if !ok {
+		t.Fatal("no child registered for persistentvolumeclaims")
+	}
+	folder := ctor(Deps{}, "default", "data-0", []string{"namespaces", "default", "persistentvolumeclaims", "data-0"})
+	ctx := t.Context()
+	item, ok := folder.ItemByID(ctx, usedByID)
+	if !ok {
+		t.Fatal("missing /used-by entry")
+	}
+	child, err := item.(Enterable).Enter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedBy, ok := child.(*UsedByFolder)
+	if !ok {
+		t.Fatalf("/used-by enters %T", child)
+	}
+	if want := []string{"namespaces", "default", "persistentvolumeclaims", "data-0", "used-by"}; !slices.Equal(usedBy.Path(), want) {
+		t.Errorf("path %v, want %v", usedBy.Path(), want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/charmbracelet/lipgloss/v2"
+	kccluster "github.com/sttts/kc/internal/cluster"
 	table "github.com/sttts/kc/internal/table"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -23,6 +24,20 @@ type ObjectItem interface {
 	Name() string
 }
 
+// KubeconfigContext identifies rows backed by a context of a kubeconfig file.
+// KubeconfigPath is empty when the file is unknown.
+type KubeconfigContext interface {
+	Item
+	ContextName() string
+	KubeconfigPath() string
+}
+
+// PortForwardEntry identifies rows backed by a running port-forward.
+type PortForwardEntry interface {
+	Item
+	PortForwardID() int
+}
+
 // Folder describes a navigable collection of rows.
 type Folder interface {
 	table.List
@@ -31,6 +46,13 @@ type Folder interface {
 	ItemByID(context.Context, string) (Item, bool)
 }
 
+// ClusterFolder identifies folders bound to a cluster connection. Panels use it
+// to resolve actions against the cluster they are browsing.
+type ClusterFolder interface {
+	Folder
+	Cluster() *kccluster.Cluster
+}
+
 // Enterable identifies rows that can return a child folder when Enter is pressed.
 type Enterable interface {
 	Item
@@ -42,6 +64,18 @@ type Viewable interface {
 	ViewContent() (title, body, lang, mime, filename string, err error)
 }
 
+// LogStreamable exposes container logs for the streaming log viewer.
+type LogStreamable interface {
+	LogTitle() string
+	LogStream() LogStreamFunc
+}
+
+// Execable runs interactive commands, e.g. shells in containers.
+type Execable interface {
+	ExecTitle() string
+	Exec() ExecFunc
+}
+
 // Countable reports aggregate information for list-style rows (resource groups, context lists).
 type Countable interface {
 	Count() int
@@ -90,3 +124,8 @@ func DimStyle() *lipgloss.Style {
 	s := lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("#7D7D7D"))
 	return &s
 }
+
+func ErrorStyle() *lipgloss.Style {
+	s := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	return &s
+}
@@ -22,8 +22,10 @@ func (c *ContainerSectionItem) Enter() (Folder, error) {
 // ContainerItem represents a concrete container entry.
 type ContainerItem struct {
 	*RowItem
-	enter  func() (Folder, error)
-	viewFn ViewContentFunc
+	enter     func() (Folder, error)
+	viewFn    ViewContentFunc
+	execTitle string
+	execFn    ExecFunc
 }
 
 func NewContainerItem(id string, cells []string, path []string, style *lipgloss.Style, view ViewContentFunc, enter func() (Folder, error)) *ContainerItem {
@@ -44,13 +46,30 @@ func (c *ContainerItem) ViewContent() (string, string, string, string, string, e
 	return c.viewFn()
 }
 
-// ContainerLogItem represents a log entry for a container.
+// WithExec makes the container execable, e.g. to open a shell in it.
+func (c *ContainerItem) WithExec(title string, exec ExecFunc) *ContainerItem {
+	c.execTitle, c.execFn = title, exec
+	return c
+}
+
+func (c *ContainerItem) ExecTitle() string { return c.execTitle }
+
+func (c *ContainerItem) Exec() ExecFunc { return c.execFn }
+
+// ContainerLogItem represents a log entry for a container. F3 opens it in the
+// streaming log viewer.
 type ContainerLogItem struct {
 	*SimpleItem
+	title  string
+	stream LogStreamFunc
 }
 
-func NewContainerLogItem(id string, cells []string, path []string, view ViewContentFunc) *ContainerLogItem {
+func NewContainerLogItem(id string, cells []string, path []string, title string, view ViewContentFunc, stream LogStreamFunc) *ContainerLogItem {
 	item := NewSimpleItem(id, cells, path, GreenStyle())
 	item.WithViewContent(view)
-	return &ContainerLogItem{SimpleItem: item}
+	return &ContainerLogItem{SimpleItem: item, title: title, stream: stream}
 }
+
+func (c *ContainerLogItem) LogTitle() string { return c.title }
+
+func (c *ContainerLogItem) LogStream() LogStreamFunc { return c.stream }
@@ -5,12 +5,16 @@ import "github.com/charmbracelet/lipgloss/v2"
 // ContextItem represents a kubeconfig context entry, viewable and enterable.
 type ContextItem struct {
 	*RowItem
-	enter  func() (Folder, error)
-	viewFn ViewContentFunc
+	name           string
+	kubeconfigPath string
+	enter          func() (Folder, error)
+	viewFn         ViewContentFunc
 }
 
+var _ KubeconfigContext = (*ContextItem)(nil)
+
 func NewContextItem(id string, cells []string, path []string, style *lipgloss.Style, enter func() (Folder, error)) *ContextItem {
-	return &ContextItem{RowItem: NewRowItem(id, cells, path, style), enter: enter}
+	return &ContextItem{RowItem: NewRowItem(id, cells, path, style), name: id, enter: enter}
 }
 
 func (c *ContextItem) Enter() (Folder, error) {
@@ -25,6 +29,15 @@ func (c *ContextItem) WithViewContent(fn ViewContentFunc) *ContextItem {
 	return c
 }
 
+// WithKubeconfig records the kubeconfig file the context is defined in.
+func (c *ContextItem) WithKubeconfig(path string) *ContextItem {
+	c.kubeconfigPath = path
+	return c
+}
+
+func (c *ContextItem) ContextName() string    { return c.name }
+func (c *ContextItem) KubeconfigPath() string { return c.kubeconfigPath }
+
 func (c *ContextItem) ViewContent() (string, string, string, string, string, error) {
 	if c.viewFn == nil {
 		return "", "", "", "", "", ErrNoViewContent
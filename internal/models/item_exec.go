@@ -0,0 +1,258 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// DefaultExecShells are tried in order when no exec command is configured.
+var DefaultExecShells = [][]string{{"/bin/bash"}, {"/bin/sh"}}
+
+// ExecFunc runs command with a TTY attached to stdin and stdout. The remote
+// terminal follows the sizes received from resize. Cancelling ctx ends the
+// session.
+type ExecFunc func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer, resize remotecommand.TerminalSizeQueue) error
+
+// ExecShell runs the first of commands that starts. A command that exits with
+// 126 or 127, or is reported as not found, before anything was typed counts
+// as missing and the next one is tried. Every attempt reads stdin through its
+// own reader, which is closed when the attempt ends: the executor of a failed
+// attempt may still be blocked reading and must not swallow the first
+// keystroke meant for the next shell. Likewise every attempt receives the
+// terminal sizes through its own queue, starting with the latest size.
+func ExecShell(ctx context.Context, exec ExecFunc, commands [][]string, stdin io.Reader, stdout io.Writer, resize remotecommand.TerminalSizeQueue) error {
+	in := newStdinPump(stdin)
+	var sizes *resizePump
+	if resize != nil {
+		sizes = newResizePump(resize)
+	}
+	var err error
+	for _, command := range commands {
+		attempt := in.attach()
+		var attemptSizes *resizeAttempt
+		var sizeQueue remotecommand.TerminalSizeQueue
+		if sizes != nil {
+			attemptSizes = sizes.attach()
+			sizeQueue = attemptSizes
+		}
+		err = exec(ctx, command, attempt, stdout, sizeQueue)
+		attempt.close()
+		if attemptSizes != nil {
+			attemptSizes.close()
+		}
+		if err == nil || ctx.Err() != nil || in.typed() || !isCommandMissing(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isCommandMissing reports whether err looks like the command does not exist
+// in the container.
+func isCommandMissing(err error) bool {
+	var exit interface{ ExitStatus() int }
+	if errors.As(err, &exit) && (exit.ExitStatus() == 126 || exit.ExitStatus() == 127) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "no such file or directory")
+}
+
+// stdinPump reads stdin in the background and hands what was typed to the
+// reader of the current attempt.
+type stdinPump struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	buf   []byte
+	err   error
+	taken bool
+}
+
+func newStdinPump(r io.Reader) *stdinPump {
+	p := &stdinPump{}
+	p.cond = sync.NewCond(&p.mu)
+	go p.run(r)
+	return p
+}
+
+// run copies r into the buffer until r fails, e.g. when the session closes it.
+func (p *stdinPump) run(r io.Reader) {
+	b := make([]byte, 4096)
+	for {
+		n, err := r.Read(b)
+		p.mu.Lock()
+		p.buf = append(p.buf, b[:n]...)
+		if err != nil {
+			p.err = err
+		}
+		p.cond.Broadcast()
+		p.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// typed reports whether an attempt consumed any input.
+func (p *stdinPump) typed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.taken
+}
+
+func (p *stdinPump) attach() *stdinAttempt { return &stdinAttempt{p: p} }
+
+// stdinAttempt reads the pumped input until it is closed.
+type stdinAttempt struct {
+	p      *stdinPump
+	closed bool
+}
+
+func (a *stdinAttempt) Read(b []byte) (int, error) {
+	p := a.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.buf) == 0 && p.err == nil && !a.closed {
+		p.cond.Wait()
+	}
+	switch {
+	case a.closed:
+		return 0, io.EOF
+	case len(p.buf) > 0:
+		n := copy(b, p.buf)
+		p.buf = p.buf[n:]
+		p.taken = true
+		return n, nil
+	}
+	return 0, p.err
+}
+
+// close ends the attempt; pending and later reads return io.EOF and leave the
+// input to the next attempt.
+func (a *stdinAttempt) close() {
+	a.p.mu.Lock()
+	a.closed = true
+	a.p.cond.Broadcast()
+	a.p.mu.Unlock()
+}
+
+// resizePump reads the terminal sizes in the background and keeps the latest
+// one for the queue of the current attempt.
+type resizePump struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	size remotecommand.TerminalSize
+	gen  int
+	done bool
+}
+
+func newResizePump(q remotecommand.TerminalSizeQueue) *resizePump {
+	p := &resizePump{}
+	p.cond = sync.NewCond(&p.mu)
+	go p.run(q)
+	return p
+}
+
+// run records the sizes of q until q ends, e.g. when the session closes.
+func (p *resizePump) run(q remotecommand.TerminalSizeQueue) {
+	for {
+		size := q.Next()
+		p.mu.Lock()
+		if size == nil {
+			p.done = true
+		} else {
+			p.size = *size
+			p.gen++
+		}
+		p.cond.Broadcast()
+		p.mu.Unlock()
+		if size == nil {
+			return
+		}
+	}
+}
+
+func (p *resizePump) attach() *resizeAttempt { return &resizeAttempt{p: p} }
+
+// resizeAttempt hands out the sizes the attempt has not seen yet until it is
+// closed.
+type resizeAttempt struct {
+	p      *resizePump
+	seen   int
+	closed bool
+}
+
+func (a *resizeAttempt) Next() *remotecommand.TerminalSize {
+	p := a.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.gen == a.seen && !p.done && !a.closed {
+		p.cond.Wait()
+	}
+	if a.closed || p.gen == a.seen {
+		return nil
+	}
+	a.seen = p.gen
+	size := p.size
+	return &size
+}
+
+// close ends the attempt; pending and later calls of Next return nil.
+func (a *resizeAttempt) close() {
+	a.p.mu.Lock()
+	a.closed = true
+	a.p.cond.Broadcast()
+	a.p.mu.Unlock()
+}
+
+// containerExec runs commands in a container, over WebSocket when the server
+// supports it and SPDY otherwise.
+func containerExec(deps Deps, namespace, pod, container string) ExecFunc {
+	return func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer, resize remotecommand.TerminalSizeQueue) error {
+		cfg := rest.CopyConfig(deps.Cl.GetConfig())
+		// Sessions stay open as long as the shell runs.
+		cfg.Timeout = 0
+		cfg = rest.AddUserAgent(cfg, "kc-exec")
+		clientset, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return err
+		}
+		req := clientset.CoreV1().RESTClient().Post().
+			Resource("pods").Namespace(namespace).Name(pod).SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: container,
+				Command:   command,
+				Stdin:     true,
+				Stdout:    true,
+				TTY:       true,
+			}, scheme.ParameterCodec)
+		spdy, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+		if err != nil {
+			return err
+		}
+		websocket, err := remotecommand.NewWebSocketExecutor(cfg, "GET", req.URL().String())
+		if err != nil {
+			return err
+		}
+		executor, err := remotecommand.NewFallbackExecutor(websocket, spdy, httpstream.IsUpgradeFailure)
+		if err != nil {
+			return err
+		}
+		return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdin:             stdin,
+			Stdout:            stdout,
+			Tty:               true,
+			TerminalSizeQueue: resize,
+		})
+	}
+}
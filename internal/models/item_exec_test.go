@@ -0,0 +1,130 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// exitError mimics the error of a remote command exiting with a code.
+type exitError int
+
+func (e exitError) Error() string   { return fmt.Sprintf("command terminated with exit code %d", int(e)) }
+func (e exitError) ExitStatus() int { return int(e) }
+
+func TestExecShell(t *testing.T) {
+	var started []string
+	shells := func(results map[string]error, typed string) ExecFunc {
+		return func(_ context.Context, command []string, stdin io.Reader, _ io.Writer, _ remotecommand.TerminalSizeQueue) error {
+			started = append(started, command[0])
+			if typed != "" {
+				buf := make([]byte, len(typed))
+				if _, err := io.ReadFull(stdin, buf); err != nil {
+					return err
+				}
+			}
+			return results[command[0]]
+		}
+	}
+
+	// A missing shell falls back to the next one.
+	exec := shells(map[string]error{"/bin/bash": exitError(126)}, "")
+	if err := ExecShell(t.Context(), exec, DefaultExecShells, strings.NewReader(""), io.Discard, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(started, []string{"/bin/bash", "/bin/sh"}) {
+		t.Errorf("started %v, want bash then sh", started)
+	}
+
+	// Runtimes reporting the missing executable as an error fall back too.
+	started = nil
+	exec = shells(map[string]error{"/bin/bash": errors.New(`exec: "/bin/bash": stat /bin/bash: no such file or directory`)}, "")
+	if err := ExecShell(t.Context(), exec, DefaultExecShells, strings.NewReader(""), io.Discard, nil); err != nil || len(started) != 2 {
+		t.Errorf("started %v with %v, want the fallback to sh", started, err)
+	}
+
+	// Once the user typed, the exit code is the shell's own.
+	started = nil
+	exec = shells(map[string]error{"/bin/bash": exitError(127)}, "foo\r")
+	if err := ExecShell(t.Context(), exec, DefaultExecShells, strings.NewReader("foo\r"), io.Discard, nil); !errors.Is(err, exitError(127)) {
+		t.Errorf("error %v, want the exit code of bash", err)
+	}
+	if len(started) != 1 {
+		t.Errorf("started %v, want only bash", started)
+	}
+
+	// Input typed after a failed attempt reaches the next shell, even if the
+	// failed attempt is still reading stdin.
+	stdinR, stdinW := io.Pipe()
+	defer stdinW.Close()
+	reading := make(chan struct{})
+	got := make(chan string, 1)
+	exec = func(_ context.Context, command []string, stdin io.Reader, _ io.Writer, _ remotecommand.TerminalSizeQueue) error {
+		if command[0] == "/bin/bash" {
+			go func() {
+				close(reading)
+				_, _ = io.Copy(io.Discard, stdin)
+			}()
+			<-reading
+			return exitError(127)
+		}
+		go func() { _, _ = io.WriteString(stdinW, "x") }()
+		buf := make([]byte, 1)
+		_, err := io.ReadFull(stdin, buf)
+		got <- string(buf)
+		return err
+	}
+	if err := ExecShell(t.Context(), exec, DefaultExecShells, stdinR, io.Discard, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := <-got; s != "x" {
+		t.Errorf("sh read %q, want the keystroke", s)
+	}
+
+	// The size taken by a failed attempt is handed to the next shell again.
+	sizes := &sliceSizeQueue{sizes: []remotecommand.TerminalSize{{Width: 80, Height: 24}}}
+	got = make(chan string, 2)
+	exec = func(_ context.Context, command []string, _ io.Reader, _ io.Writer, resize remotecommand.TerminalSizeQueue) error {
+		size := resize.Next()
+		got <- fmt.Sprintf("%s %v", command[0], *size)
+		if command[0] == "/bin/bash" {
+			return exitError(127)
+		}
+		return nil
+	}
+	if err := ExecShell(t.Context(), exec, DefaultExecShells, strings.NewReader(""), io.Discard, sizes); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"/bin/bash {80 24}", "/bin/sh {80 24}"} {
+		if s := <-got; s != want {
+			t.Errorf("got %q, want %q", s, want)
+		}
+	}
+
+	// Other failures are reported right away.
+	started = nil
+	exec = shells(map[string]error{"/bin/bash": errors.New("pods \"web\" is forbidden")}, "")
+	if err := ExecShell(t.Context(), exec, DefaultExecShells, strings.NewReader(""), io.Discard, nil); err == nil || len(started) != 1 {
+		t.Errorf("started %v with %v, want the error of bash", started, err)
+	}
+}
+
+// sliceSizeQueue hands out sizes and then ends.
+type sliceSizeQueue struct {
+	sizes []remotecommand.TerminalSize
+}
+
+func (q *sliceSizeQueue) Next() *remotecommand.TerminalSize {
+	if len(q.sizes) == 0 {
+		return nil
+	}
+	size := q.sizes[0]
+	q.sizes = q.sizes[1:]
+	return &size
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"context"
+	"io"
+)
+
+// LogOptions selects the log lines a LogStreamFunc streams.
+type LogOptions struct {
+	// Follow keeps the stream open for new lines.
+	Follow bool
+	// Previous streams the logs of the previous, terminated container instance.
+	Previous bool
+	// Timestamps prefixes every line with its RFC3339 timestamp.
+	Timestamps bool
+	// SinceSeconds limits the logs to the last seconds when positive.
+	SinceSeconds int64
+	// TailLines limits the logs to the last lines when positive.
+	TailLines int64
+}
+
+// LogStreamFunc opens a stream of log lines. Cancelling ctx ends the stream.
+type LogStreamFunc func(ctx context.Context, opts LogOptions) (io.ReadCloser, error)
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/charmbracelet/lipgloss/v2"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -11,6 +13,7 @@ type ObjectRow struct {
 	gvr       schema.GroupVersionResource
 	namespace string
 	name      string
+	created   time.Time
 	viewFn    ViewContentFunc
 }
 
@@ -27,6 +30,9 @@ func (o *ObjectRow) GVR() schema.GroupVersionResource { return o.gvr }
 func (o *ObjectRow) Namespace() string                { return o.namespace }
 func (o *ObjectRow) Name() string                     { return o.name }
 
+// objectRow exposes the embedded ObjectRow of wrapper items such as ObjectWithChildItem.
+func (o *ObjectRow) objectRow() *ObjectRow { return o }
+
 func (o *ObjectRow) WithViewContent(fn ViewContentFunc) *ObjectRow {
 	o.viewFn = fn
 	return o
@@ -3,15 +3,15 @@ package models
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
+	"weak"
 
 	"github.com/charmbracelet/lipgloss/v2"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	toolscache "k8s.io/client-go/tools/cache"
 	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
-	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -33,6 +33,11 @@ type ResourceGroupItem struct {
 	lastPeek   time.Time
 	onChange   func()
 
+	// liveCount tracks objects in scope from metadata informer events;
+	// liveSynced flips once the initial replay has been counted.
+	liveCount  atomic.Int64
+	liveSynced atomic.Bool
+
 	publishedCount      int
 	publishedCountKnown bool
 	publishedEmpty      bool
@@ -77,14 +82,28 @@ func (r *ResourceGroupItem) Count() int {
 	if !r.watchable {
 		return 0
 	}
+	if count, ok := r.TryCount(); ok {
+		return count
+	}
+	logger := crlog.FromContext(r.deps.Ctx)
+	logger.Info("initializing metadata informer for resource count", "gvr", r.gvr.String(), "namespace", r.namespace)
+	// Getting the informer blocks until it synced; r.mu stays free meanwhile.
+	informer, err := r.deps.Cl.MetadataInformerByGVR(r.deps.Ctx, r.gvr, crcache.BlockUntilSynced(true))
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.countKnown {
 		return r.count
 	}
-	logger := crlog.FromContext(r.deps.Ctx)
-	logger.Info("initializing informer for resource count", "gvr", r.gvr.String(), "namespace", r.namespace)
-	count, ok := r.countFromInformerLocked()
+	if err != nil {
+		if apierrors.IsMethodNotSupported(err) {
+			logger.Info("resource watch not supported; skipping informer", "gvr", r.gvr.String(), "namespace", r.namespace)
+			r.watchable = false
+			r.count, r.countKnown = 0, true
+			r.empty, r.emptyKnown = true, true
+		}
+		return 0
+	}
+	count, ok := r.countFromInformerLocked(informer)
 	if ok {
 		r.count = count
 		r.countKnown = true
@@ -145,63 +164,75 @@ func (r *ResourceGroupItem) TryCount() (int, bool) {
 	return r.count, true
 }
 
-func (r *ResourceGroupItem) countFromInformerLocked() (int, bool) {
+// countFromInformerLocked counts objects via the shared metadata-only
+// informer for the GVR. The count is maintained from Add/Delete events so the
+// store is never rescanned; after the initial replay every change is published.
+// The handler only holds the item weakly and is removed once the item has been
+// garbage collected, so that closed folders do not linger on the informer.
+func (r *ResourceGroupItem) countFromInformerLocked(informer crcache.Informer) (int, bool) {
 	ctx := r.deps.Ctx
-	gvk, err := r.deps.Cl.RESTMapper().KindFor(r.gvr)
+	wr := weak.Make(r)
+	reg, err := addHandlerWhileAlive(r, informer, toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if item := wr.Value(); item != nil && item.inScope(obj) {
+				item.adjustLiveCount(1)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if item := wr.Value(); item != nil && item.inScope(obj) {
+				item.adjustLiveCount(-1)
+			}
+		},
+	})
 	if err != nil {
 		return 0, false
 	}
-	obj := &unstructured.Unstructured{}
-	obj.SetGroupVersionKind(gvk)
-	informer, err := r.deps.Cl.GetCache().GetInformer(ctx, obj, crcache.BlockUntilSynced(true))
-	if err != nil {
-		if apierrors.IsMethodNotSupported(err) {
-			crlog.FromContext(r.deps.Ctx).Info("resource watch not supported; skipping informer", "gvr", r.gvr.String(), "namespace", r.namespace)
-			r.watchable = false
-			return 0, true
-		}
+	// The registration syncs once the informer's existing objects were replayed as Add events.
+	if !toolscache.WaitForCacheSync(ctx.Done(), reg.HasSynced) {
+		_ = informer.RemoveEventHandler(reg)
+		// A retry registers anew and replays every object again.
+		r.liveCount.Store(0)
+		r.liveSynced.Store(false)
 		return 0, false
 	}
-	if !informer.HasSynced() {
-		toolscache.WaitForCacheSync(ctx.Done(), informer.HasSynced)
-	}
-	type storeInformer interface {
-		GetStore() toolscache.Store
-	}
-	if si, ok := informer.(storeInformer); ok {
-		items := si.GetStore().List()
-		if r.namespace == "" {
-			return len(items), true
-		}
-		count := 0
-		for _, raw := range items {
-			switch o := raw.(type) {
-			case crclient.Object:
-				if o.GetNamespace() == r.namespace {
-					count++
-				}
-			case *unstructured.Unstructured:
-				if o.GetNamespace() == r.namespace {
-					count++
-				}
-			}
-		}
-		return count, true
+	r.liveSynced.Store(true)
+	return int(r.liveCount.Load()), true
+}
+
+func (r *ResourceGroupItem) inScope(obj interface{}) bool {
+	if r.namespace == "" {
+		return true
 	}
-	// Fallback: cache-backed list
-	ul := &unstructured.UnstructuredList{}
-	ul.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind + "List"})
-	opts := []crclient.ListOption{}
-	if r.namespace != "" {
-		opts = append(opts, crclient.InNamespace(r.namespace))
+	accessor, ok := accessorForEvent(obj)
+	return ok && accessor.GetNamespace() == r.namespace
+}
+
+// adjustLiveCount applies a delta from the metadata informer and, once the
+// initial replay has completed, publishes the new count.
+func (r *ResourceGroupItem) adjustLiveCount(delta int64) {
+	n := int(r.liveCount.Add(delta))
+	if !r.liveSynced.Load() {
+		return
 	}
-	if err := r.deps.Cl.GetCache().List(ctx, ul, opts...); err != nil {
-		return 0, false
+	r.mu.Lock()
+	r.count = n
+	r.countKnown = true
+	r.empty = n == 0
+	r.emptyKnown = true
+	changed := r.recordPublishedLocked()
+	onChange := r.onChange
+	r.mu.Unlock()
+	if changed && onChange != nil {
+		onChange()
 	}
-	return len(ul.Items), true
 }
 
+// peekEmptyLocked answers from the metadata informer when it is synced and
+// otherwise issues a metadata-only peek against the server.
 func (r *ResourceGroupItem) peekEmptyLocked() (bool, bool) {
+	if r.liveSynced.Load() {
+		return r.liveCount.Load() == 0, true
+	}
 	ctx := r.deps.Ctx
 	has, err := r.deps.Cl.HasAnyByGVR(ctx, r.gvr, r.namespace)
 	if err != nil {
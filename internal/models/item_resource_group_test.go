@@ -1,6 +1,11 @@
 package models
 
-import "testing"
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
 
 func TestResourceGroupItemNotifyIfChanged(t *testing.T) {
 	r := &ResourceGroupItem{
@@ -68,3 +73,47 @@ func TestResourceGroupItemNotifyOnUpdateCallback(t *testing.T) {
 		t.Fatalf("expected no additional callbacks, got change=%d update=%d", changeCount, updateCount)
 	}
 }
+
+func TestResourceGroupItemLiveCount(t *testing.T) {
+	r := &ResourceGroupItem{RowItem: NewRowItem("group", nil, nil, nil), watchable: true, namespace: "ns1"}
+
+	var fired int
+	r.SetOnChange(func() { fired++ })
+
+	// Replayed objects before the registration synced are only tallied.
+	inNS := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1"}}
+	otherNS := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns2"}}
+	for _, obj := range []interface{}{inNS, otherNS} {
+		if r.inScope(obj) {
+			r.adjustLiveCount(1)
+		}
+	}
+	if fired != 0 {
+		t.Fatalf("expected no publish before sync, got %d", fired)
+	}
+	r.liveSynced.Store(true)
+	if empty, ok := r.peekEmptyLocked(); !ok || empty {
+		t.Fatalf("expected non-empty from live count, got empty=%v ok=%v", empty, ok)
+	}
+
+	r.adjustLiveCount(1)
+	if got, ok := r.TryCount(); !ok || got != 2 {
+		t.Fatalf("expected count 2, got %d (known=%v)", got, ok)
+	}
+	if fired != 1 {
+		t.Fatalf("expected publish after sync, got %d", fired)
+	}
+
+	tomb := toolscache.DeletedFinalStateUnknown{Key: "ns1/a", Obj: inNS}
+	if !r.inScope(tomb) {
+		t.Fatalf("expected tombstone in scope")
+	}
+	r.adjustLiveCount(-1)
+	r.adjustLiveCount(-1)
+	if got, _ := r.TryCount(); got != 0 {
+		t.Fatalf("expected count 0, got %d", got)
+	}
+	if empty, ok := r.peekEmptyLocked(); !ok || !empty {
+		t.Fatalf("expected empty from live count")
+	}
+}
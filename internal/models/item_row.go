@@ -64,6 +64,13 @@ func (r *RowItem) reset(id string, cells []string, path []string, style *lipglos
 	r.path = append([]string(nil), path...)
 }
 
+// setStyle renders every cell of the row with style.
+func (r *RowItem) setStyle(style *lipgloss.Style) {
+	for i := range r.SimpleRow.Styles {
+		r.SimpleRow.Styles[i] = style
+	}
+}
+
 // SetDetails updates the human-readable details string exposed via Details().
 func (r *RowItem) SetDetails(detail string) {
 	if r == nil {
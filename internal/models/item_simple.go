@@ -23,3 +23,20 @@ func (s *SimpleItem) ViewContent() (string, string, string, string, string, erro
 	}
 	return s.viewFn()
 }
+
+// FolderItem is an enterable row without object or count semantics.
+type FolderItem struct {
+	*RowItem
+	enter func() (Folder, error)
+}
+
+func NewFolderItem(id string, cells []string, path []string, style *lipgloss.Style, enter func() (Folder, error)) *FolderItem {
+	return &FolderItem{RowItem: NewRowItem(id, cells, path, style), enter: enter}
+}
+
+func (f *FolderItem) Enter() (Folder, error) {
+	if f.enter == nil {
+		return nil, nil
+	}
+	return f.enter()
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/sttts/kc/internal/subscribers"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeconfigSource holds the current kubeconfig view shared by all folders.
+// The UI replaces it when kubeconfig files change on disk; folders listing
+// contexts or kubeconfig files repopulate when it does.
+type KubeconfigSource struct {
+	mu     sync.Mutex
+	config clientcmdapi.Config
+	files  []KubeconfigFile
+	subs   subscribers.List[struct{}]
+}
+
+// NewKubeconfigSource returns a source seeded with the aggregated contexts and
+// the discovered kubeconfig files.
+func NewKubeconfigSource(config clientcmdapi.Config, files []KubeconfigFile) *KubeconfigSource {
+	return &KubeconfigSource{config: config, files: slices.Clone(files)}
+}
+
+// Get returns the current aggregated contexts and kubeconfig files.
+func (s *KubeconfigSource) Get() (clientcmdapi.Config, []KubeconfigFile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config, slices.Clone(s.files)
+}
+
+// Set replaces the kubeconfig view and notifies subscribers.
+func (s *KubeconfigSource) Set(config clientcmdapi.Config, files []KubeconfigFile) {
+	s.mu.Lock()
+	s.config, s.files = config, slices.Clone(files)
+	s.mu.Unlock()
+	s.subs.Notify(struct{}{})
+}
+
+// Subscribe registers fn to be called after Set. The returned function
+// removes the subscription.
+func (s *KubeconfigSource) Subscribe(fn func()) (unsubscribe func()) {
+	return s.subs.Subscribe(func(struct{}) { fn() })
+}
+
+// kubeConfig returns the aggregated contexts, preferring the live source over
+// the snapshot taken when the deps were built.
+func (d Deps) kubeConfig() clientcmdapi.Config {
+	if d.KubeconfigSource == nil {
+		return d.KubeConfig
+	}
+	cfg, _ := d.KubeconfigSource.Get()
+	cfg.CurrentContext = d.KubeConfig.CurrentContext
+	return cfg
+}
+
+// kubeconfigFiles returns the discovered kubeconfig files, preferring the live source.
+func (d Deps) kubeconfigFiles() []KubeconfigFile {
+	if d.KubeconfigSource == nil {
+		return d.Kubeconfigs
+	}
+	_, files := d.KubeconfigSource.Get()
+	return files
+}
+
+// subscribeKubeconfigChanges repopulates the folder when the kubeconfig source
+// changes.
+func (b *BaseFolder) subscribeKubeconfigChanges() {
+	if b.Deps.KubeconfigSource == nil {
+		return
+	}
+	b.subscribeWeakly(b.Deps.KubeconfigSource.Subscribe)
+}
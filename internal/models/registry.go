@@ -3,6 +3,8 @@ package models
 import (
 	"sync"
 
+	table "github.com/sttts/kc/internal/table"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -30,6 +32,17 @@ func ChildFor(gvr schema.GroupVersionResource) (ChildConstructor, bool) {
 	return ctor, ok
 }
 
+// childConstructorFor returns the registered constructor for the GVR and
+// otherwise one for the folder of objects owned by the object.
+func childConstructorFor(gvr schema.GroupVersionResource) ChildConstructor {
+	if ctor, ok := ChildFor(gvr); ok {
+		return ctor
+	}
+	return func(deps Deps, ns, name string, basePath []string) Folder {
+		return NewOwnedObjectsFolder(deps, gvr, ns, name, basePath)
+	}
+}
+
 func init() {
 	RegisterChild(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}, func(deps Deps, ns, name string, basePath []string) Folder {
 		return NewPodContainersFolder(deps, basePath, ns, name)
@@ -40,6 +53,30 @@ func init() {
 	RegisterChild(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}, func(deps Deps, ns, name string, basePath []string) Folder {
 		return NewSecretKeysFolder(deps, basePath, ns, name)
 	})
+	RegisterChild(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "services"}, func(deps Deps, ns, name string, basePath []string) Folder {
+		return NewServiceEndpointsFolder(deps, basePath, ns, name)
+	})
+	RegisterChild(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "nodes"}, func(deps Deps, _ string, name string, basePath []string) Folder {
+		return NewNodePodsFolder(deps, basePath, name)
+	})
+	RegisterChild(crdGVR, func(deps Deps, _ string, name string, basePath []string) Folder {
+		return newCRDChild(deps, name, basePath)
+	})
+	for _, resource := range []string{"persistentvolumeclaims", "serviceaccounts"} {
+		gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: resource}
+		RegisterChild(gvr, func(deps Deps, ns, name string, basePath []string) Folder {
+			folder := NewOwnedObjectsFolder(deps, gvr, ns, name, basePath)
+			folder.leading = append([]table.Row{newUsedByItem(deps, gvr, ns, name, basePath)}, folder.leading...)
+			return folder
+		})
+	}
+	for _, gvr := range workloadLogResources {
+		RegisterChild(gvr, func(deps Deps, ns, name string, basePath []string) Folder {
+			folder := NewOwnedObjectsFolder(deps, gvr, ns, name, basePath)
+			folder.leading = append(folder.leading, newWorkloadLogsItem(deps, gvr, ns, name, basePath))
+			return folder
+		})
+	}
 	RegisterChild(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}, func(deps Deps, ns, name string, basePath []string) Folder {
 		return NewNamespacedResourcesFolder(deps, name, basePath)
 	})
@@ -1,15 +1,25 @@
 package models
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/sttts/kc/pkg/kubeconfig"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/yaml"
 )
 
@@ -27,6 +37,9 @@ func objectViewContent(deps Deps, gvr schema.GroupVersionResource, namespace, na
 		if err != nil {
 			return "", "", "", "", "", err
 		}
+		if gvr != eventsGVR {
+			data = appendEventsComment(data, deps, obj.GetNamespace(), obj.GetUID())
+		}
 		title := name
 		if title == "" {
 			title = obj.GetName()
@@ -38,6 +51,52 @@ func objectViewContent(deps Deps, gvr schema.GroupVersionResource, namespace, na
 	}
 }
 
+// appendEventsComment appends the events regarding the object with the given
+// UID to its YAML, like the Events section of kubectl describe. Being a
+// comment, it keeps the YAML valid to edit or save. Events that cannot be
+// listed are left out.
+func appendEventsComment(data []byte, deps Deps, namespace string, uid types.UID) []byte {
+	events, err := eventsRegarding(deps.Ctx, deps, namespace, uid)
+	if err != nil || len(events) == 0 {
+		return data
+	}
+	return append(data, eventsComment(events, time.Now())...)
+}
+
+// eventsComment renders events as an aligned YAML comment block.
+func eventsComment(events []eventsv1.Event, now time.Time) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Type\tReason\tCount\tAge\tMessage")
+	for i := range events {
+		cells := eventCells(&events[i], now)
+		for j, cell := range cells {
+			// Multi-line messages would end the comment.
+			cells[j] = strings.Join(strings.Fields(cell), " ")
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+	var out strings.Builder
+	out.WriteString("\n# Events:\n")
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		out.WriteString("#   " + strings.TrimRight(line, " ") + "\n")
+	}
+	return out.String()
+}
+
+// contextViewContent renders the stanza of a kubeconfig context with
+// credentials redacted.
+func contextViewContent(cfg clientcmdapi.Config, name string) ViewContentFunc {
+	return func() (string, string, string, string, string, error) {
+		out, err := kubeconfig.RedactedContext(&cfg, name)
+		if err != nil {
+			return "", "", "", "", "", err
+		}
+		return name, string(out), "yaml", "application/yaml", name + ".yaml", nil
+	}
+}
+
 func keyViewContent(deps Deps, gvr schema.GroupVersionResource, namespace, name, key string, secret bool) ViewContentFunc {
 	return func() (string, string, string, string, string, error) {
 		obj, err := deps.Cl.GetByGVR(deps.Ctx, gvr, namespace, name)
@@ -105,16 +164,11 @@ func containerViewContent(deps Deps, namespace, pod, container string) ViewConte
 
 func containerLogsViewContent(deps Deps, namespace, pod, container string, tailLines int64) ViewContentFunc {
 	return func() (string, string, string, string, string, error) {
-		cfg := rest.CopyConfig(deps.Cl.GetConfig())
-		clientset, err := kubernetes.NewForConfig(rest.AddUserAgent(cfg, "kc-log-view"))
+		clientset, err := logsClientset(deps)
 		if err != nil {
 			return "", "", "", "", "", err
 		}
-		opts := &corev1.PodLogOptions{Container: container}
-		if tailLines > 0 {
-			opts.TailLines = &tailLines
-		}
-		req := clientset.CoreV1().Pods(namespace).GetLogs(pod, opts)
+		req := clientset.CoreV1().Pods(namespace).GetLogs(pod, podLogOptions(container, LogOptions{TailLines: tailLines}))
 		data, err := req.Do(deps.Ctx).Raw()
 		if err != nil {
 			return "", "", "", "", "", err
@@ -124,6 +178,42 @@ func containerLogsViewContent(deps Deps, namespace, pod, container string, tailL
 	}
 }
 
+// containerLogStream streams the logs of a container for the log viewer.
+func containerLogStream(deps Deps, namespace, pod, container string) LogStreamFunc {
+	return func(ctx context.Context, opts LogOptions) (io.ReadCloser, error) {
+		clientset, err := logsClientset(deps)
+		if err != nil {
+			return nil, err
+		}
+		return clientset.CoreV1().Pods(namespace).GetLogs(pod, podLogOptions(container, opts)).Stream(ctx)
+	}
+}
+
+func logsClientset(deps Deps) (*kubernetes.Clientset, error) {
+	cfg := rest.CopyConfig(deps.Cl.GetConfig())
+	// Followed streams stay open as long as the viewer does.
+	cfg.Timeout = 0
+	return kubernetes.NewForConfig(rest.AddUserAgent(cfg, "kc-log-view"))
+}
+
+func podLogOptions(container string, opts LogOptions) *corev1.PodLogOptions {
+	out := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     opts.Follow,
+		Previous:   opts.Previous,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.SinceSeconds > 0 {
+		since := opts.SinceSeconds
+		out.SinceSeconds = &since
+	}
+	if opts.TailLines > 0 {
+		tail := opts.TailLines
+		out.TailLines = &tail
+	}
+	return out
+}
+
 func findContainer(obj map[string]interface{}, name string) map[string]interface{} {
 	if arr, found, _ := unstructured.NestedSlice(obj, "spec", "containers"); found {
 		for _, c := range arr {
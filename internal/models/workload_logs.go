@@ -0,0 +1,218 @@
+package models
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// logsID is the row ID of the /logs entry of workloads.
+const logsID = "/logs"
+
+// workloadLogResources are the workloads with a /logs entry streaming the
+// logs of all their pods.
+var workloadLogResources = []schema.GroupVersionResource{
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+}
+
+// logPrefixColors are the ANSI foreground colors of the pod/container
+// prefixes, picked by pod name.
+var logPrefixColors = []string{"31", "32", "33", "34", "35", "36", "91", "92", "93", "94", "95", "96"}
+
+// newWorkloadLogsItem returns the /logs entry shown under a workload.
+func newWorkloadLogsItem(deps Deps, gvr schema.GroupVersionResource, namespace, name string, parentPath []string) *ContainerLogItem {
+	path := append(append([]string{}, parentPath...), "logs")
+	item := NewContainerLogItem(logsID, []string{"logs"}, path, "logs:"+name, nil, workloadLogStream(deps, gvr, namespace, name))
+	item.RowItem.details = fmt.Sprintf("logs of all pods of %s", name)
+	return item
+}
+
+// workloadLogStream streams the logs of every container of the pods selected
+// by the workload concurrently, each line prefixed with pod/container. Pods
+// and restarted containers are picked up from an informer of just the
+// workload's pods, which runs while the stream is open.
+func workloadLogStream(deps Deps, gvr schema.GroupVersionResource, namespace, name string) LogStreamFunc {
+	return func(ctx context.Context, opts LogOptions) (io.ReadCloser, error) {
+		obj, err := deps.Cl.GetByGVR(ctx, gvr, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := workloadSelector(obj)
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := logsClientset(deps)
+		if err != nil {
+			return nil, err
+		}
+		informer, release, err := deps.Cl.PodInformerForSelector(namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+
+		r, w := io.Pipe()
+		logs := newMultiPodLogs(ctx, opts, w, func(ctx context.Context, pod, container string, opts LogOptions) (io.ReadCloser, error) {
+			return clientset.CoreV1().Pods(namespace).GetLogs(pod, podLogOptions(container, opts)).Stream(ctx)
+		})
+		observe := func(obj interface{}) {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			var pod corev1.Pod
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &pod); err != nil {
+				return
+			}
+			logs.observe(&pod)
+		}
+		reg, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    observe,
+			UpdateFunc: func(_, obj interface{}) { observe(obj) },
+		})
+		if err != nil {
+			release()
+			_ = w.Close()
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			_ = informer.RemoveEventHandler(reg)
+			release()
+			_ = w.CloseWithError(io.EOF)
+		}()
+		return r, nil
+	}
+}
+
+// workloadSelector returns the pod selector of a workload object.
+func workloadSelector(obj *unstructured.Unstructured) (labels.Selector, error) {
+	m, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s has no pod selector", obj.GetName())
+	}
+	var ls metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &ls); err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&ls)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("%s has an empty pod selector", obj.GetName())
+	}
+	return selector, nil
+}
+
+// containerLogOpener opens the log stream of one container of a pod.
+type containerLogOpener func(ctx context.Context, pod, container string, opts LogOptions) (io.ReadCloser, error)
+
+// multiPodLogs merges the log streams of the containers of several pods into
+// one writer, line by line.
+type multiPodLogs struct {
+	ctx  context.Context
+	opts LogOptions
+	open containerLogOpener
+
+	// writeMu serializes lines; it is separate from mu so that a slow
+	// reader never blocks the informer handler calling observe.
+	writeMu sync.Mutex
+	w       io.Writer
+
+	mu sync.Mutex
+	// active are the containers streamed right now; streamed records the
+	// restart count each container was last streamed with.
+	active   map[string]bool
+	streamed map[string]int32
+}
+
+func newMultiPodLogs(ctx context.Context, opts LogOptions, w io.Writer, open containerLogOpener) *multiPodLogs {
+	return &multiPodLogs{ctx: ctx, opts: opts, open: open, w: w, active: map[string]bool{}, streamed: map[string]int32{}}
+}
+
+// observe starts streaming the containers of pod that have logs and are not
+// streamed yet. A container is streamed again after it restarted; only the
+// first stream of a container is limited by the tail and since options.
+func (m *multiPodLogs) observe(pod *corev1.Pod) {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, st := range statuses {
+		if st.State.Running == nil && st.State.Terminated == nil {
+			continue
+		}
+		key := pod.Name + "/" + st.Name
+		if m.active[key] {
+			continue
+		}
+		opts := m.opts
+		if restarts, ok := m.streamed[key]; ok {
+			if restarts == st.RestartCount || !m.opts.Follow || m.opts.Previous {
+				continue
+			}
+			opts.TailLines, opts.SinceSeconds = 0, 0
+		}
+		m.active[key] = true
+		m.streamed[key] = st.RestartCount
+		go m.stream(pod.Name, st.Name, opts)
+	}
+}
+
+func (m *multiPodLogs) stream(pod, container string, opts LogOptions) {
+	key := pod + "/" + container
+	defer func() {
+		m.mu.Lock()
+		delete(m.active, key)
+		m.mu.Unlock()
+	}()
+	prefix := logPrefix(pod, container)
+	rc, err := m.open(m.ctx, pod, container, opts)
+	if err != nil {
+		if m.ctx.Err() == nil {
+			m.writeLine(prefix, fmt.Sprintf("error: %v", err))
+		}
+		return
+	}
+	defer rc.Close()
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if !m.writeLine(prefix, scanner.Text()) {
+			return
+		}
+	}
+}
+
+// writeLine writes one prefixed line and reports whether the reader is still
+// there.
+func (m *multiPodLogs) writeLine(prefix, line string) bool {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	_, err := io.WriteString(m.w, prefix+line+"\n")
+	return err == nil
+}
+
+// logPrefix renders "pod/container " colored by pod name.
+func logPrefix(pod, container string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pod))
+	color := logPrefixColors[h.Sum32()%uint32(len(logPrefixColors))]
+	return fmt.Sprintf("\x1b[%sm%s/%s\x1b[39m ", color, pod, container)
+}
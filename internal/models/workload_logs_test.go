@@ -0,0 +1,107 @@
+package models
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kctesting "github.com/sttts/kc/internal/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestWorkloadSelector(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web"},
+		"spec": map[string]interface{}{"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app": "web"},
+		}},
+	}}
+	selector, err := workloadSelector(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !selector.Matches(labels.Set{"app": "web", "pod-template-hash": "1"}) || selector.Matches(labels.Set{"app": "api"}) {
+		t.Errorf("unexpected selector %s", selector)
+	}
+
+	unstructured.RemoveNestedField(obj.Object, "spec", "selector", "matchLabels")
+	if _, err := workloadSelector(obj); err == nil {
+		t.Error("empty selectors must not select every pod")
+	}
+}
+
+func TestMultiPodLogs(t *testing.T) {
+	type opened struct {
+		key  string
+		opts LogOptions
+	}
+	var mu sync.Mutex
+	var opens []opened
+	r, w := io.Pipe()
+	open := func(_ context.Context, pod, container string, opts LogOptions) (io.ReadCloser, error) {
+		mu.Lock()
+		opens = append(opens, opened{pod + "/" + container, opts})
+		mu.Unlock()
+		return io.NopCloser(strings.NewReader(container + " started\n")), nil
+	}
+	logs := newMultiPodLogs(t.Context(), LogOptions{Follow: true, TailLines: 200}, w, open)
+
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", State: running},
+				{Name: "sidecar", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+			},
+		},
+	}
+	logs.observe(pod)
+
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for len(lines) < 2 && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	slices.Sort(lines)
+	want := []string{
+		logPrefix("web-1", "app") + "app started",
+		logPrefix("web-1", "init") + "init started",
+	}
+	if !slices.Equal(lines, want) {
+		t.Fatalf("lines %q, want %q", lines, want)
+	}
+
+	// Wait for both streams to finish before observing the pod again.
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		logs.mu.Lock()
+		defer logs.mu.Unlock()
+		return len(logs.active) == 0
+	})
+	// Finished containers are only streamed again after a restart, then
+	// without the tail limit.
+	logs.observe(pod)
+	pod.Status.ContainerStatuses[0].RestartCount = 1
+	logs.observe(pod)
+	if !scanner.Scan() || scanner.Text() != logPrefix("web-1", "app")+"app started" {
+		t.Fatalf("restarted container not streamed, got %q", scanner.Text())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(opens) != 3 {
+		t.Fatalf("opened %v, want 3 streams", opens)
+	}
+	if last := opens[2]; last.key != "web-1/app" || last.opts != (LogOptions{Follow: true}) {
+		t.Errorf("restart stream %+v, want app without tail", last)
+	}
+}
@@ -2,6 +2,7 @@ package navigation
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/sttts/kc/internal/models"
@@ -57,6 +58,75 @@ func (n *Navigator) Back() models.Folder {
 	return n.stack[len(n.stack)-1].f
 }
 
+// GoTo navigates from the root folder along an absolute path such as
+// "/namespaces/kube-system/pods", replacing the stack. Each segment is
+// matched against the rows of the current folder, by the first cell (as shown
+// in breadcrumbs, without a leading "/") or else by row ID, and entered. Each
+// frame remembers the entered row, so Back restores the selection. GoTo
+// returns the deepest folder reached, with an error for the first segment that
+// cannot be resolved or entered.
+func (n *Navigator) GoTo(ctx context.Context, path string) (models.Folder, error) {
+	if len(n.stack) == 0 {
+		return nil, fmt.Errorf("no root folder")
+	}
+	n.stack = n.stack[:1]
+	n.stack[0].selID = ""
+	var done []string
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		cur := n.Current()
+		item, ok := findSegment(ctx, cur, seg)
+		if !ok {
+			return cur, fmt.Errorf("%q not found in /%s", seg, strings.Join(done, "/"))
+		}
+		enterable, ok := item.(models.Enterable)
+		if !ok {
+			return cur, fmt.Errorf("cannot enter /%s", strings.Join(append(done, seg), "/"))
+		}
+		next, err := enterable.Enter()
+		if err != nil {
+			return cur, fmt.Errorf("failed to enter /%s: %w", strings.Join(append(done, seg), "/"), err)
+		}
+		if next == nil {
+			return cur, fmt.Errorf("cannot enter /%s", strings.Join(append(done, seg), "/"))
+		}
+		id, _, _, _ := item.Columns()
+		n.SetSelectionID(id)
+		n.Push(next)
+		done = append(done, seg)
+	}
+	return n.Current(), nil
+}
+
+// findSegment returns the row of folder that a path segment names: the row
+// with that ID, else the first row whose name cell reads seg.
+func findSegment(ctx context.Context, folder models.Folder, seg string) (models.Item, bool) {
+	if folder == nil {
+		return nil, false
+	}
+	if item, ok := folder.ItemByID(ctx, seg); ok && item != nil {
+		if _, isBack := item.(models.Back); !isBack {
+			return item, true
+		}
+	}
+	for _, row := range folder.Lines(ctx, 0, folder.Len(ctx)) {
+		item, ok := row.(models.Item)
+		if !ok {
+			continue
+		}
+		if _, isBack := item.(models.Back); isBack {
+			continue
+		}
+		_, cells, _, _ := row.Columns()
+		if len(cells) > 0 && strings.TrimPrefix(strings.TrimSpace(cells[0]), "/") == seg {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
 // HasBack reports whether a back action is possible.
 func (n *Navigator) HasBack() bool { return len(n.stack) > 1 }
 
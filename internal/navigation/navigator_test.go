@@ -71,3 +71,101 @@ func equalPath(a, b []string) bool {
 	}
 	return true
 }
+
+// mkTree builds enterable folders for slash-separated paths; leaves are plain items.
+func mkTree(paths ...string) models.Folder {
+	type node struct {
+		names    []string
+		children map[string]*node
+	}
+	root := &node{children: map[string]*node{}}
+	for _, p := range paths {
+		cur := root
+		for _, seg := range strings.Split(strings.Trim(p, "/"), "/") {
+			next, ok := cur.children[seg]
+			if !ok {
+				next = &node{children: map[string]*node{}}
+				cur.children[seg] = next
+				cur.names = append(cur.names, seg)
+			}
+			cur = next
+		}
+	}
+	var build func(path []string, n *node) models.Folder
+	build = func(path []string, n *node) models.Folder {
+		rows := make([]table.Row, 0, len(n.names))
+		for _, name := range n.names {
+			child := n.children[name]
+			itemPath := append(append([]string(nil), path...), name)
+			if len(child.names) == 0 {
+				rows = append(rows, models.NewSimpleItem("id-"+name, []string{name}, itemPath, models.WhiteStyle()))
+				continue
+			}
+			enter := func() (models.Folder, error) { return build(itemPath, child), nil }
+			rows = append(rows, models.NewContextListItem("id-"+name, []string{"/" + name}, itemPath, models.GreenStyle(), len(child.names), enter))
+		}
+		return modeltesting.NewSliceFolder(strings.Join(path, "/"), []table.Column{{Title: " Name"}}, rows)
+	}
+	return build(nil, root)
+}
+
+func TestNavigator_GoTo(t *testing.T) {
+	ctx := t.Context()
+	root := mkTree("/namespaces/default/pods/web", "/namespaces/kube-system/pods/coredns", "/contexts/dev/namespaces/default")
+	nav := NewNavigator(root)
+	nav.Push(mkFolder([]string{"elsewhere"}, "x"))
+
+	cur, err := nav.GoTo(ctx, "/namespaces/kube-system/pods")
+	if err != nil {
+		t.Fatalf("GoTo() error = %v", err)
+	}
+	if !equalPath(cur.Path(), []string{"namespaces", "kube-system", "pods"}) {
+		t.Fatalf("unexpected folder %v", cur.Path())
+	}
+	if got := nav.Path(ctx); got != "/namespaces/kube-system/pods" {
+		t.Fatalf("Path() = %q", got)
+	}
+	// Each frame restores the entered row when going back.
+	nav.Back()
+	if id := nav.CurrentSelectionID(); id != "id-pods" {
+		t.Fatalf("selection after back = %q, want id-pods", id)
+	}
+	nav.Back()
+	if id := nav.CurrentSelectionID(); id != "id-kube-system" {
+		t.Fatalf("selection after back = %q, want id-kube-system", id)
+	}
+
+	// Segments also match row IDs.
+	if cur, err := nav.GoTo(ctx, "contexts/id-dev"); err != nil || !equalPath(cur.Path(), []string{"contexts", "dev"}) {
+		t.Fatalf("GoTo() by ID = %v, %v", cur.Path(), err)
+	}
+
+	// A row ID wins over an earlier row with the same name.
+	enterFolder := func(path ...string) func() (models.Folder, error) {
+		return func() (models.Folder, error) { return mkFolder(path, "x"), nil }
+	}
+	dups := modeltesting.NewSliceFolder("/", []table.Column{{Title: " Name"}}, []table.Row{
+		models.NewContextListItem("first", []string{"/config"}, []string{"first"}, models.GreenStyle(), 1, enterFolder("first")),
+		models.NewContextListItem("config", []string{"/config"}, []string{"config"}, models.GreenStyle(), 1, enterFolder("config")),
+	})
+	if cur, err := NewNavigator(dups).GoTo(ctx, "/config"); err != nil || !equalPath(cur.Path(), []string{"config"}) {
+		t.Fatalf("GoTo() by ID = %v, %v", cur.Path(), err)
+	}
+
+	// The deepest reachable folder is returned with an error.
+	cur, err = nav.GoTo(ctx, "/namespaces/missing/pods")
+	if err == nil || !strings.Contains(err.Error(), `"missing" not found in /namespaces`) {
+		t.Fatalf("expected unresolved segment error, got %v", err)
+	}
+	if !equalPath(cur.Path(), []string{"namespaces"}) || nav.Current() != cur {
+		t.Fatalf("expected to stop at namespaces, got %v", cur.Path())
+	}
+	if _, err := nav.GoTo(ctx, "/namespaces/default/pods/web"); err == nil || !strings.Contains(err.Error(), "cannot enter") {
+		t.Fatalf("expected leaf error, got %v", err)
+	}
+
+	// The root path resets to the root.
+	if cur, err := nav.GoTo(ctx, "/"); err != nil || cur != root || nav.HasBack() {
+		t.Fatalf("GoTo(/) = %v, %v", cur, err)
+	}
+}
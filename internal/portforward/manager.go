@@ -0,0 +1,436 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sttts/kc/internal/subscribers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientportforward "k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// Kind is the kind of object a port-forward targets.
+type Kind string
+
+const (
+	KindPod     Kind = "pod"
+	KindService Kind = "svc"
+)
+
+// Status is the state of a port-forward.
+type Status string
+
+const (
+	StatusStarting     Status = "Starting"
+	StatusActive       Status = "Active"
+	StatusReconnecting Status = "Reconnecting"
+)
+
+// Target describes what a port-forward connects to.
+type Target struct {
+	// Context is the label of the kubeconfig context the target lives in.
+	Context   string
+	Namespace string
+	Kind      Kind
+	Name      string
+	// Port is the remote port, a number or a port name declared by the pod
+	// or the service.
+	Port string
+	// LocalPort is the port listened on at localhost, 0 picks a free one.
+	LocalPort int
+}
+
+// String renders the target like kubectl does, e.g. svc/web:http.
+func (t Target) String() string {
+	return fmt.Sprintf("%s/%s:%s", t.Kind, t.Name, t.Port)
+}
+
+// Info is a snapshot of a port-forward.
+type Info struct {
+	ID     int
+	Target Target
+	// LocalPort is the port listened on at localhost.
+	LocalPort int
+	// Pod and PodPort are where the connections currently go to.
+	Pod      string
+	PodPort  int
+	Status   Status
+	Err      error
+	Sent     int64
+	Received int64
+	Started  time.Time
+}
+
+// dialFunc opens the port-forward stream connection to a pod.
+type dialFunc func(cfg *rest.Config, namespace, pod string) (httpstream.Dialer, error)
+
+// Manager runs port-forwards in the background until they are stopped. The
+// forwards are independent of the UI and of the cluster cache: they keep
+// running while the user navigates and reconnect after connections to the
+// API server or the pod are lost.
+type Manager struct {
+	ctx     context.Context
+	dial    dialFunc
+	clients func(cfg *rest.Config) (kubernetes.Interface, error)
+	// retryDelay is the pause before a lost forward reconnects.
+	retryDelay time.Duration
+	version    atomic.Int64
+
+	mu       sync.Mutex
+	forwards []*forward
+	nextID   int
+	subs     subscribers.List[struct{}]
+}
+
+// NewManager returns a manager whose forwards end at the latest when ctx is
+// done.
+func NewManager(ctx context.Context) *Manager {
+	return &Manager{
+		ctx:  ctx,
+		dial: dialPod,
+		clients: func(cfg *rest.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(cfg)
+		},
+		retryDelay: 2 * time.Second,
+	}
+}
+
+// Start forwards a local port to target and returns once the local port is
+// listening. If the first attempt fails, nothing keeps running and the error
+// is returned; later failures are retried until the forward is stopped.
+func (m *Manager) Start(ctx context.Context, cfg *rest.Config, target Target) (Info, error) {
+	client, err := m.clients(cfg)
+	if err != nil {
+		return Info{}, err
+	}
+	fctx, cancel := context.WithCancel(m.ctx)
+	m.mu.Lock()
+	m.nextID++
+	f := &forward{
+		m:       m,
+		id:      m.nextID,
+		target:  target,
+		cfg:     cfg,
+		client:  client,
+		cancel:  cancel,
+		local:   target.LocalPort,
+		status:  StatusStarting,
+		started: time.Now(),
+	}
+	m.mu.Unlock()
+
+	ready := make(chan error, 1)
+	go f.run(fctx, ready)
+	select {
+	case err := <-ready:
+		if err != nil {
+			cancel()
+			return Info{}, err
+		}
+	case <-ctx.Done():
+		cancel()
+		return Info{}, ctx.Err()
+	}
+	m.mu.Lock()
+	m.forwards = append(m.forwards, f)
+	m.mu.Unlock()
+	m.changed()
+	return f.info(), nil
+}
+
+// List returns the running forwards in start order.
+func (m *Manager) List() []Info {
+	m.mu.Lock()
+	forwards := slices.Clone(m.forwards)
+	m.mu.Unlock()
+	infos := make([]Info, 0, len(forwards))
+	for _, f := range forwards {
+		infos = append(infos, f.info())
+	}
+	return infos
+}
+
+// Stop ends the forward with the given ID. It reports false when there is
+// no such forward.
+func (m *Manager) Stop(id int) bool {
+	m.mu.Lock()
+	i := slices.IndexFunc(m.forwards, func(f *forward) bool { return f.id == id })
+	var f *forward
+	if i >= 0 {
+		f = m.forwards[i]
+		m.forwards = slices.Delete(m.forwards, i, i+1)
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return false
+	}
+	f.cancel()
+	m.changed()
+	return true
+}
+
+// StopAll ends all forwards.
+func (m *Manager) StopAll() {
+	m.mu.Lock()
+	forwards := m.forwards
+	m.forwards = nil
+	m.mu.Unlock()
+	for _, f := range forwards {
+		f.cancel()
+	}
+	if len(forwards) > 0 {
+		m.changed()
+	}
+}
+
+// Version changes whenever a forward starts, stops, changes its status or
+// transfers data.
+func (m *Manager) Version() int64 { return m.version.Load() }
+
+// Subscribe registers fn to be called when forwards start, stop or change
+// their status, but not on transfers. The returned function removes the
+// subscription.
+func (m *Manager) Subscribe(fn func()) (unsubscribe func()) {
+	return m.subs.Subscribe(func(struct{}) { fn() })
+}
+
+func (m *Manager) changed() {
+	m.version.Add(1)
+	m.subs.Notify(struct{}{})
+}
+
+// forward is a single port-forward and its reconnect loop.
+type forward struct {
+	m       *Manager
+	id      int
+	target  Target
+	cfg     *rest.Config
+	client  kubernetes.Interface
+	cancel  context.CancelFunc
+	started time.Time
+
+	sent, received atomic.Int64
+
+	mu      sync.Mutex
+	local   int
+	pod     string
+	podPort int
+	status  Status
+	err     error
+}
+
+func (f *forward) info() Info {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return Info{
+		ID:        f.id,
+		Target:    f.target,
+		LocalPort: f.local,
+		Pod:       f.pod,
+		PodPort:   f.podPort,
+		Status:    f.status,
+		Err:       f.err,
+		Sent:      f.sent.Load(),
+		Received:  f.received.Load(),
+		Started:   f.started,
+	}
+}
+
+// run forwards until ctx is done. The first outcome is sent to ready: nil
+// once listening, or the error of the first attempt, which ends the forward.
+func (f *forward) run(ctx context.Context, ready chan<- error) {
+	onReady := func() {
+		if ready != nil {
+			ready <- nil
+			ready = nil
+		}
+	}
+	for {
+		err := f.forwardOnce(ctx, onReady)
+		if ctx.Err() != nil {
+			return
+		}
+		if ready != nil {
+			ready <- err
+			return
+		}
+		f.update(func() {
+			// The pod's own error explains a lost connection better.
+			if f.err == nil || !errors.Is(err, clientportforward.ErrLostConnectionToPod) {
+				f.err = err
+			}
+			f.status = StatusReconnecting
+		})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.m.retryDelay):
+		}
+	}
+}
+
+// forwardOnce resolves the target and forwards until the connection is lost
+// or ctx is done. onReady is called once the local port listens.
+func (f *forward) forwardOnce(ctx context.Context, onReady func()) error {
+	pod, podPort, err := resolve(ctx, f.client, f.target)
+	if err != nil {
+		return err
+	}
+	dialer, err := f.m.dial(f.cfg, f.target.Namespace, pod)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	local := f.local
+	f.mu.Unlock()
+
+	stop, readyCh := make(chan struct{}), make(chan struct{})
+	pf, err := clientportforward.NewOnAddresses(countingDialer{Dialer: dialer, f: f}, []string{"localhost"},
+		[]string{fmt.Sprintf("%d:%d", local, podPort)}, stop, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- pf.ForwardPorts() }()
+	select {
+	case <-readyCh:
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		close(stop)
+		<-done
+		return nil
+	}
+	if ports, err := pf.GetPorts(); err == nil && len(ports) > 0 {
+		// Reconnects keep listening on the same port.
+		local = int(ports[0].Local)
+	}
+	f.update(func() {
+		f.local, f.pod, f.podPort = local, pod, podPort
+		f.status = StatusActive
+	})
+	onReady()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			err = errors.New("port-forward ended")
+		}
+		return err
+	case <-ctx.Done():
+		close(stop)
+		<-done
+		return nil
+	}
+}
+
+func (f *forward) update(fn func()) {
+	f.mu.Lock()
+	fn()
+	f.mu.Unlock()
+	f.m.changed()
+}
+
+// dialPod returns a SPDY dialer for the port-forward subresource of pod.
+func dialPod(cfg *rest.Config, namespace, pod string) (httpstream.Dialer, error) {
+	cfg = rest.CopyConfig(cfg)
+	// Forwards stay open until stopped.
+	cfg.Timeout = 0
+	cfg = rest.AddUserAgent(cfg, "kc-port-forward")
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	u := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(pod).SubResource("portforward").URL()
+	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, u), nil
+}
+
+// countingDialer counts the bytes of the data streams of its connections and
+// records errors reported by the pod.
+type countingDialer struct {
+	httpstream.Dialer
+	f *forward
+}
+
+func (d countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, "", err
+	}
+	return &countingConnection{Connection: conn, f: d.f}, protocol, nil
+}
+
+type countingConnection struct {
+	httpstream.Connection
+	f *forward
+}
+
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	s, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	switch headers.Get(corev1.StreamType) {
+	case corev1.StreamTypeData:
+		return &dataStream{Stream: s, f: c.f}, nil
+	case corev1.StreamTypeError:
+		return &errorStream{Stream: s, f: c.f}, nil
+	}
+	return s, nil
+}
+
+// dataStream counts what is sent to and received from the pod.
+type dataStream struct {
+	httpstream.Stream
+	f *forward
+}
+
+func (s *dataStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if n > 0 {
+		s.f.received.Add(int64(n))
+		s.f.m.version.Add(1)
+	}
+	return n, err
+}
+
+func (s *dataStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	if n > 0 {
+		s.f.sent.Add(int64(n))
+		s.f.m.version.Add(1)
+	}
+	return n, err
+}
+
+// errorStream records the errors the pod reports for a connection, e.g. that
+// nothing listens on the remote port.
+type errorStream struct {
+	httpstream.Stream
+	f *forward
+}
+
+func (s *errorStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if msg := strings.TrimSpace(string(p[:n])); msg != "" {
+		s.f.update(func() { s.f.err = errors.New(msg) })
+	}
+	return n, err
+}
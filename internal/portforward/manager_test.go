@@ -0,0 +1,182 @@
+package portforward
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kctesting "github.com/sttts/kc/internal/testing"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// echoConnection fakes the port-forward connection to a pod whose data
+// streams echo what they receive.
+type echoConnection struct {
+	podErr    string
+	closed    chan bool
+	closeOnce sync.Once
+}
+
+func (c *echoConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	if headers.Get(corev1.StreamType) == corev1.StreamTypeError {
+		r, w := io.Pipe()
+		go func() {
+			_, _ = io.WriteString(w, c.podErr)
+			_ = w.Close()
+		}()
+		return &echoStream{r: r, w: io.Discard, headers: headers}, nil
+	}
+	r, w := io.Pipe()
+	return &echoStream{r: r, w: w, headers: headers}, nil
+}
+
+func (c *echoConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *echoConnection) CloseChan() <-chan bool             { return c.closed }
+func (c *echoConnection) SetIdleTimeout(time.Duration)       {}
+func (c *echoConnection) RemoveStreams(...httpstream.Stream) {}
+
+type echoStream struct {
+	r       io.Reader
+	w       io.Writer
+	headers http.Header
+}
+
+func (s *echoStream) Read(p []byte) (int, error)  { return s.r.Read(p) }
+func (s *echoStream) Write(p []byte) (int, error) { return s.w.Write(p) }
+func (s *echoStream) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+func (s *echoStream) Reset() error         { return s.Close() }
+func (s *echoStream) Headers() http.Header { return s.headers }
+func (s *echoStream) Identifier() uint32   { return 0 }
+
+type dialerFunc func(protocols ...string) (httpstream.Connection, string, error)
+
+func (f dialerFunc) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	return f(protocols...)
+}
+
+func TestManager(t *testing.T) {
+	client := fake.NewSimpleClientset(testPod("web", true, nil))
+	m := NewManager(t.Context())
+	m.retryDelay = 10 * time.Millisecond
+	m.clients = func(*rest.Config) (kubernetes.Interface, error) { return client, nil }
+
+	var (
+		mu     sync.Mutex
+		conns  []*echoConnection
+		podErr string
+	)
+	m.dial = func(_ *rest.Config, namespace, pod string) (httpstream.Dialer, error) {
+		if namespace != "default" || pod != "web" {
+			return nil, fmt.Errorf("unexpected pod %s/%s", namespace, pod)
+		}
+		return dialerFunc(func(...string) (httpstream.Connection, string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			conn := &echoConnection{podErr: podErr, closed: make(chan bool)}
+			conns = append(conns, conn)
+			return conn, "portforward.k8s.io", nil
+		}), nil
+	}
+	var notified atomic.Int32
+	defer m.Subscribe(func() { notified.Add(1) })()
+
+	// A target that cannot be resolved fails right away.
+	if _, err := m.Start(t.Context(), &rest.Config{}, Target{Namespace: "default", Kind: KindPod, Name: "web", Port: "grpc"}); err == nil {
+		t.Fatal("want an error for an unknown port name")
+	}
+	if len(m.List()) != 0 {
+		t.Fatal("failed forwards must not be listed")
+	}
+
+	info, err := m.Start(t.Context(), &rest.Config{}, Target{Context: "kind", Namespace: "default", Kind: KindPod, Name: "web", Port: "http"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.LocalPort == 0 || info.PodPort != 8080 || info.Status != StatusActive {
+		t.Fatalf("unexpected forward %+v", info)
+	}
+	if notified.Load() == 0 {
+		t.Error("subscribers were not notified")
+	}
+
+	echo := func() {
+		t.Helper()
+		conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", info.LocalPort))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if _, err := io.WriteString(conn, "ping"); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+			t.Fatalf("got %q (%v), want the echo", buf, err)
+		}
+	}
+	version := m.Version()
+	echo()
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		got := m.List()[0]
+		return got.Sent == 4 && got.Received == 4
+	}, "bytes were not counted")
+	if m.Version() == version {
+		t.Error("transfers must change the version")
+	}
+
+	// Lost connections reconnect on the same local port and keep the error
+	// the pod reported.
+	mu.Lock()
+	podErr = "connection refused"
+	conns[0].Close()
+	mu.Unlock()
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(conns) >= 2 && m.List()[0].Status == StatusActive
+	}, "forward did not reconnect")
+	if got := m.List()[0]; got.LocalPort != info.LocalPort {
+		t.Errorf("reconnected on port %d, want %d", got.LocalPort, info.LocalPort)
+	}
+	conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", info.LocalPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.Close()
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		got := m.List()[0]
+		return got.Err != nil && got.Err.Error() == "connection refused"
+	}, "pod error was not recorded")
+
+	if !m.Stop(info.ID) || m.Stop(info.ID) {
+		t.Fatal("want exactly one stop to succeed")
+	}
+	if len(m.List()) != 0 {
+		t.Error("stopped forwards must not be listed")
+	}
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", info.LocalPort))
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err != nil
+	}, "local port still listens")
+}
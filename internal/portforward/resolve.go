@@ -0,0 +1,190 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Port is a port declared by a pod container or a service.
+type Port struct {
+	Name     string
+	Port     int32
+	Protocol corev1.Protocol
+}
+
+// String renders the port as number and name, e.g. 80/http.
+func (p Port) String() string {
+	if p.Name == "" {
+		return strconv.Itoa(int(p.Port))
+	}
+	return fmt.Sprintf("%d/%s", p.Port, p.Name)
+}
+
+// DeclaredPorts returns the TCP ports declared by a pod or service object.
+func DeclaredPorts(obj *unstructured.Unstructured) ([]Port, error) {
+	var ports []Port
+	switch obj.GetKind() {
+	case "Pod":
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+			return nil, err
+		}
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				ports = append(ports, Port{Name: p.Name, Port: p.ContainerPort, Protocol: p.Protocol})
+			}
+		}
+	case "Service":
+		var svc corev1.Service
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+			return nil, err
+		}
+		for _, p := range svc.Spec.Ports {
+			ports = append(ports, Port{Name: p.Name, Port: p.Port, Protocol: p.Protocol})
+		}
+	default:
+		return nil, fmt.Errorf("cannot forward ports of %s", obj.GetKind())
+	}
+	tcp := ports[:0]
+	for _, p := range ports {
+		// Port-forwarding only carries TCP.
+		if p.Protocol == "" || p.Protocol == corev1.ProtocolTCP {
+			tcp = append(tcp, p)
+		}
+	}
+	return tcp, nil
+}
+
+// ParsePorts parses a kubectl style "[local:]remote" port pair. The remote
+// port is a number or a port name. Without a local port, a numeric remote
+// port is also listened on locally; an empty local port, or one for a named
+// remote port, is picked freely and returned as 0.
+func ParsePorts(s string) (local int, remote string, err error) {
+	s = strings.TrimSpace(s)
+	localStr, remote, hasLocal := strings.Cut(s, ":")
+	if !hasLocal {
+		remote, localStr = localStr, ""
+		if _, err := strconv.ParseUint(remote, 10, 16); err == nil {
+			localStr = remote
+		}
+	}
+	if remote == "" {
+		return 0, "", fmt.Errorf("missing remote port in %q", s)
+	}
+	if n, err := strconv.ParseUint(remote, 10, 16); err == nil {
+		if n == 0 {
+			return 0, "", fmt.Errorf("invalid remote port %q", remote)
+		}
+	} else if len(validation.IsValidPortName(remote)) > 0 {
+		return 0, "", fmt.Errorf("invalid remote port %q", remote)
+	}
+	if localStr != "" {
+		n, err := strconv.ParseUint(localStr, 10, 16)
+		if err != nil {
+			return 0, "", fmt.Errorf("invalid local port %q", localStr)
+		}
+		local = int(n)
+	}
+	return local, remote, nil
+}
+
+// resolve returns the pod the target forwards to and the port in that pod.
+// Services forward to one of their running pods, preferring ready ones.
+func resolve(ctx context.Context, client kubernetes.Interface, t Target) (string, int, error) {
+	if t.Kind != KindService {
+		pod, err := client.CoreV1().Pods(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", 0, err
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return "", 0, fmt.Errorf("pod %s is not running (phase %s)", pod.Name, pod.Status.Phase)
+		}
+		port, err := containerPort(pod, t.Port)
+		return pod.Name, port, err
+	}
+
+	svc, err := client.CoreV1().Services(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	var svcPort *corev1.ServicePort
+	for i, p := range svc.Spec.Ports {
+		if p.Name == t.Port || strconv.Itoa(int(p.Port)) == t.Port {
+			svcPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if svcPort == nil {
+		return "", 0, fmt.Errorf("service %s has no port %s", svc.Name, t.Port)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s has no selector", svc.Name)
+	}
+	pods, err := client.CoreV1().Pods(t.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	pod := pickPod(pods.Items)
+	if pod == nil {
+		return "", 0, fmt.Errorf("service %s has no running pods", svc.Name)
+	}
+	switch {
+	case svcPort.TargetPort.Type == intstr.String:
+		port, err := containerPort(pod, svcPort.TargetPort.StrVal)
+		return pod.Name, port, err
+	case svcPort.TargetPort.IntVal != 0:
+		return pod.Name, int(svcPort.TargetPort.IntVal), nil
+	default:
+		return pod.Name, int(svcPort.Port), nil
+	}
+}
+
+// containerPort maps a port number or a container port name of pod to the
+// port number.
+func containerPort(pod *corev1.Pod, port string) (int, error) {
+	if n, err := strconv.ParseUint(port, 10, 16); err == nil {
+		return int(n), nil
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == port {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no port named %s", pod.Name, port)
+}
+
+// pickPod returns the first running pod that is ready, or else the first
+// running one. Pods being deleted are skipped.
+func pickPod(pods []corev1.Pod) *corev1.Pod {
+	var running *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return pod
+			}
+		}
+		if running == nil {
+			running = pod
+		}
+	}
+	return running
+}
@@ -0,0 +1,137 @@
+package portforward
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testPod(name string, ready bool, labels map[string]string) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Ports: []corev1.ContainerPort{
+				{Name: "http", ContainerPort: 8080},
+				{Name: "dns", ContainerPort: 53, Protocol: corev1.ProtocolUDP},
+			},
+		}}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: corev1.ServiceSpec{
+			Selector: web,
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+				{Name: "metrics", Port: 9090, TargetPort: intstr.FromInt32(9091)},
+				{Name: "plain", Port: 7000},
+			},
+		},
+	}
+	pending := testPod("web-pending", false, web)
+	pending.Status.Phase = corev1.PodPending
+	client := fake.NewSimpleClientset(svc, pending, testPod("web-unready", false, web), testPod("web-ready", true, web))
+
+	tests := []struct {
+		target  Target
+		pod     string
+		port    int
+		wantErr bool
+	}{
+		{target: Target{Kind: KindPod, Name: "web-ready", Port: "http"}, pod: "web-ready", port: 8080},
+		{target: Target{Kind: KindPod, Name: "web-ready", Port: "3000"}, pod: "web-ready", port: 3000},
+		{target: Target{Kind: KindPod, Name: "web-ready", Port: "grpc"}, wantErr: true},
+		{target: Target{Kind: KindPod, Name: "web-pending", Port: "http"}, wantErr: true},
+		{target: Target{Kind: KindService, Name: "web", Port: "http"}, pod: "web-ready", port: 8080},
+		{target: Target{Kind: KindService, Name: "web", Port: "80"}, pod: "web-ready", port: 8080},
+		{target: Target{Kind: KindService, Name: "web", Port: "metrics"}, pod: "web-ready", port: 9091},
+		{target: Target{Kind: KindService, Name: "web", Port: "7000"}, pod: "web-ready", port: 7000},
+		{target: Target{Kind: KindService, Name: "web", Port: "8080"}, wantErr: true},
+		{target: Target{Kind: KindService, Name: "missing", Port: "80"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt.target.Namespace = "default"
+		pod, port, err := resolve(t.Context(), client, tt.target)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want an error, got %s:%d", tt.target, pod, port)
+			}
+			continue
+		}
+		if err != nil || pod != tt.pod || port != tt.port {
+			t.Errorf("%s: got %s:%d (%v), want %s:%d", tt.target, pod, port, err, tt.pod, tt.port)
+		}
+	}
+
+	// Without a ready pod, a running one is used.
+	client = fake.NewSimpleClientset(svc, testPod("web-unready", false, web))
+	if pod, _, err := resolve(t.Context(), client, Target{Namespace: "default", Kind: KindService, Name: "web", Port: "http"}); err != nil || pod != "web-unready" {
+		t.Errorf("got %s (%v), want the running pod", pod, err)
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		in      string
+		local   int
+		remote  string
+		wantErr bool
+	}{
+		{in: "8080", local: 8080, remote: "8080"},
+		{in: "9000:80", local: 9000, remote: "80"},
+		{in: ":80", local: 0, remote: "80"},
+		{in: " http ", local: 0, remote: "http"},
+		{in: "9000:http", local: 9000, remote: "http"},
+		{in: "", wantErr: true},
+		{in: "9000:", wantErr: true},
+		{in: "0", wantErr: true},
+		{in: "70000", wantErr: true},
+		{in: "x:80", wantErr: true},
+		{in: "Bad_Name", wantErr: true},
+	}
+	for _, tt := range tests {
+		local, remote, err := ParsePorts(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: want an error, got %d:%s", tt.in, local, remote)
+			}
+			continue
+		}
+		if err != nil || local != tt.local || remote != tt.remote {
+			t.Errorf("%q: got %d:%s (%v), want %d:%s", tt.in, local, remote, err, tt.local, tt.remote)
+		}
+	}
+}
+
+func TestDeclaredPorts(t *testing.T) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(testPod("web", true, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetKind("Pod")
+	ports, err := DeclaredPorts(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 1 || ports[0].String() != "8080/http" {
+		t.Errorf("got %v, want only the TCP port", ports)
+	}
+}
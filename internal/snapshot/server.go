@@ -0,0 +1,413 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/sttts/kc/internal/tablecache"
+)
+
+// resourceVersion is reported for every list and table. Snapshots never
+// change, so watches start and stay at this version.
+const resourceVersion = "1"
+
+// Version is the git version reported by the snapshot server's /version.
+const Version = "snapshot"
+
+// Server serves a Store as a read-only Kubernetes API on the loopback
+// interface and writes a kubeconfig pointing at it, so regular clients,
+// informers and discovery work against the snapshot unchanged.
+type Server struct {
+	store      *Store
+	srv        *http.Server
+	kubeconfig string
+	context    string
+}
+
+// Start loads dir and serves it on a random loopback port.
+func Start(dir string) (*Server, error) {
+	store, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	s := &Server{
+		store:   store,
+		srv:     &http.Server{Handler: newHandler(store), ReadHeaderTimeout: 10 * time.Second},
+		context: "snapshot-" + filepath.Base(filepath.Clean(dir)),
+	}
+	path, err := s.writeKubeconfig("http://" + ln.Addr().String())
+	if err != nil {
+		ln.Close()
+		return nil, err
+	}
+	s.kubeconfig = path
+	go func() { _ = s.srv.Serve(ln) }()
+	return s, nil
+}
+
+// KubeconfigPath returns the temporary kubeconfig pointing at the server.
+func (s *Server) KubeconfigPath() string { return s.kubeconfig }
+
+// ContextName returns the name of the only context in the kubeconfig.
+func (s *Server) ContextName() string { return s.context }
+
+// Close stops the server, dropping open watches, and removes the kubeconfig.
+func (s *Server) Close() error {
+	err := s.srv.Close()
+	if rmErr := os.Remove(s.kubeconfig); rmErr != nil && err == nil && !os.IsNotExist(rmErr) {
+		err = rmErr
+	}
+	return err
+}
+
+func (s *Server) writeKubeconfig(server string) (string, error) {
+	namespace := "default"
+	if nss := s.store.Namespaces(); len(nss) > 0 && !contains(nss, namespace) {
+		namespace = nss[0]
+	}
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["snapshot"] = &clientcmdapi.Cluster{Server: server}
+	cfg.AuthInfos["snapshot"] = &clientcmdapi.AuthInfo{}
+	cfg.Contexts[s.context] = &clientcmdapi.Context{Cluster: "snapshot", AuthInfo: "snapshot", Namespace: namespace}
+	cfg.CurrentContext = s.context
+
+	f, err := os.CreateTemp("", "kc-snapshot-*.kubeconfig")
+	if err != nil {
+		return "", fmt.Errorf("kubeconfig: %w", err)
+	}
+	path := f.Name()
+	f.Close()
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("kubeconfig: %w", err)
+	}
+	return path, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// handler implements the read-only subset of the Kubernetes API used by kc:
+// legacy discovery, list, get and (eventless) watch, each as plain objects,
+// PartialObjectMetadata or Table.
+type handler struct {
+	store *Store
+}
+
+func newHandler(store *Store) *handler {
+	return &handler{store: store}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeStatus(w, &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusMethodNotAllowed,
+			Reason:  metav1.StatusReasonMethodNotAllowed,
+			Message: "snapshot is read-only",
+		})
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/version":
+		writeJSON(w, http.StatusOK, &version.Info{GitVersion: Version, Major: "1", Minor: "0"})
+	case len(parts) == 1 && parts[0] == "api":
+		writeJSON(w, http.StatusOK, &metav1.APIVersions{
+			TypeMeta: metav1.TypeMeta{Kind: "APIVersions"},
+			Versions: []string{"v1"},
+		})
+	case len(parts) == 1 && parts[0] == "apis":
+		writeJSON(w, http.StatusOK, h.groupList())
+	case len(parts) >= 2 && parts[0] == "api":
+		h.serveGroupVersion(w, r, schema.GroupVersion{Version: parts[1]}, parts[2:])
+	case len(parts) >= 3 && parts[0] == "apis":
+		h.serveGroupVersion(w, r, schema.GroupVersion{Group: parts[1], Version: parts[2]}, parts[3:])
+	default:
+		writeStatus(w, notFound(r.URL.Path))
+	}
+}
+
+func (h *handler) groupList() *metav1.APIGroupList {
+	list := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+	index := map[string]int{}
+	for _, res := range h.store.Resources() {
+		gv := res.GVK.GroupVersion()
+		if gv.Group == "" {
+			continue
+		}
+		i, ok := index[gv.Group]
+		if !ok {
+			i = len(list.Groups)
+			index[gv.Group] = i
+			// Resources are sorted by version priority, so the first one is preferred.
+			preferred := metav1.GroupVersionForDiscovery{GroupVersion: gv.String(), Version: gv.Version}
+			list.Groups = append(list.Groups, metav1.APIGroup{Name: gv.Group, PreferredVersion: preferred})
+		}
+		g := &list.Groups[i]
+		if n := len(g.Versions); n == 0 || g.Versions[n-1].Version != gv.Version {
+			g.Versions = append(g.Versions, metav1.GroupVersionForDiscovery{GroupVersion: gv.String(), Version: gv.Version})
+		}
+	}
+	return list
+}
+
+func (h *handler) resourceList(gv schema.GroupVersion) (*metav1.APIResourceList, bool) {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: gv.String(),
+	}
+	found := false
+	for _, res := range h.store.Resources() {
+		if res.GVK.GroupVersion() != gv {
+			continue
+		}
+		found = true
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:         res.Plural,
+			SingularName: strings.ToLower(res.GVK.Kind),
+			Namespaced:   res.Namespaced,
+			Kind:         res.GVK.Kind,
+			Verbs:        metav1.Verbs{"get", "list", "watch"},
+		})
+	}
+	return list, found
+}
+
+// serveGroupVersion dispatches the path segments after /api/v1 or
+// /apis/<group>/<version>.
+func (h *handler) serveGroupVersion(w http.ResponseWriter, r *http.Request, gv schema.GroupVersion, rest []string) {
+	if len(rest) == 0 {
+		list, ok := h.resourceList(gv)
+		if !ok {
+			writeStatus(w, notFound(r.URL.Path))
+			return
+		}
+		writeJSON(w, http.StatusOK, list)
+		return
+	}
+
+	var namespace, resource, name string
+	switch {
+	case len(rest) >= 3 && rest[0] == "namespaces":
+		namespace, rest = rest[1], rest[2:]
+		resource = rest[0]
+		if len(rest) > 1 {
+			name = rest[1]
+		}
+	default:
+		resource = rest[0]
+		if len(rest) > 1 {
+			name = rest[1]
+		}
+	}
+	if len(rest) > 2 {
+		writeStatus(w, notFound(r.URL.Path))
+		return
+	}
+	res, ok := h.store.Resource(gv.WithResource(resource))
+	if !ok || (namespace != "" && !res.Namespaced) {
+		writeStatus(w, notFound(r.URL.Path))
+		return
+	}
+	if name != "" {
+		h.serveGet(w, r, res, namespace, name)
+		return
+	}
+	if isWatch(r) {
+		serveWatch(w, r)
+		return
+	}
+	h.serveList(w, r, res, namespace)
+}
+
+func (h *handler) serveGet(w http.ResponseWriter, r *http.Request, res Resource, namespace, name string) {
+	u, ok := h.store.Get(res.GVR(), namespace, name)
+	if !ok {
+		writeStatus(w, &apierrors.NewNotFound(res.GVR().GroupResource(), name).ErrStatus)
+		return
+	}
+	h.writeObjects(w, r, res, []*unstructured.Unstructured{u}, false)
+}
+
+func (h *handler) serveList(w http.ResponseWriter, r *http.Request, res Resource, namespace string) {
+	q := r.URL.Query()
+	ls, err := labels.Parse(q.Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, &apierrors.NewBadRequest(err.Error()).ErrStatus)
+		return
+	}
+	fs, err := fields.ParseSelector(q.Get("fieldSelector"))
+	if err != nil {
+		writeStatus(w, &apierrors.NewBadRequest(err.Error()).ErrStatus)
+		return
+	}
+	var objs []*unstructured.Unstructured
+	for _, u := range h.store.List(res.GVR(), namespace) {
+		if ls.Matches(labels.Set(u.GetLabels())) && fs.Matches(objectFields{u}) {
+			objs = append(objs, u)
+		}
+	}
+	h.writeObjects(w, r, res, objs, true)
+}
+
+// writeObjects encodes objs in the representation requested by the Accept
+// header. Single objects are written without a list wrapper unless a Table
+// was requested.
+func (h *handler) writeObjects(w http.ResponseWriter, r *http.Request, res Resource, objs []*unstructured.Unstructured, list bool) {
+	switch negotiate(r.Header.Get("Accept")) {
+	case "Table":
+		policy := metav1.IncludeObjectPolicy(r.URL.Query().Get("includeObject"))
+		table, err := tablecache.NewPrinter(res.GVK, h.store.CRD(res.GVK.GroupKind())).Table(objs, policy)
+		if err != nil {
+			writeStatus(w, &apierrors.NewInternalError(err).ErrStatus)
+			return
+		}
+		table.ResourceVersion = resourceVersion
+		writeJSON(w, http.StatusOK, table)
+	case "PartialObjectMetadata", "PartialObjectMetadataList":
+		if !list {
+			writeJSON(w, http.StatusOK, tablecache.PartialObjectMetadata(objs[0]))
+			return
+		}
+		out := &metav1.PartialObjectMetadataList{
+			TypeMeta: metav1.TypeMeta{APIVersion: metav1.SchemeGroupVersion.String(), Kind: "PartialObjectMetadataList"},
+			ListMeta: metav1.ListMeta{ResourceVersion: resourceVersion},
+			Items:    make([]metav1.PartialObjectMetadata, 0, len(objs)),
+		}
+		for _, u := range objs {
+			out.Items = append(out.Items, *tablecache.PartialObjectMetadata(u))
+		}
+		writeJSON(w, http.StatusOK, out)
+	default:
+		if !list {
+			writeJSON(w, http.StatusOK, objs[0].Object)
+			return
+		}
+		items := make([]interface{}, 0, len(objs))
+		for _, u := range objs {
+			items = append(items, u.Object)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"apiVersion": res.GVK.GroupVersion().String(),
+			"kind":       res.GVK.Kind + "List",
+			"metadata":   map[string]interface{}{"resourceVersion": resourceVersion},
+			"items":      items,
+		})
+	}
+}
+
+// negotiate returns the "as" parameter of the first JSON media type in accept,
+// or "" for plain objects. Protobuf is never served; clients fall back to JSON.
+func negotiate(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || (mediaType != "application/json" && mediaType != "*/*") {
+			continue
+		}
+		switch params["as"] {
+		case "Table", "PartialObjectMetadata", "PartialObjectMetadataList":
+			if params["g"] == metav1.GroupName {
+				return params["as"]
+			}
+		case "":
+			return ""
+		}
+	}
+	return ""
+}
+
+func isWatch(r *http.Request) bool {
+	w := r.URL.Query().Get("watch")
+	return w == "true" || w == "1"
+}
+
+// serveWatch keeps the watch open without events until the client goes away
+// or the requested timeout passes. Snapshots never change.
+func serveWatch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	timeout := 30 * time.Minute
+	if s, err := strconv.Atoi(r.URL.Query().Get("timeoutSeconds")); err == nil && s > 0 {
+		timeout = time.Duration(s) * time.Second
+	}
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case <-r.Context().Done():
+	case <-t.C:
+	}
+}
+
+// objectFields exposes arbitrary dotted string fields of an object to field
+// selectors, e.g. metadata.name or spec.nodeName.
+type objectFields struct {
+	u *unstructured.Unstructured
+}
+
+func (f objectFields) Has(field string) bool {
+	_, found, _ := unstructured.NestedFieldNoCopy(f.u.Object, strings.Split(field, ".")...)
+	return found
+}
+
+func (f objectFields) Get(field string) string {
+	v, found, _ := unstructured.NestedFieldNoCopy(f.u.Object, strings.Split(field, ".")...)
+	if !found || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func notFound(path string) *metav1.Status {
+	return &apierrors.NewNotFound(schema.GroupResource{}, path).ErrStatus
+}
+
+func writeStatus(w http.ResponseWriter, st *metav1.Status) {
+	st.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+	writeJSON(w, int(st.Code), st)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	kccluster "github.com/sttts/kc/internal/cluster"
+)
+
+func TestServerBacksCluster(t *testing.T) {
+	srv, err := Start(writeSnapshot(t))
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Close()
+
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: srv.KubeconfigPath()},
+		&clientcmd.ConfigOverrides{CurrentContext: srv.ContextName()},
+	).ClientConfig()
+	if err != nil {
+		t.Fatalf("client config: %v", err)
+	}
+	cl, err := kccluster.New(cfg)
+	if err != nil {
+		t.Fatalf("cluster: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	go func() { _ = cl.Start(ctx) }()
+	defer cl.Stop()
+
+	infos, err := cl.GetResourceInfos()
+	if err != nil {
+		t.Fatalf("GetResourceInfos: %v", err)
+	}
+	found := map[string]bool{}
+	for _, info := range infos {
+		found[info.GVK.String()] = true
+	}
+	for _, want := range []string{"/v1, Kind=Pod", "apps/v1, Kind=Deployment", "example.com/v1, Kind=Widget", "/v1, Kind=Namespace"} {
+		if !found[want] {
+			t.Fatalf("discovery misses %s: %v", want, infos)
+		}
+	}
+
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	table, err := cl.ListTableByGVR(ctx, pods, "shop")
+	if err != nil {
+		t.Fatalf("ListTableByGVR: %v", err)
+	}
+	if len(table.Rows) != 2 || table.ColumnDefinitions[1].Name != "Ready" {
+		t.Fatalf("unexpected table: %+v", table)
+	}
+	if got := table.Rows[1].Cells; got[0] != "web-1" || got[1] != "1/1" || got[2] != "Running" || got[3] != float64(2) {
+		t.Fatalf("unexpected web-1 cells: %v", got)
+	}
+
+	rows, err := cl.ListRowsByGVR(ctx, pods, "shop")
+	if err != nil {
+		t.Fatalf("ListRowsByGVR: %v", err)
+	}
+	if len(rows.Items) != 2 {
+		t.Fatalf("expected 2 rows from the row cache, got %d", len(rows.Items))
+	}
+
+	deploy, err := cl.GetByGVR(ctx, schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, "shop", "web")
+	if err != nil {
+		t.Fatalf("GetByGVR: %v", err)
+	}
+	if deploy.GetName() != "web" {
+		t.Fatalf("unexpected deployment %v", deploy)
+	}
+
+	inf, err := cl.MetadataInformerByGVR(ctx, pods)
+	if err != nil {
+		t.Fatalf("MetadataInformerByGVR: %v", err)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), inf.HasSynced) {
+		t.Fatalf("metadata informer did not sync")
+	}
+
+	has, err := cl.HasAnyByGVR(ctx, schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}, "")
+	if err != nil || !has {
+		t.Fatalf("HasAnyByGVR(widgets) = %v, %v", has, err)
+	}
+}
+
+func TestServerIsReadOnly(t *testing.T) {
+	srv, err := Start(writeSnapshot(t))
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Close()
+
+	cfg, err := clientcmd.LoadFromFile(srv.KubeconfigPath())
+	if err != nil {
+		t.Fatalf("kubeconfig: %v", err)
+	}
+	server := cfg.Clusters["snapshot"].Server
+	req, _ := http.NewRequest(http.MethodDelete, server+"/api/v1/namespaces/shop/pods/web-1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", resp.StatusCode)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"application/json": "",
+		"application/json;as=Table;g=meta.k8s.io;v=v1, application/json":                                                                                       "Table",
+		"application/vnd.kubernetes.protobuf;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1,application/json;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1": "PartialObjectMetadataList",
+		"application/vnd.kubernetes.protobuf": "",
+	}
+	for accept, want := range tests {
+		if got := negotiate(accept); got != want {
+			t.Errorf("negotiate(%q) = %q, want %q", accept, got, want)
+		}
+	}
+}
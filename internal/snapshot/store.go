@@ -0,0 +1,314 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// Resource describes a resource type synthesized from the snapshot contents.
+type Resource struct {
+	GVK        schema.GroupVersionKind
+	Plural     string
+	Namespaced bool
+}
+
+// GVR returns the group/version/resource of r.
+func (r Resource) GVR() schema.GroupVersionResource {
+	return r.GVK.GroupVersion().WithResource(r.Plural)
+}
+
+// Store holds the objects of a snapshot directory indexed by resource.
+type Store struct {
+	resources []Resource
+	byGVR     map[schema.GroupVersionResource]Resource
+	objects   map[schema.GroupVersionResource][]*unstructured.Unstructured
+	crds      map[schema.GroupKind]*unstructured.Unstructured
+}
+
+// clusterScopedKinds lists built-in kinds that are never namespaced, so a stray
+// metadata.namespace in a dump does not turn them into namespaced resources.
+// Other kinds are namespaced when any of their objects carries a namespace.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}:        true,
+	{Kind: "Node"}:             true,
+	{Kind: "PersistentVolume"}: true,
+	{Kind: "ComponentStatus"}:  true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                         true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                  true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:                 true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                             true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                   true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                      true,
+	{Group: "storage.k8s.io", Kind: "CSINode"}:                                        true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                               true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                               true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                                true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                      true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:     true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"}:        true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"}: true,
+	{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"}:                 true,
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"}:                       true,
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"}:       true,
+}
+
+var namespaceGVK = schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+
+// Load reads every .yaml, .yml and .json file below dir. Files may hold single
+// objects, multi-document YAML streams or List objects as written by
+// `kubectl get -o yaml`. Documents without apiVersion, kind or name are skipped.
+// Namespaces referenced by objects but missing from the dump are synthesized.
+func Load(dir string) (*Store, error) {
+	var objs []*unstructured.Unstructured
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		decoded, err := decodeObjects(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		objs = append(objs, decoded...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newStore(objs), nil
+}
+
+func decodeObjects(r io.Reader) ([]*unstructured.Unstructured, error) {
+	dec := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	var out []*unstructured.Unstructured
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return out, nil
+			}
+			return nil, err
+		}
+		// utiljson keeps integers as int64, like objects read from an API server.
+		var doc map[string]interface{}
+		if err := utiljson.Unmarshal(raw, &doc); err != nil {
+			return nil, err
+		}
+		if len(doc) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: doc}
+		if u.IsList() {
+			err := u.EachListItem(func(obj runtime.Object) error {
+				if item, ok := obj.(*unstructured.Unstructured); ok && validObject(item) {
+					out = append(out, item)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if validObject(u) {
+			out = append(out, u)
+		}
+	}
+}
+
+func validObject(u *unstructured.Unstructured) bool {
+	return u.GetAPIVersion() != "" && u.GetKind() != "" && u.GetName() != ""
+}
+
+func newStore(objs []*unstructured.Unstructured) *Store {
+	s := &Store{
+		byGVR:   map[schema.GroupVersionResource]Resource{},
+		objects: map[schema.GroupVersionResource][]*unstructured.Unstructured{},
+		crds:    map[schema.GroupKind]*unstructured.Unstructured{},
+	}
+	byGVK := map[schema.GroupVersionKind]Resource{}
+	fromCRD := map[schema.GroupVersionKind]bool{}
+
+	// CRDs carry authoritative plural names and scopes for custom resources.
+	for _, u := range objs {
+		if u.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+		plural, _, _ := unstructured.NestedString(u.Object, "spec", "names", "plural")
+		kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+		scope, _, _ := unstructured.NestedString(u.Object, "spec", "scope")
+		s.crds[schema.GroupKind{Group: group, Kind: kind}] = u
+		versions, _, _ := unstructured.NestedSlice(u.Object, "spec", "versions")
+		for _, v := range versions {
+			name, _, _ := unstructured.NestedString(asMap(v), "name")
+			if name == "" || plural == "" || kind == "" {
+				continue
+			}
+			gvk := schema.GroupVersionKind{Group: group, Version: name, Kind: kind}
+			byGVK[gvk] = Resource{GVK: gvk, Plural: plural, Namespaced: scope != "Cluster"}
+			fromCRD[gvk] = true
+		}
+	}
+
+	namespaces := map[string]bool{}
+	hasNamespace := map[string]bool{}
+	type key struct {
+		gvr       schema.GroupVersionResource
+		namespace string
+		name      string
+	}
+	index := map[key]int{}
+	for _, u := range objs {
+		gvk := u.GroupVersionKind()
+		res, ok := byGVK[gvk]
+		if !ok {
+			plural, _ := meta.UnsafeGuessKindToResource(gvk)
+			res = Resource{GVK: gvk, Plural: plural.Resource}
+		}
+		if u.GetNamespace() != "" && !clusterScopedKinds[gvk.GroupKind()] && !fromCRD[gvk] {
+			res.Namespaced = true
+		}
+		byGVK[gvk] = res
+	}
+	if _, ok := byGVK[namespaceGVK]; !ok {
+		byGVK[namespaceGVK] = Resource{GVK: namespaceGVK, Plural: "namespaces"}
+	}
+	for _, u := range objs {
+		res := byGVK[u.GroupVersionKind()]
+		if !res.Namespaced {
+			u.SetNamespace("")
+		} else if u.GetNamespace() == "" {
+			u.SetNamespace("default")
+		}
+		if res.Namespaced {
+			namespaces[u.GetNamespace()] = true
+		}
+		if res.GVK == namespaceGVK {
+			hasNamespace[u.GetName()] = true
+		}
+		gvr := res.GVR()
+		k := key{gvr: gvr, namespace: u.GetNamespace(), name: u.GetName()}
+		if i, dup := index[k]; dup {
+			s.objects[gvr][i] = u
+			continue
+		}
+		index[k] = len(s.objects[gvr])
+		s.objects[gvr] = append(s.objects[gvr], u)
+	}
+	for ns := range namespaces {
+		if hasNamespace[ns] {
+			continue
+		}
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(namespaceGVK)
+		u.SetName(ns)
+		_ = unstructured.SetNestedField(u.Object, "Active", "status", "phase")
+		gvr := byGVK[namespaceGVK].GVR()
+		s.objects[gvr] = append(s.objects[gvr], u)
+	}
+
+	for _, res := range byGVK {
+		s.resources = append(s.resources, res)
+		s.byGVR[res.GVR()] = res
+	}
+	sort.Slice(s.resources, func(i, j int) bool {
+		a, b := s.resources[i].GVK, s.resources[j].GVK
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Version != b.Version {
+			return version.CompareKubeAwareVersionStrings(a.Version, b.Version) > 0
+		}
+		return s.resources[i].Plural < s.resources[j].Plural
+	})
+	for gvr := range s.objects {
+		items := s.objects[gvr]
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].GetNamespace() != items[j].GetNamespace() {
+				return items[i].GetNamespace() < items[j].GetNamespace()
+			}
+			return items[i].GetName() < items[j].GetName()
+		})
+	}
+	return s
+}
+
+// Resources returns the synthesized resource catalog sorted by group and
+// version priority.
+func (s *Store) Resources() []Resource { return append([]Resource(nil), s.resources...) }
+
+// Resource looks up a resource by group/version/resource.
+func (s *Store) Resource(gvr schema.GroupVersionResource) (Resource, bool) {
+	res, ok := s.byGVR[gvr]
+	return res, ok
+}
+
+// List returns the objects of gvr, restricted to namespace unless it is empty.
+func (s *Store) List(gvr schema.GroupVersionResource, namespace string) []*unstructured.Unstructured {
+	var out []*unstructured.Unstructured
+	for _, u := range s.objects[gvr] {
+		if namespace == "" || u.GetNamespace() == namespace {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// Get returns a single object.
+func (s *Store) Get(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, bool) {
+	for _, u := range s.objects[gvr] {
+		if u.GetNamespace() == namespace && u.GetName() == name {
+			return u, true
+		}
+	}
+	return nil, false
+}
+
+// CRD returns the CustomResourceDefinition of gk from the snapshot, or nil.
+func (s *Store) CRD(gk schema.GroupKind) *unstructured.Unstructured { return s.crds[gk] }
+
+// Namespaces returns the names of all namespaces in the snapshot.
+func (s *Store) Namespaces() []string {
+	var out []string
+	for _, u := range s.objects[namespaceGVK.GroupVersion().WithResource("namespaces")] {
+		out = append(out, u.GetName())
+	}
+	return out
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
@@ -0,0 +1,135 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const testPods = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: web-1
+    namespace: shop
+    labels:
+      app: web
+  spec:
+    nodeName: node-a
+    containers:
+    - name: web
+  status:
+    phase: Running
+    containerStatuses:
+    - name: web
+      ready: true
+      restartCount: 2
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: db-1
+    namespace: shop
+  spec:
+    containers:
+    - name: db
+  status:
+    phase: Pending
+`
+
+const testMisc = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: 3
+status:
+  readyReplicas: 2
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Cluster
+  names:
+    kind: Widget
+    plural: widgets
+  versions:
+  - name: v1
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gear
+  namespace: ignored
+---
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-a
+`
+
+func writeSnapshot(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"pods.yaml":         testPods,
+		"nested/misc.yml":   testMisc,
+		".hidden/skip.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: hidden\n",
+		"notes.txt":         "not yaml",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadSynthesizesResources(t *testing.T) {
+	s, err := Load(writeSnapshot(t))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	if res, ok := s.Resource(pods); !ok || !res.Namespaced || res.GVK.Kind != "Pod" {
+		t.Fatalf("pods resource = %+v, %v", res, ok)
+	}
+	if got := len(s.List(pods, "shop")); got != 2 {
+		t.Fatalf("expected 2 pods in shop, got %d", got)
+	}
+	if _, ok := s.Get(pods, "shop", "web-1"); !ok {
+		t.Fatalf("expected pod shop/web-1")
+	}
+
+	widgets := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	res, ok := s.Resource(widgets)
+	if !ok || res.Namespaced {
+		t.Fatalf("widgets should be cluster-scoped per CRD, got %+v, %v", res, ok)
+	}
+	if _, ok := s.Get(widgets, "", "gear"); !ok {
+		t.Fatalf("expected cluster-scoped widget gear")
+	}
+
+	if _, ok := s.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}); ok {
+		t.Fatalf("hidden directories must be skipped")
+	}
+	if got := s.Namespaces(); len(got) != 1 || got[0] != "shop" {
+		t.Fatalf("expected synthesized namespace shop, got %v", got)
+	}
+	ns, _ := s.Get(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, "", "shop")
+	if ns == nil || ns.Object["status"].(map[string]interface{})["phase"] != "Active" {
+		t.Fatalf("synthesized namespace should be Active, got %v", ns)
+	}
+}
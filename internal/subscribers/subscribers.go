@@ -0,0 +1,44 @@
+// Package subscribers keeps the callbacks interested in changes of a value.
+package subscribers
+
+import "sync"
+
+// List is a set of callbacks notified with values of type T. It is safe for
+// concurrent use; the zero value is an empty list.
+type List[T any] struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]func(T)
+}
+
+// Subscribe registers fn to be called on Notify. The returned function
+// removes the subscription.
+func (l *List[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.subs == nil {
+		l.subs = map[int]func(T){}
+	}
+	id := l.nextID
+	l.nextID++
+	l.subs[id] = fn
+	return func() {
+		l.mu.Lock()
+		delete(l.subs, id)
+		l.mu.Unlock()
+	}
+}
+
+// Notify calls every subscribed callback with v. Callbacks run on the calling
+// goroutine without the list locked, so they may subscribe or unsubscribe.
+func (l *List[T]) Notify(v T) {
+	l.mu.Lock()
+	subs := make([]func(T), 0, len(l.subs))
+	for _, fn := range l.subs {
+		subs = append(subs, fn)
+	}
+	l.mu.Unlock()
+	for _, fn := range subs {
+		fn(v)
+	}
+}
@@ -0,0 +1,36 @@
+package subscribers
+
+import "testing"
+
+func TestList(t *testing.T) {
+	var l List[int]
+	var a, b []int
+	unsubscribeA := l.Subscribe(func(v int) { a = append(a, v) })
+	l.Subscribe(func(v int) { b = append(b, v) })
+
+	l.Notify(1)
+	unsubscribeA()
+	l.Notify(2)
+
+	if len(a) != 1 || a[0] != 1 {
+		t.Errorf("a got %v, want [1]", a)
+	}
+	if len(b) != 2 || b[0] != 1 || b[1] != 2 {
+		t.Errorf("b got %v, want [1 2]", b)
+	}
+}
+
+func TestListUnsubscribeFromCallback(t *testing.T) {
+	var l List[struct{}]
+	calls := 0
+	var unsubscribe func()
+	unsubscribe = l.Subscribe(func(struct{}) {
+		calls++
+		unsubscribe()
+	})
+	l.Notify(struct{}{})
+	l.Notify(struct{}{})
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
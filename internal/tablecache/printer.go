@@ -0,0 +1,426 @@
+package tablecache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Printer renders objects as Table rows locally, for servers and backends that
+// do not serve Tables. Columns come from a CRD's additionalPrinterColumns when
+// one is given and from built-in printers for common kinds otherwise. Name is
+// always the first column; kinds without specific columns get Name and Age,
+// like the API server's default table convertor.
+type Printer struct {
+	gvk     schema.GroupVersionKind
+	columns []printColumn
+	now     func() time.Time
+}
+
+// printColumn computes one cell from an object.
+type printColumn struct {
+	def  metav1.TableColumnDefinition
+	cell func(u *unstructured.Unstructured, now time.Time) interface{}
+}
+
+// NewPrinter returns a printer for objects of gvk. crd is the
+// CustomResourceDefinition serving gvk, or nil for built-in resources.
+func NewPrinter(gvk schema.GroupVersionKind, crd *unstructured.Unstructured) *Printer {
+	p := &Printer{gvk: gvk, now: time.Now}
+	p.columns = append(p.columns, nameColumn)
+	if crdCols, ok := crdColumns(crd, gvk.Version); ok {
+		p.columns = append(p.columns, crdCols...)
+		return p
+	}
+	p.columns = append(p.columns, builtinColumns[gvk.GroupKind()]...)
+	p.columns = append(p.columns, ageColumn)
+	return p
+}
+
+// Columns returns the table column definitions of the printer.
+func (p *Printer) Columns() []metav1.TableColumnDefinition {
+	out := make([]metav1.TableColumnDefinition, 0, len(p.columns))
+	for _, c := range p.columns {
+		out = append(out, c.def)
+	}
+	return out
+}
+
+// Cells computes the cells of one object in column order.
+func (p *Printer) Cells(u *unstructured.Unstructured) []interface{} {
+	now := p.now()
+	cells := make([]interface{}, 0, len(p.columns))
+	for _, c := range p.columns {
+		cells = append(cells, c.cell(u, now))
+	}
+	return cells
+}
+
+// Table renders objs as a meta.k8s.io/v1 Table. includeObject follows the API
+// server semantics: None, Metadata (the default) or Object.
+func (p *Printer) Table(objs []*unstructured.Unstructured, includeObject metav1.IncludeObjectPolicy) (*metav1.Table, error) {
+	table := &metav1.Table{
+		TypeMeta:          metav1.TypeMeta{APIVersion: metav1.SchemeGroupVersion.String(), Kind: "Table"},
+		ColumnDefinitions: p.Columns(),
+	}
+	for _, u := range objs {
+		row := metav1.TableRow{Cells: p.Cells(u)}
+		switch includeObject {
+		case metav1.IncludeNone:
+		case metav1.IncludeObject:
+			raw, err := u.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			row.Object.Raw = raw
+		default:
+			raw, err := json.Marshal(PartialObjectMetadata(u))
+			if err != nil {
+				return nil, err
+			}
+			row.Object.Raw = raw
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+// RowList renders list as a RowList whose rows carry the objects.
+func (p *Printer) RowList(list *unstructured.UnstructuredList) *RowList {
+	out := NewRowList(p.gvk)
+	out.Columns = p.Columns()
+	if list == nil {
+		return out
+	}
+	out.ResourceVersion = list.GetResourceVersion()
+	for i := range list.Items {
+		u := &list.Items[i]
+		row := NewRow(p.gvk)
+		row.Columns = out.Columns
+		row.ObjectMeta = *PartialObjectMetadata(u).ObjectMeta.DeepCopy()
+		row.TableRow = metav1.TableRow{
+			Cells:  p.Cells(u),
+			Object: runtime.RawExtension{Object: u.DeepCopy()},
+		}
+		out.Items = append(out.Items, *row)
+	}
+	return out
+}
+
+// PartialObjectMetadata strips u down to its metadata, as the API server does
+// for as=PartialObjectMetadata and for Table rows by default.
+func PartialObjectMetadata(u *unstructured.Unstructured) *metav1.PartialObjectMetadata {
+	out := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: metav1.SchemeGroupVersion.String(), Kind: "PartialObjectMetadata"},
+	}
+	if m, ok := u.Object["metadata"].(map[string]interface{}); ok {
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(m, &out.ObjectMeta)
+	}
+	return out
+}
+
+var nameColumn = printColumn{
+	def:  metav1.TableColumnDefinition{Name: "Name", Type: "string", Format: "name", Description: "Name of the object"},
+	cell: func(u *unstructured.Unstructured, _ time.Time) interface{} { return u.GetName() },
+}
+
+var ageColumn = printColumn{
+	def: metav1.TableColumnDefinition{Name: "Age", Type: "string", Description: "Time since creation"},
+	cell: func(u *unstructured.Unstructured, now time.Time) interface{} {
+		return humanAge(u.GetCreationTimestamp().Time, now)
+	},
+}
+
+func humanAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(t))
+}
+
+// crdColumns builds columns from the additionalPrinterColumns of version in
+// crd, falling back to the legacy top-level list. It reports false when crd
+// is nil or defines no columns for version.
+func crdColumns(crd *unstructured.Unstructured, version string) ([]printColumn, bool) {
+	if crd == nil {
+		return nil, false
+	}
+	var specs []interface{}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, _ := v.(map[string]interface{})
+		if name, _, _ := unstructured.NestedString(m, "name"); name == version {
+			specs, _, _ = unstructured.NestedSlice(m, "additionalPrinterColumns")
+		}
+	}
+	if len(specs) == 0 {
+		specs, _, _ = unstructured.NestedSlice(crd.Object, "spec", "additionalPrinterColumns")
+	}
+	if len(specs) == 0 {
+		return nil, false
+	}
+	cols := make([]printColumn, 0, len(specs)+1)
+	for _, s := range specs {
+		m, _ := s.(map[string]interface{})
+		name, _, _ := unstructured.NestedString(m, "name")
+		path, _, _ := unstructured.NestedString(m, "jsonPath")
+		if path == "" {
+			// apiextensions.k8s.io/v1beta1 spelling
+			path, _, _ = unstructured.NestedString(m, "JSONPath")
+		}
+		typ, _, _ := unstructured.NestedString(m, "type")
+		format, _, _ := unstructured.NestedString(m, "format")
+		desc, _, _ := unstructured.NestedString(m, "description")
+		priority, _, _ := unstructured.NestedInt64(m, "priority")
+		if name == "" || path == "" {
+			continue
+		}
+		jp := jsonpath.New(name).AllowMissingKeys(true)
+		if err := jp.Parse(fmt.Sprintf("{%s}", path)); err != nil {
+			continue
+		}
+		cols = append(cols, printColumn{
+			def:  metav1.TableColumnDefinition{Name: name, Type: typ, Format: format, Description: desc, Priority: int32(priority)},
+			cell: jsonPathCell(jp, typ),
+		})
+	}
+	return cols, len(cols) > 0
+}
+
+// jsonPathCell evaluates jp on an object. Date columns render as ages like
+// the API server does; missing values render as "<none>".
+func jsonPathCell(jp *jsonpath.JSONPath, typ string) func(*unstructured.Unstructured, time.Time) interface{} {
+	return func(u *unstructured.Unstructured, now time.Time) interface{} {
+		results, err := jp.FindResults(u.Object)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			return "<none>"
+		}
+		v := results[0][0].Interface()
+		if v == nil {
+			return "<none>"
+		}
+		if typ == "date" {
+			if s, ok := v.(string); ok {
+				if t, err := time.Parse(time.RFC3339, s); err == nil {
+					return humanAge(t, now)
+				}
+			}
+		}
+		switch v := v.(type) {
+		case string, bool, int64, float64:
+			return v
+		case map[string]interface{}, []interface{}:
+			data, _ := json.Marshal(v)
+			return string(data)
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+}
+
+// builtinColumns mirrors the most important server-side printer columns of
+// common built-in kinds. Name and Age are added by NewPrinter.
+var builtinColumns = map[schema.GroupKind][]printColumn{
+	{Kind: "Pod"}: {
+		{def: metav1.TableColumnDefinition{Name: "Ready", Type: "string"}, cell: podReady},
+		{def: metav1.TableColumnDefinition{Name: "Status", Type: "string"}, cell: podStatus},
+		{def: metav1.TableColumnDefinition{Name: "Restarts", Type: "integer"}, cell: podRestarts},
+		{def: metav1.TableColumnDefinition{Name: "IP", Type: "string", Priority: 1}, cell: stringField("status", "podIP")},
+		{def: metav1.TableColumnDefinition{Name: "Node", Type: "string", Priority: 1}, cell: stringField("spec", "nodeName")},
+	},
+	{Kind: "Service"}: {
+		{def: metav1.TableColumnDefinition{Name: "Type", Type: "string"}, cell: stringField("spec", "type")},
+		{def: metav1.TableColumnDefinition{Name: "Cluster-IP", Type: "string"}, cell: stringField("spec", "clusterIP")},
+		{def: metav1.TableColumnDefinition{Name: "Ports", Type: "string"}, cell: servicePorts},
+	},
+	{Kind: "Node"}: {
+		{def: metav1.TableColumnDefinition{Name: "Status", Type: "string"}, cell: nodeStatus},
+		{def: metav1.TableColumnDefinition{Name: "Version", Type: "string"}, cell: stringField("status", "nodeInfo", "kubeletVersion")},
+	},
+	{Kind: "Namespace"}: {
+		{def: metav1.TableColumnDefinition{Name: "Status", Type: "string"}, cell: stringField("status", "phase")},
+	},
+	{Kind: "ConfigMap"}: {
+		{def: metav1.TableColumnDefinition{Name: "Data", Type: "integer"}, cell: dataCount("data", "binaryData")},
+	},
+	{Kind: "Secret"}: {
+		{def: metav1.TableColumnDefinition{Name: "Type", Type: "string"}, cell: stringField("type")},
+		{def: metav1.TableColumnDefinition{Name: "Data", Type: "integer"}, cell: dataCount("data")},
+	},
+	{Kind: "PersistentVolumeClaim"}: {
+		{def: metav1.TableColumnDefinition{Name: "Status", Type: "string"}, cell: stringField("status", "phase")},
+		{def: metav1.TableColumnDefinition{Name: "Volume", Type: "string"}, cell: stringField("spec", "volumeName")},
+		{def: metav1.TableColumnDefinition{Name: "Capacity", Type: "string"}, cell: stringField("status", "capacity", "storage")},
+	},
+	{Group: "apps", Kind: "Deployment"}: {
+		{def: metav1.TableColumnDefinition{Name: "Ready", Type: "string"}, cell: replicaRatio("readyReplicas")},
+		{def: metav1.TableColumnDefinition{Name: "Up-to-date", Type: "integer"}, cell: intField("status", "updatedReplicas")},
+		{def: metav1.TableColumnDefinition{Name: "Available", Type: "integer"}, cell: intField("status", "availableReplicas")},
+	},
+	{Group: "apps", Kind: "StatefulSet"}: {
+		{def: metav1.TableColumnDefinition{Name: "Ready", Type: "string"}, cell: replicaRatio("readyReplicas")},
+	},
+	{Group: "apps", Kind: "ReplicaSet"}: {
+		{def: metav1.TableColumnDefinition{Name: "Desired", Type: "integer"}, cell: intField("spec", "replicas")},
+		{def: metav1.TableColumnDefinition{Name: "Current", Type: "integer"}, cell: intField("status", "replicas")},
+		{def: metav1.TableColumnDefinition{Name: "Ready", Type: "integer"}, cell: intField("status", "readyReplicas")},
+	},
+	{Group: "apps", Kind: "DaemonSet"}: {
+		{def: metav1.TableColumnDefinition{Name: "Desired", Type: "integer"}, cell: intField("status", "desiredNumberScheduled")},
+		{def: metav1.TableColumnDefinition{Name: "Current", Type: "integer"}, cell: intField("status", "currentNumberScheduled")},
+		{def: metav1.TableColumnDefinition{Name: "Ready", Type: "integer"}, cell: intField("status", "numberReady")},
+	},
+	{Group: "batch", Kind: "Job"}: {
+		{def: metav1.TableColumnDefinition{Name: "Completions", Type: "string"}, cell: jobCompletions},
+	},
+}
+
+func stringField(fields ...string) func(*unstructured.Unstructured, time.Time) interface{} {
+	return func(u *unstructured.Unstructured, _ time.Time) interface{} {
+		v, _, _ := unstructured.NestedFieldNoCopy(u.Object, fields...)
+		if v == nil || v == "" {
+			return "<none>"
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+func intField(fields ...string) func(*unstructured.Unstructured, time.Time) interface{} {
+	return func(u *unstructured.Unstructured, _ time.Time) interface{} {
+		v, _, _ := unstructured.NestedInt64(u.Object, fields...)
+		return v
+	}
+}
+
+func dataCount(fields ...string) func(*unstructured.Unstructured, time.Time) interface{} {
+	return func(u *unstructured.Unstructured, _ time.Time) interface{} {
+		var n int64
+		for _, f := range fields {
+			m, _, _ := unstructured.NestedMap(u.Object, f)
+			n += int64(len(m))
+		}
+		return n
+	}
+}
+
+func replicaRatio(readyField string) func(*unstructured.Unstructured, time.Time) interface{} {
+	return func(u *unstructured.Unstructured, _ time.Time) interface{} {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", readyField)
+		desired, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		return fmt.Sprintf("%d/%d", ready, desired)
+	}
+}
+
+func jobCompletions(u *unstructured.Unstructured, _ time.Time) interface{} {
+	succeeded, _, _ := unstructured.NestedInt64(u.Object, "status", "succeeded")
+	completions, found, _ := unstructured.NestedInt64(u.Object, "spec", "completions")
+	if !found {
+		return fmt.Sprintf("%d/1", succeeded)
+	}
+	return fmt.Sprintf("%d/%d", succeeded, completions)
+}
+
+func containerStatuses(u *unstructured.Unstructured) []map[string]interface{} {
+	list, _, _ := unstructured.NestedSlice(u.Object, "status", "containerStatuses")
+	out := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func podReady(u *unstructured.Unstructured, _ time.Time) interface{} {
+	containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "containers")
+	ready := 0
+	for _, cs := range containerStatuses(u) {
+		if ok, _, _ := unstructured.NestedBool(cs, "ready"); ok {
+			ready++
+		}
+	}
+	return fmt.Sprintf("%d/%d", ready, len(containers))
+}
+
+func podStatus(u *unstructured.Unstructured, _ time.Time) interface{} {
+	if u.GetDeletionTimestamp() != nil {
+		return "Terminating"
+	}
+	for _, cs := range containerStatuses(u) {
+		if reason, _, _ := unstructured.NestedString(cs, "state", "waiting", "reason"); reason != "" {
+			return reason
+		}
+		if reason, _, _ := unstructured.NestedString(cs, "state", "terminated", "reason"); reason != "" {
+			return reason
+		}
+	}
+	if reason, _, _ := unstructured.NestedString(u.Object, "status", "reason"); reason != "" {
+		return reason
+	}
+	if phase, _, _ := unstructured.NestedString(u.Object, "status", "phase"); phase != "" {
+		return phase
+	}
+	return "Unknown"
+}
+
+func podRestarts(u *unstructured.Unstructured, _ time.Time) interface{} {
+	var restarts int64
+	for _, cs := range containerStatuses(u) {
+		n, _, _ := unstructured.NestedInt64(cs, "restartCount")
+		restarts += n
+	}
+	return restarts
+}
+
+func servicePorts(u *unstructured.Unstructured, _ time.Time) interface{} {
+	ports, _, _ := unstructured.NestedSlice(u.Object, "spec", "ports")
+	parts := make([]string, 0, len(ports))
+	for _, p := range ports {
+		m, _ := p.(map[string]interface{})
+		port, _, _ := unstructured.NestedInt64(m, "port")
+		proto, _, _ := unstructured.NestedString(m, "protocol")
+		if proto == "" {
+			proto = "TCP"
+		}
+		if nodePort, _, _ := unstructured.NestedInt64(m, "nodePort"); nodePort != 0 {
+			parts = append(parts, fmt.Sprintf("%d:%d/%s", port, nodePort, proto))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d/%s", port, proto))
+	}
+	if len(parts) == 0 {
+		return "<none>"
+	}
+	return strings.Join(parts, ",")
+}
+
+func nodeStatus(u *unstructured.Unstructured, _ time.Time) interface{} {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	status := "Unknown"
+	for _, c := range conditions {
+		m, _ := c.(map[string]interface{})
+		if t, _, _ := unstructured.NestedString(m, "type"); t != "Ready" {
+			continue
+		}
+		if s, _, _ := unstructured.NestedString(m, "status"); s == "True" {
+			status = "Ready"
+		} else {
+			status = "NotReady"
+		}
+	}
+	if unschedulable, _, _ := unstructured.NestedBool(u.Object, "spec", "unschedulable"); unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
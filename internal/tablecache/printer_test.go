@@ -0,0 +1,148 @@
+package tablecache
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func columnNames(cols []metav1.TableColumnDefinition) []string {
+	out := make([]string, 0, len(cols))
+	for _, c := range cols {
+		out = append(out, c.Name)
+	}
+	return out
+}
+
+func TestPrinterBuiltinPodColumns(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":              "web",
+			"namespace":         "default",
+			"creationTimestamp": now.Add(-2 * time.Hour).Format(time.RFC3339),
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}},
+		},
+		"status": map[string]interface{}{
+			"phase": "Running",
+			"containerStatuses": []interface{}{
+				map[string]interface{}{"name": "a", "ready": true, "restartCount": int64(1)},
+				map[string]interface{}{"name": "b", "ready": false, "restartCount": int64(2),
+					"state": map[string]interface{}{"waiting": map[string]interface{}{"reason": "CrashLoopBackOff"}}},
+			},
+		},
+	}}
+
+	p := NewPrinter(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, nil)
+	p.now = func() time.Time { return now }
+
+	want := []string{"Name", "Ready", "Status", "Restarts", "IP", "Node", "Age"}
+	if got := columnNames(p.Columns()); !slices.Equal(got, want) {
+		t.Fatalf("columns = %v, want %v", got, want)
+	}
+	list := p.RowList(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{*pod}})
+	if len(list.Items) != 1 {
+		t.Fatalf("expected one row, got %d", len(list.Items))
+	}
+	row := list.Items[0]
+	if row.Name != "web" || row.Namespace != "default" || row.CreationTimestamp.IsZero() {
+		t.Fatalf("row metadata not populated: %+v", row.ObjectMeta)
+	}
+	cells := row.Cells
+	if cells[0] != "web" || cells[1] != "1/2" || cells[2] != "CrashLoopBackOff" || cells[3] != int64(3) || cells[4] != "<none>" || cells[6] != "120m" {
+		t.Fatalf("unexpected cells %v", cells)
+	}
+}
+
+func TestPrinterDefaultColumns(t *testing.T) {
+	p := NewPrinter(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, nil)
+	if got := columnNames(p.Columns()); !slices.Equal(got, []string{"Name", "Age"}) {
+		t.Fatalf("columns = %v, want Name and Age", got)
+	}
+}
+
+func TestPrinterCRDColumns(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"spec": map[string]interface{}{
+			"group": "example.com",
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1alpha1"},
+				map[string]interface{}{
+					"name": "v1",
+					"additionalPrinterColumns": []interface{}{
+						map[string]interface{}{"name": "Size", "type": "integer", "jsonPath": ".spec.size"},
+						map[string]interface{}{"name": "Phase", "type": "string", "jsonPath": ".status.phase"},
+						map[string]interface{}{"name": "Owner", "type": "string", "jsonPath": ".spec.owner", "priority": int64(1)},
+						map[string]interface{}{"name": "Age", "type": "date", "jsonPath": ".metadata.creationTimestamp"},
+					},
+				},
+			},
+		},
+	}}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":              "gear",
+			"creationTimestamp": now.Add(-3 * 24 * time.Hour).Format(time.RFC3339),
+		},
+		"spec": map[string]interface{}{"size": int64(4)},
+	}}
+
+	p := NewPrinter(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, crd)
+	p.now = func() time.Time { return now }
+
+	cols := p.Columns()
+	if got := columnNames(cols); !slices.Equal(got, []string{"Name", "Size", "Phase", "Owner", "Age"}) {
+		t.Fatalf("columns = %v", got)
+	}
+	if cols[3].Priority != 1 {
+		t.Fatalf("expected priority 1 for Owner, got %d", cols[3].Priority)
+	}
+	cells := p.Cells(widget)
+	if cells[0] != "gear" || cells[1] != int64(4) || cells[2] != "<none>" || cells[4] != "3d" {
+		t.Fatalf("unexpected cells %v", cells)
+	}
+
+	// Versions without columns fall back to the built-in defaults.
+	p = NewPrinter(schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Widget"}, crd)
+	if got := columnNames(p.Columns()); !slices.Equal(got, []string{"Name", "Age"}) {
+		t.Fatalf("v1alpha1 columns = %v", got)
+	}
+}
+
+func TestPrinterTableIncludeObject(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cfg", "namespace": "default"},
+		"data":       map[string]interface{}{"a": "1", "b": "2"},
+	}}
+	p := NewPrinter(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, nil)
+	for policy, want := range map[metav1.IncludeObjectPolicy]string{
+		metav1.IncludeNone:     "",
+		metav1.IncludeMetadata: `{"kind":"PartialObjectMetadata","apiVersion":"meta.k8s.io/v1","metadata":{"name":"cfg","namespace":"default","creationTimestamp":null}}`,
+	} {
+		table, err := p.Table([]*unstructured.Unstructured{u}, policy)
+		if err != nil {
+			t.Fatalf("Table(%s): %v", policy, err)
+		}
+		if got := string(table.Rows[0].Object.Raw); got != want {
+			t.Fatalf("Table(%s) object = %s, want %s", policy, got, want)
+		}
+		if table.Rows[0].Cells[1] != int64(2) {
+			t.Fatalf("expected data count 2, got %v", table.Rows[0].Cells[1])
+		}
+	}
+}
@@ -61,11 +61,17 @@ func NewReader(delegate withWatch, mapper meta.RESTMapper, cfg *rest.Config, sch
 	if err != nil {
 		return nil, err
 	}
-	return &Reader{delegate: delegate, mapper: mapper, fetcher: fetcher}, nil
+	return newReader(delegate, mapper, fetcher), nil
 }
 
 // NewReaderWithFetcher is intended for tests, letting callers inject a custom table fetcher.
 func NewReaderWithFetcher(delegate withWatch, mapper meta.RESTMapper, fetcher tableFetcher) *Reader {
+	return newReader(delegate, mapper, fetcher)
+}
+
+// newReader returns a Reader fetching tables through fetcher, e.g. the one
+// shared with a row cache.
+func newReader(delegate withWatch, mapper meta.RESTMapper, fetcher tableFetcher) *Reader {
 	if delegate == nil {
 		panic("tablecache: delegate reader must not be nil")
 	}
@@ -140,8 +146,9 @@ func (r *Reader) Watch(ctx context.Context, list client.ObjectList, opts ...clie
 		opt.ApplyToList(lo)
 	}
 
-	listOpts := lo.AsListOptions()
-	upstream, err := r.fetcher.WatchTable(ctx, mapping, lo.Namespace, *listOpts.DeepCopy())
+	watchOpts := lo.AsListOptions().DeepCopy()
+	watchOpts.Watch = true
+	upstream, err := r.fetcher.WatchTable(ctx, mapping, lo.Namespace, *watchOpts)
 	if err != nil {
 		return nil, err
 	}
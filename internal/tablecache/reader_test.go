@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -303,9 +304,11 @@ type fakeFetcher struct {
 	lastNamespace string
 	lastListOpts  metav1.ListOptions
 	lastWatchOpts metav1.ListOptions
+	listCalls     atomic.Int32
 }
 
 func (f *fakeFetcher) ListTable(_ context.Context, _ *meta.RESTMapping, namespace string, opts metav1.ListOptions) (*metav1.Table, error) {
+	f.listCalls.Add(1)
 	f.lastNamespace = namespace
 	f.lastListOpts = opts
 	if f.listErr != nil {
@@ -3,21 +3,98 @@ package tablecache
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	toolscache "k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// rowSyncPollInterval controls how often List/Get re-check informer sync state.
+const rowSyncPollInterval = 50 * time.Millisecond
+
+// rowCache serves Row/RowList objects from shared informers that are fed by
+// table watches. Every other type is delegated to the embedded cache.
 type rowCache struct {
 	cache.Cache
 	mapper  meta.RESTMapper
 	fetcher tableFetcher
+	reader  *Reader
+
+	mu        sync.Mutex
+	ctx       context.Context // set by Start; nil until the cache runs
+	informers map[rowInformerKey]*rowInformer
+}
+
+// rowInformerKey identifies a Row informer by target kind and namespace. An
+// empty namespace denotes an all-namespaces (or cluster-scoped) informer.
+type rowInformerKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
 }
 
-func newRowCache(base cache.Cache, mapper meta.RESTMapper, fetcher tableFetcher) cache.Cache {
-	return &rowCache{Cache: base, mapper: mapper, fetcher: fetcher}
+// rowInformer wraps a shared informer over Row objects together with the
+// lifecycle state needed to stop it and to surface initial list failures.
+type rowInformer struct {
+	toolscache.SharedIndexInformer
+	key rowInformerKey
+
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	columns []metav1.TableColumnDefinition
+	err     error
+}
+
+func newRowCache(base cache.Cache, mapper meta.RESTMapper, fetcher tableFetcher, delegate withWatch) cache.Cache {
+	return &rowCache{
+		Cache:     base,
+		mapper:    mapper,
+		fetcher:   fetcher,
+		reader:    newReader(delegate, mapper, fetcher),
+		informers: map[rowInformerKey]*rowInformer{},
+	}
+}
+
+// Start runs pending Row informers and the delegate cache; it blocks until ctx is done.
+func (c *rowCache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	for _, inf := range c.informers {
+		c.runLocked(inf)
+	}
+	c.mu.Unlock()
+
+	err := c.Cache.Start(ctx)
+
+	c.mu.Lock()
+	c.ctx = nil
+	c.informers = map[rowInformerKey]*rowInformer{}
+	c.mu.Unlock()
+	return err
+}
+
+// WaitForCacheSync waits for the delegate cache and all Row informers.
+func (c *rowCache) WaitForCacheSync(ctx context.Context) bool {
+	if !c.Cache.WaitForCacheSync(ctx) {
+		return false
+	}
+	c.mu.Lock()
+	syncs := make([]toolscache.InformerSynced, 0, len(c.informers))
+	for _, inf := range c.informers {
+		syncs = append(syncs, inf.HasSynced)
+	}
+	c.mu.Unlock()
+	return toolscache.WaitForCacheSync(ctx.Done(), syncs...)
 }
 
 func (c *rowCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
@@ -31,30 +108,59 @@ func (c *rowCache) List(ctx context.Context, list client.ObjectList, opts ...cli
 		return fmt.Errorf("tablecache: RowList missing TableTarget")
 	}
 
-	mapping, err := c.mapper.RESTMapping(target.GroupKind(), target.Version)
-	if err != nil {
-		return err
-	}
-
 	lo := &client.ListOptions{}
 	for _, opt := range opts {
 		opt.ApplyToList(lo)
 	}
 
-	listOpts := lo.AsListOptions()
-	table, err := c.fetcher.ListTable(ctx, mapping, lo.Namespace, *listOpts.DeepCopy())
-	if err != nil {
-		return err
+	// Field selectors cannot be evaluated against cached rows, and before
+	// Start there is nothing to serve from; ask the server directly then.
+	if (lo.FieldSelector != nil && !lo.FieldSelector.Empty()) || !c.started() {
+		return c.reader.List(ctx, rowList, opts...)
 	}
 
-	rows, err := convertTable(table, target)
+	inf, err := c.informerFor(target, lo.Namespace)
 	if err != nil {
 		return err
 	}
+	if err := c.waitForSync(ctx, inf); err != nil {
+		return err
+	}
 
-	rowList.SetTableTarget(target)
-	copy := rows.DeepCopy()
-	*rowList = *copy
+	var objs []interface{}
+	if lo.Namespace != "" && inf.key.namespace == "" {
+		objs, err = inf.GetIndexer().ByIndex(toolscache.NamespaceIndex, lo.Namespace)
+		if err != nil {
+			return err
+		}
+	} else {
+		objs = inf.GetIndexer().List()
+	}
+
+	out := NewRowList(target)
+	out.Columns = inf.Columns()
+	out.ResourceVersion = inf.LastSyncResourceVersion()
+	for _, obj := range objs {
+		row, ok := obj.(*Row)
+		if !ok {
+			continue
+		}
+		if lo.LabelSelector != nil && !lo.LabelSelector.Matches(labels.Set(row.Labels)) {
+			continue
+		}
+		out.Items = append(out.Items, *row.DeepCopy())
+	}
+	sort.Slice(out.Items, func(i, j int) bool {
+		if out.Items[i].Namespace != out.Items[j].Namespace {
+			return out.Items[i].Namespace < out.Items[j].Namespace
+		}
+		return out.Items[i].Name < out.Items[j].Name
+	})
+	if lo.Limit > 0 && int64(len(out.Items)) > lo.Limit {
+		out.Items = out.Items[:lo.Limit]
+	}
+
+	*rowList = *out
 	return nil
 }
 
@@ -69,6 +175,41 @@ func (c *rowCache) Get(ctx context.Context, key client.ObjectKey, obj client.Obj
 		return fmt.Errorf("tablecache: Row missing TableTarget")
 	}
 
+	if !c.started() {
+		return c.getDirect(ctx, key, row)
+	}
+
+	inf, err := c.informerFor(target, key.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := c.waitForSync(ctx, inf); err != nil {
+		return err
+	}
+
+	storeKey := key.Name
+	if key.Namespace != "" {
+		storeKey = key.Namespace + "/" + key.Name
+	}
+	item, exists, err := inf.GetIndexer().GetByKey(storeKey)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return c.notFound(target, key.Name)
+	}
+	cached, ok := item.(*Row)
+	if !ok {
+		return fmt.Errorf("tablecache: unexpected object %T in row store", item)
+	}
+	*row = *cached.DeepCopy()
+	row.SetTableTarget(target)
+	return nil
+}
+
+// getDirect fetches a single row from the server, bypassing informers.
+func (c *rowCache) getDirect(ctx context.Context, key client.ObjectKey, row *Row) error {
+	target := row.TableTarget()
 	mapping, err := c.mapper.RESTMapping(target.GroupKind(), target.Version)
 	if err != nil {
 		return err
@@ -93,26 +234,216 @@ func (c *rowCache) Get(ctx context.Context, key client.ObjectKey, obj client.Obj
 		}
 	}
 
-	return client.IgnoreNotFound(fmt.Errorf("tablecache: row %s/%s not found", key.Namespace, key.Name))
+	return c.notFound(target, key.Name)
+}
+
+func (c *rowCache) notFound(target schema.GroupVersionKind, name string) error {
+	gr := schema.GroupResource{Group: target.Group, Resource: target.Kind}
+	if mapping, err := c.mapper.RESTMapping(target.GroupKind(), target.Version); err == nil {
+		gr = mapping.Resource.GroupResource()
+	}
+	return apierrors.NewNotFound(gr, name)
 }
 
 func (c *rowCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
-	if _, ok := obj.(*Row); ok {
-		return nil, fmt.Errorf("tablecache: informers not supported for Row")
+	if row, ok := obj.(*Row); ok {
+		target := row.TableTarget()
+		if target.Empty() {
+			return nil, fmt.Errorf("tablecache: Row missing TableTarget")
+		}
+		return c.informerFor(target, row.GetNamespace())
 	}
 	return c.Cache.GetInformer(ctx, obj, opts...)
 }
 
 func (c *rowCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
 	if gvk.Group == SchemeGroupVersion.Group && (gvk.Kind == RowKind || gvk.Kind == RowListKind) {
-		return nil, fmt.Errorf("tablecache: informers not supported for Row types")
+		return nil, fmt.Errorf("tablecache: Row informers need a table target, use GetInformer with a Row")
 	}
 	return c.Cache.GetInformerForKind(ctx, gvk, opts...)
 }
 
+// RemoveInformer stops and forgets the Row informers for the row's target.
+// With a namespace set only that namespace's informer is removed; without one
+// every informer of the target is, which also works after the resource has
+// disappeared from discovery.
+func (c *rowCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	row, ok := obj.(*Row)
+	if !ok {
+		return c.Cache.RemoveInformer(ctx, obj)
+	}
+	target := row.TableTarget()
+	namespace := row.GetNamespace()
+	var removed []*rowInformer
+	c.mu.Lock()
+	for key, inf := range c.informers {
+		if key.gvk != target || (namespace != "" && key.namespace != namespace) {
+			continue
+		}
+		delete(c.informers, key)
+		removed = append(removed, inf)
+	}
+	c.mu.Unlock()
+	for _, inf := range removed {
+		inf.stop()
+	}
+	return nil
+}
+
 func (c *rowCache) IndexField(ctx context.Context, obj client.Object, field string, extract client.IndexerFunc) error {
 	if _, ok := obj.(*Row); ok {
 		return fmt.Errorf("tablecache: indexing not supported for Row")
 	}
 	return c.Cache.IndexField(ctx, obj, field, extract)
 }
+
+func (c *rowCache) started() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ctx != nil && c.ctx.Err() == nil
+}
+
+// informerKey normalizes the namespace for cluster-scoped targets.
+func (c *rowCache) informerKey(target schema.GroupVersionKind, namespace string) (rowInformerKey, error) {
+	mapping, err := c.mapper.RESTMapping(target.GroupKind(), target.Version)
+	if err != nil {
+		return rowInformerKey{}, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	}
+	return rowInformerKey{gvk: target, namespace: namespace}, nil
+}
+
+// informerFor returns the informer serving target rows in namespace, creating
+// it on demand. An existing all-namespaces informer also serves namespaced reads.
+func (c *rowCache) informerFor(target schema.GroupVersionKind, namespace string) (*rowInformer, error) {
+	key, err := c.informerKey(target, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inf, ok := c.informers[key]; ok {
+		return inf, nil
+	}
+	if key.namespace != "" {
+		if inf, ok := c.informers[rowInformerKey{gvk: target}]; ok {
+			return inf, nil
+		}
+	}
+
+	inf := c.newRowInformer(key)
+	c.informers[key] = inf
+	if c.ctx != nil {
+		c.runLocked(inf)
+	}
+	return inf, nil
+}
+
+func (c *rowCache) newRowInformer(key rowInformerKey) *rowInformer {
+	inf := &rowInformer{key: key}
+	lw := &toolscache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			list := NewRowList(key.gvk)
+			lo := &client.ListOptions{Namespace: key.namespace, Limit: opts.Limit, Continue: opts.Continue, Raw: &opts}
+			if err := c.reader.List(inf.context(), list, lo); err != nil {
+				return nil, err
+			}
+			inf.setColumns(list.Columns)
+			return list, nil
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			// Bookmarks arrive as raw tables which the Row store cannot hold.
+			opts.AllowWatchBookmarks = false
+			opts.Watch = true
+			lo := &client.ListOptions{Namespace: key.namespace, Raw: &opts}
+			return c.reader.Watch(inf.context(), NewRowList(key.gvk), lo)
+		},
+	}
+	inf.SharedIndexInformer = toolscache.NewSharedIndexInformer(lw, &Row{}, 0, toolscache.Indexers{
+		toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc,
+	})
+	_ = inf.SetWatchErrorHandler(func(r *toolscache.Reflector, err error) {
+		inf.setErr(err)
+		toolscache.DefaultWatchErrorHandler(r, err)
+	})
+	return inf
+}
+
+// runLocked starts inf under the cache context. Callers must hold c.mu.
+func (c *rowCache) runLocked(inf *rowInformer) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	inf.mu.Lock()
+	inf.ctx, inf.cancel = ctx, cancel
+	inf.mu.Unlock()
+	go inf.Run(ctx.Done())
+}
+
+// waitForSync blocks until inf has synced. If the initial list fails, the
+// informer is dropped so the next read retries from scratch, and the error
+// is returned instead of blocking the caller forever.
+func (c *rowCache) waitForSync(ctx context.Context, inf *rowInformer) error {
+	for !inf.HasSynced() {
+		if err := inf.lastErr(); err != nil {
+			c.mu.Lock()
+			if c.informers[inf.key] == inf {
+				delete(c.informers, inf.key)
+			}
+			c.mu.Unlock()
+			inf.stop()
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rowSyncPollInterval):
+		}
+	}
+	return nil
+}
+
+func (i *rowInformer) context() context.Context {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.ctx == nil {
+		return context.Background()
+	}
+	return i.ctx
+}
+
+func (i *rowInformer) stop() {
+	i.mu.Lock()
+	cancel := i.cancel
+	i.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// Columns returns the column definitions from the most recent table list.
+func (i *rowInformer) Columns() []metav1.TableColumnDefinition {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]metav1.TableColumnDefinition(nil), i.columns...)
+}
+
+func (i *rowInformer) setColumns(cols []metav1.TableColumnDefinition) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.columns = append([]metav1.TableColumnDefinition(nil), cols...)
+	i.err = nil
+}
+
+func (i *rowInformer) setErr(err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.err = err
+}
+
+func (i *rowInformer) lastErr() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.err
+}
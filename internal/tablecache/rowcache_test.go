@@ -0,0 +1,124 @@
+package tablecache
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRowCacheServesListsFromInformer(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	target := corev1.SchemeGroupVersion.WithKind("Pod")
+	mapper.AddSpecific(target, schema.GroupVersionResource{Version: "v1", Resource: "pods"}, schema.GroupVersionResource{Version: "v1", Resource: "pod"}, meta.RESTScopeNamespace)
+
+	table := buildTestTable(t)
+	fakeWatch := watch.NewFake()
+	fetcher := &fakeFetcher{table: table, watch: fakeWatch}
+	rc := newRowCache(&stubCache{}, mapper, fetcher, &fakeDelegate{})
+
+	go func() { _ = rc.Start(ctx) }()
+	waitFor(t, func() bool { return rc.(*rowCache).started() })
+
+	rows := NewRowList(target)
+	if err := rc.List(ctx, rows, client.InNamespace("default")); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(rows.Items) != 2 || rows.Items[0].Name != "pod-a" || rows.Items[1].Name != "pod-b" {
+		t.Fatalf("unexpected rows %+v", rows.Items)
+	}
+	if len(rows.Columns) != 1 || rows.Columns[0].Name != "Name" {
+		t.Fatalf("expected table columns to be carried over, got %+v", rows.Columns)
+	}
+
+	if err := rc.List(ctx, NewRowList(target), client.InNamespace("default")); err != nil {
+		t.Fatalf("second List returned error: %v", err)
+	}
+	if got := fetcher.listCalls.Load(); got != 1 {
+		t.Fatalf("expected a single table LIST, got %d", got)
+	}
+
+	inf, err := rc.GetInformer(ctx, &Row{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}, target: target})
+	if err != nil {
+		t.Fatalf("GetInformer returned error: %v", err)
+	}
+	if !inf.HasSynced() {
+		t.Fatalf("expected informer to be synced")
+	}
+
+	added := buildTestTable(t)
+	added.Rows = added.Rows[:1]
+	raw := added.Rows[0].Object.Raw
+	added.Rows[0].Object.Raw = []byte(strings.Replace(string(raw), "pod-a", "pod-c", 1))
+	go fakeWatch.Add(added)
+
+	waitFor(t, func() bool {
+		rows := NewRowList(target)
+		return rc.List(ctx, rows, client.InNamespace("default")) == nil && len(rows.Items) == 3
+	})
+
+	single := NewRow(target)
+	if err := rc.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pod-c"}, single); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if single.Name != "pod-c" || single.TableTarget() != target {
+		t.Fatalf("unexpected row %s with target %v", single.Name, single.TableTarget())
+	}
+	if got := fetcher.listCalls.Load(); got != 1 {
+		t.Fatalf("expected reads to be served from memory, got %d LISTs", got)
+	}
+}
+
+func TestRowCacheListsDirectlyBeforeStart(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	target := corev1.SchemeGroupVersion.WithKind("Pod")
+	mapper.AddSpecific(target, schema.GroupVersionResource{Version: "v1", Resource: "pods"}, schema.GroupVersionResource{Version: "v1", Resource: "pod"}, meta.RESTScopeNamespace)
+
+	fetcher := &fakeFetcher{table: buildTestTable(t)}
+	rc := newRowCache(&stubCache{}, mapper, fetcher, &fakeDelegate{})
+
+	rows := NewRowList(target)
+	if err := rc.List(t.Context(), rows, client.InNamespace("default")); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(rows.Items) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows.Items))
+	}
+	if fetcher.lastNamespace != "default" {
+		t.Fatalf("expected namespace default, got %q", fetcher.lastNamespace)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// stubCache stands in for the controller-runtime cache that rowCache delegates non-Row types to.
+type stubCache struct {
+	cache.Cache
+}
+
+func (s *stubCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (s *stubCache) WaitForCacheSync(context.Context) bool { return true }
@@ -6,6 +6,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
@@ -15,6 +16,7 @@ type Options struct {
 }
 
 // New constructs a cache that lists/watches Kubernetes resources using Table responses when requested.
+// Row reads are served from shared informers fed by table watches once the cache has been started.
 func New(cfg *rest.Config, opts Options) (cache.Cache, error) {
 	base, err := cache.New(cfg, opts.Options)
 	if err != nil {
@@ -47,7 +49,13 @@ func New(cfg *rest.Config, opts Options) (cache.Cache, error) {
 		return nil, err
 	}
 
-	return newRowCache(base, mapper, fetcher), nil
+	// The object-watch fallback needs a watching client; unstructured lists are decoded without the scheme.
+	delegate, err := client.NewWithWatch(cfg, client.Options{Scheme: sch, Mapper: mapper, HTTPClient: httpClient})
+	if err != nil {
+		return nil, fmt.Errorf("tablecache: build watch client: %w", err)
+	}
+
+	return newRowCache(base, mapper, fetcher, delegate), nil
 }
 
 // NewFromOptions is a convenience wrapper that accepts a plain cache.Options value.
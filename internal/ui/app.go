@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"syscall"
 	"time"
@@ -18,6 +19,8 @@ import (
 	models "github.com/sttts/kc/internal/models"
 	navui "github.com/sttts/kc/internal/navigation"
 	"github.com/sttts/kc/internal/overlay"
+	"github.com/sttts/kc/internal/portforward"
+	"github.com/sttts/kc/internal/snapshot"
 	"github.com/sttts/kc/pkg/appconfig"
 	"github.com/sttts/kc/pkg/kubeconfig"
 	corev1 "k8s.io/api/core/v1"
@@ -44,16 +47,29 @@ type kubectlEditFinishedMsg struct {
 	tempConfig  string
 }
 
+// kubeconfigChangedMsg reports that kubeconfig files changed on disk.
+type kubeconfigChangedMsg struct{}
+
+// clusterStateMsg carries a connection state change published by the cluster pool.
+type clusterStateMsg struct {
+	key   kccluster.Key
+	state kccluster.State
+}
+
 type namespaceCreatedMsg struct {
-	name string
-	err  error
+	name    string
+	context string
+	err     error
 }
 
 type deleteTarget struct {
 	panelIdx  int
+	cluster   panelCluster
 	gvr       schema.GroupVersionResource
 	namespace string
 	name      string
+	// kubeContext is set when a kubeconfig context is deleted instead of an object.
+	kubeContext *contextTarget
 }
 
 type resourceDeletedMsg struct {
@@ -89,6 +105,9 @@ type App struct {
 	// New navigation (folder-backed) using a Navigator
 	leftNav  *navui.Navigator
 	rightNav *navui.Navigator
+	// navSeq counts the navigations of each panel so that a background
+	// navigation is dropped once the panel navigated again, see navigatePanel.
+	navSeq [2]int
 	// Mouse double-click detection
 	lastClickTime  time.Time
 	lastClickPanel int
@@ -118,6 +137,34 @@ type App struct {
 	deleteConfirm        *DeleteConfirmModel
 	pendingDelete        *deleteTarget
 	namespaceCreatePanel int
+	prompt               *PromptModel
+	pendingPrompt        func(string) tea.Cmd
+	// snapshot serves an offline dump directory instead of live clusters (read-only).
+	snapshotDir string
+	snapshot    *snapshot.Server
+	// kubeconfigPath restricts discovery to one file (--kubeconfig).
+	kubeconfigPath string
+	// kubeSource feeds context and kubeconfig folders; it is replaced when
+	// kubeconfig files change on disk.
+	kubeSource *models.KubeconfigSource
+	// portForwards runs the port-forwards started from any panel until they
+	// are stopped or kc exits.
+	portForwards *portforward.Manager
+	// exec is the exec session shown instead of the panels, if any.
+	exec *ExecSession
+}
+
+// Options configures Run.
+type Options struct {
+	// Snapshot is a directory of YAML/JSON dumps to browse instead of the
+	// clusters from the kubeconfigs.
+	Snapshot string
+	// Kubeconfig is an explicit kubeconfig file, taking precedence over
+	// $KUBECONFIG and ~/.kube like kubectl's --kubeconfig flag.
+	Kubeconfig string
+	// Path is where the left panel starts, e.g. "/namespaces/kube-system/pods",
+	// instead of the current context's namespace.
+	Path string
 }
 
 const requestTimeout = 10 * time.Second
@@ -189,6 +236,7 @@ func cloneConfig(cfg *appconfig.Config) *appconfig.Config {
 	}
 	clone := *cfg
 	clone.Resources.Favorites = append([]string(nil), cfg.Resources.Favorites...)
+	clone.Exec.Command = append([]string(nil), cfg.Exec.Command...)
 	return &clone
 }
 
@@ -231,6 +279,250 @@ func (a *App) navigatorForPanel(panel *Panel) *navui.Navigator {
 	return a.leftNav
 }
 
+// panelCluster describes the cluster connection a panel is browsing.
+type panelCluster struct {
+	key kccluster.Key
+	cl  *kccluster.Cluster
+	ctx *kubeconfig.Context
+}
+
+// name returns the context label used in toasts.
+func (pc panelCluster) name() string {
+	if pc.ctx != nil {
+		return pc.ctx.Name
+	}
+	return pc.key.ContextName
+}
+
+// clusterForPanel resolves the cluster behind the panel's current folder. Each
+// panel may browse a different context; the startup cluster is the fallback
+// for panels that have not been populated yet. It neither restarts clusters
+// evicted from the pool nor keeps them alive, so render and tick paths can
+// use it; user actions use clusterForAction.
+func (a *App) clusterForPanel(panel *Panel) panelCluster {
+	return a.resolvePanelCluster(panel, false)
+}
+
+// clusterForAction is clusterForPanel for user actions. It gets the cluster
+// from the pool, restarting it if it was evicted while idle.
+func (a *App) clusterForAction(panel *Panel) panelCluster {
+	return a.resolvePanelCluster(panel, true)
+}
+
+func (a *App) resolvePanelCluster(panel *Panel, get bool) panelCluster {
+	pc := panelCluster{cl: a.cl, ctx: a.currentCtx}
+	if a.cl != nil {
+		pc.key = a.cl.Key()
+	}
+	if nav := a.navigatorForPanel(panel); nav != nil {
+		if cf, ok := nav.Current().(models.ClusterFolder); ok && cf.Cluster() != nil {
+			pc.cl = cf.Cluster()
+			pc.key = pc.cl.Key()
+		}
+	}
+	if pc.key == (kccluster.Key{}) {
+		return pc
+	}
+	switch {
+	case a.clPool == nil:
+	case get:
+		if cl, err := a.clPool.Get(a.ctx, pc.key); err == nil {
+			pc.cl = cl
+		}
+	default:
+		if cl, ok := a.clPool.Lookup(pc.key); ok {
+			pc.cl = cl
+		}
+	}
+	pc.ctx = a.contextForKey(pc.key)
+	return pc
+}
+
+// contextForKey finds the kubeconfig context a pool key was created for.
+func (a *App) contextForKey(key kccluster.Key) *kubeconfig.Context {
+	if a.currentCtx != nil && a.currentCtx.Name == key.ContextName && a.currentCtx.Kubeconfig != nil && a.currentCtx.Kubeconfig.Path == key.KubeconfigPath {
+		return a.currentCtx
+	}
+	if a.kubeMgr == nil {
+		return nil
+	}
+	for _, ctx := range a.kubeMgr.GetContexts() {
+		if ctx != nil && ctx.Name == key.ContextName && ctx.Kubeconfig != nil && ctx.Kubeconfig.Path == key.KubeconfigPath {
+			return ctx
+		}
+	}
+	return nil
+}
+
+// touchPanelClusters keeps the clusters shown in either panel from being
+// evicted from the pool while they are idle on screen.
+func (a *App) touchPanelClusters() {
+	if a.clPool == nil {
+		return
+	}
+	for _, nav := range []*navui.Navigator{a.leftNav, a.rightNav} {
+		if nav == nil {
+			continue
+		}
+		if cf, ok := nav.Current().(models.ClusterFolder); ok && cf.Cluster() != nil {
+			a.clPool.Touch(cf.Cluster().Key())
+		}
+	}
+}
+
+// rebindReplacedCluster re-enters the panels still browsing an older cluster
+// of key after the pool replaced it, e.g. because it stopped unexpectedly.
+// Folders keep the cluster they were created with, so the panels are rebuilt
+// along their current path.
+func (a *App) rebindReplacedCluster(key kccluster.Key) {
+	if a.clPool == nil {
+		return
+	}
+	cl, ok := a.clPool.Lookup(key)
+	if !ok {
+		return
+	}
+	// The root folders of both panels are bound to the startup cluster.
+	rootStale := a.cl != nil && a.cl.Key() == key && a.cl != cl
+	if rootStale {
+		a.cl = cl
+	}
+	for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+		nav := a.navigatorForPanel(panel)
+		if panel == nil || nav == nil {
+			continue
+		}
+		cf, ok := nav.Current().(models.ClusterFolder)
+		if rootStale || (ok && cf.Cluster() != nil && cf.Cluster().Key() == key && cf.Cluster() != cl) {
+			a.reenterPanel(panel, a.navigatorPath(nav))
+		}
+	}
+}
+
+// reenterPanel rebuilds the panel's navigator from a fresh root and walks to
+// path in the background, keeping the selection when the selected row still
+// exists.
+func (a *App) reenterPanel(panel *Panel, path string) {
+	selID := ""
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	if item, ok := panel.SelectedNavItem(ctx); ok && item != nil {
+		selID, _, _, _ = item.Columns()
+	}
+	cancel()
+	a.enqueueCmd(a.navigatePanel(panel, a.newPanelNavigator(panel), path, selID, false))
+}
+
+// newPanelNavigator returns a navigator at a fresh root folder for panel.
+func (a *App) newPanelNavigator(panel *Panel) *navui.Navigator {
+	cfg := a.ensurePanelConfig(panel)
+	a.syncPanelConfig(panel)
+	currentName := ""
+	if a.currentCtx != nil {
+		currentName = a.currentCtx.Name
+	}
+	return navui.NewNavigator(models.NewRootFolder(a.makeDeps(a.cl, cfg, currentName), a.makeEnterContextFunc(cfg)))
+}
+
+// panelNavigatedMsg carries a navigator that walked to path in the
+// background, see navigatePanel.
+type panelNavigatedMsg struct {
+	panelIdx int
+	seq      int
+	nav      *navui.Navigator
+	path     string
+	selID    string
+	report   bool
+	err      error
+}
+
+// navigatePanel walks nav, which the panel does not use yet, to path in the
+// background, as listing and entering the folders along the way may take a
+// while. Once done, the panel switches to nav and selects selID, if set,
+// unless it navigated again in the meantime. With report set, a path that
+// cannot be reached is shown in a toast.
+func (a *App) navigatePanel(panel *Panel, nav *navui.Navigator, path, selID string, report bool) tea.Cmd {
+	idx := a.panelIndex(panel)
+	if idx < 0 {
+		return nil
+	}
+	a.navSeq[idx]++
+	seq := a.navSeq[idx]
+	ctx := a.ctx
+	return a.withBusy("Navigate", 300*time.Millisecond, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		_, err := nav.GoTo(ctx, path)
+		return panelNavigatedMsg{panelIdx: idx, seq: seq, nav: nav, path: path, selID: selID, report: report, err: err}
+	})
+}
+
+// applyPanelNavigation shows the folder a background navigation reached. On
+// error the panel shows the deepest folder that could be reached.
+func (a *App) applyPanelNavigation(msg panelNavigatedMsg) {
+	if msg.seq != a.navSeq[msg.panelIdx] {
+		return
+	}
+	if msg.err != nil {
+		ctrllog.FromContext(a.ctx).Error(msg.err, "failed to navigate panel", "path", msg.path)
+		if msg.report && a.toastLogger != nil {
+			a.enqueueCmd(a.toastLogger.Errorf("Go to %s: %v", msg.path, msg.err))
+		}
+	}
+	panel := a.panelByIndex(msg.panelIdx)
+	if msg.panelIdx == 1 {
+		a.rightNav = msg.nav
+	} else {
+		a.leftNav = msg.nav
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	defer cancel()
+	panel.SetFolder(ctx, msg.nav.Current(), msg.nav.HasBack())
+	panel.SetCurrentPath(a.navigatorPath(msg.nav))
+	panel.ResetSelectionTop(ctx)
+	if msg.selID != "" {
+		panel.SelectByRowID(ctx, msg.selID)
+	}
+	a.syncPanelClusterStates()
+}
+
+// syncPanelClusterStates shows the pool state of each panel's cluster in its
+// footer and breadcrumb.
+func (a *App) syncPanelClusterStates() {
+	if a.clPool == nil {
+		return
+	}
+	for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+		if panel == nil {
+			continue
+		}
+		st, ok := a.clPool.State(a.clusterForPanel(panel).key)
+		panel.SetClusterState(st, ok)
+	}
+}
+
+// clusterPath strips the "/contexts/<name>" or "/kubeconfigs/<file>/<name>"
+// prefix from a breadcrumb so that location checks work the same for every
+// cluster a panel browses.
+func clusterPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/contexts/")
+	segments := 1
+	if !ok {
+		rest, ok = strings.CutPrefix(path, "/kubeconfigs/")
+		segments = 2
+	}
+	if !ok {
+		return path
+	}
+	parts := strings.SplitN(rest, "/", segments+1)
+	if len(parts) < segments {
+		return path
+	}
+	if len(parts) == segments {
+		return "/"
+	}
+	return "/" + parts[segments]
+}
+
 func (a *App) panelByIndex(idx int) *Panel {
 	if idx == 1 {
 		return a.rightPanel
@@ -285,17 +577,247 @@ func (a *App) aggregatedKubeConfig(current string) clientcmdapi.Config {
 	}
 }
 
+// kubeconfigFiles lists the discovered kubeconfig files for the /kubeconfigs folder.
+func (a *App) kubeconfigFiles() []models.KubeconfigFile {
+	if a.kubeMgr == nil {
+		return nil
+	}
+	var files []models.KubeconfigFile
+	for _, kc := range a.kubeMgr.GetKubeconfigs() {
+		if kc == nil || kc.Config == nil {
+			continue
+		}
+		files = append(files, models.KubeconfigFile{Path: kc.Path, Config: *kc.Config})
+	}
+	return files
+}
+
 func (a *App) makeDeps(cl *kccluster.Cluster, cfg *appconfig.Config, current string) models.Deps {
 	if cfg == nil {
 		cfg = a.cfg
 	}
+	if a.kubeSource == nil {
+		a.kubeSource = models.NewKubeconfigSource(a.aggregatedKubeConfig(""), a.kubeconfigFiles())
+	}
 	return models.Deps{
-		Cl:         cl,
-		Ctx:        a.ctx,
-		CtxName:    current,
-		KubeConfig: a.aggregatedKubeConfig(current),
-		AppConfig:  cfg,
+		Cl:               cl,
+		Ctx:              a.ctx,
+		CtxName:          current,
+		KubeConfig:       a.aggregatedKubeConfig(current),
+		Kubeconfigs:      a.kubeconfigFiles(),
+		AppConfig:        cfg,
+		KubeconfigSource: a.kubeSource,
+		PortForwards:     a.portForwards,
+	}
+}
+
+// reloadKubeconfigs rediscovers the kubeconfig files after they changed on
+// disk, publishes the new contexts to the folders and evicts the pooled
+// clusters of contexts that no longer exist. Panels browsing those go back to
+// /contexts; the root folders follow a renamed or deleted startup context.
+// Clusters of contexts whose cluster or user changed are restarted.
+func (a *App) reloadKubeconfigs() {
+	if a.kubeMgr == nil {
+		return
+	}
+	log := ctrllog.FromContext(a.ctx).WithName("kubeconfig")
+	before := a.kubeContextKeys()
+	beforeFiles := a.kubeMgr.GetKubeconfigs()
+	if err := a.kubeMgr.DiscoverKubeconfigs(); err != nil {
+		log.Error(err, "failed to reload kubeconfigs")
+		if a.toastLogger != nil {
+			a.enqueueCmd(a.toastLogger.Errorf("Kubeconfig reload failed: %v", err))
+		}
+		return
+	}
+	after := a.kubeContextKeys()
+	log.Info("kubeconfigs reloaded", "count", len(a.kubeMgr.GetKubeconfigs()), "contexts", len(after))
+
+	renamed := renamedContexts(before, after)
+	if a.kubeSource != nil {
+		a.kubeSource.Set(a.aggregatedKubeConfig(""), a.kubeconfigFiles())
+	}
+	// Context pointers are rebuilt on every discovery.
+	if a.currentCtx != nil && a.currentCtx.Kubeconfig != nil {
+		key := kccluster.Key{KubeconfigPath: a.currentCtx.Kubeconfig.Path, ContextName: a.currentCtx.Name}
+		if ctx, ok := after[key]; ok {
+			a.currentCtx = ctx
+		} else {
+			a.moveStartupContext(key, renamed[key])
+		}
 	}
+	for key := range before {
+		if _, ok := after[key]; ok {
+			continue
+		}
+		if a.clPool != nil && a.clPool.Remove(key) {
+			log.Info("evicted cluster of removed context", "key", key)
+			if _, ok := renamed[key]; !ok && a.toastLogger != nil {
+				a.enqueueCmd(a.toastLogger.Errorf("Context %s was removed from %s", key.ContextName, key.KubeconfigPath))
+			}
+		}
+		// Panels browsing the stopped cluster go back to the context list.
+		for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+			if nav := a.navigatorForPanel(panel); panel != nil && nav != nil {
+				if cf, ok := nav.Current().(models.ClusterFolder); ok && cf.Cluster() != nil && cf.Cluster().Key() == key {
+					a.reenterPanel(panel, "/contexts")
+				}
+			}
+		}
+	}
+	for _, key := range changedContexts(before, after, beforeFiles, a.kubeMgr.GetKubeconfigs()) {
+		a.restartChangedContext(key)
+	}
+}
+
+// restartChangedContext replaces the pooled cluster of a context whose cluster
+// or user changed on disk, and rebinds the panels browsing it. Clusters
+// nobody browses are only evicted and start again when entered.
+func (a *App) restartChangedContext(key kccluster.Key) {
+	if a.clPool == nil || !a.clPool.Remove(key) {
+		return
+	}
+	ctrllog.FromContext(a.ctx).WithName("kubeconfig").Info("evicted cluster of changed context", "key", key)
+	inUse := a.cl != nil && a.cl.Key() == key
+	for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+		if nav := a.navigatorForPanel(panel); panel != nil && nav != nil {
+			if cf, ok := nav.Current().(models.ClusterFolder); ok && cf.Cluster() != nil && cf.Cluster().Key() == key {
+				inUse = true
+			}
+		}
+	}
+	if !inUse {
+		return
+	}
+	if _, err := a.clPool.Get(a.ctx, key); err != nil {
+		if a.toastLogger != nil {
+			a.enqueueCmd(a.toastLogger.Errorf("Context %s: %v", key.ContextName, err))
+		}
+		return
+	}
+	a.rebindReplacedCluster(key)
+}
+
+// contextStanzas are the cluster and user a context resolves to.
+type contextStanzas struct {
+	cluster *clientcmdapi.Cluster
+	user    *clientcmdapi.AuthInfo
+}
+
+// resolveContextStanzas looks up the cluster and user of ctx in its own file
+// first and then in the other kubeconfigs, like the pool loads them.
+func resolveContextStanzas(ctx *kubeconfig.Context, files []*kubeconfig.Kubeconfig) contextStanzas {
+	var s contextStanzas
+	for _, kc := range append([]*kubeconfig.Kubeconfig{ctx.Kubeconfig}, files...) {
+		if kc == nil || kc.Config == nil {
+			continue
+		}
+		if s.cluster == nil {
+			s.cluster = kc.Config.Clusters[ctx.Cluster]
+		}
+		if s.user == nil {
+			s.user = kc.Config.AuthInfos[ctx.User]
+		}
+	}
+	return s
+}
+
+// changedContexts returns the contexts present before and after a reload
+// whose cluster or user stanza changed.
+func changedContexts(before, after map[kccluster.Key]*kubeconfig.Context, beforeFiles, afterFiles []*kubeconfig.Kubeconfig) []kccluster.Key {
+	var changed []kccluster.Key
+	for key, old := range before {
+		ctx, ok := after[key]
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(resolveContextStanzas(old, beforeFiles), resolveContextStanzas(ctx, afterFiles)) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+// moveStartupContext rebinds the startup context after it disappeared from
+// the kubeconfigs. The root folders of both panels are bound to its cluster,
+// so they follow it to its new name after a rename, or to the context
+// selectCurrentContext picks after a delete. Without any context left, the
+// roots have no cluster. The old cluster is evicted afterwards.
+func (a *App) moveStartupContext(key kccluster.Key, renamedTo *kubeconfig.Context) {
+	next := renamedTo
+	if next == nil {
+		next = a.selectCurrentContext()
+	}
+	a.currentCtx = next
+	a.cl = nil
+	if next != nil && a.clPool != nil {
+		nextKey := kccluster.Key{KubeconfigPath: next.Kubeconfig.Path, ContextName: next.Name}
+		if cl, err := a.clPool.Get(a.ctx, nextKey); err != nil {
+			ctrllog.FromContext(a.ctx).Error(err, "failed to start cluster", "key", nextKey)
+			if a.toastLogger != nil {
+				a.enqueueCmd(a.toastLogger.Errorf("Context %s: %v", next.Name, err))
+			}
+		} else {
+			a.cl = cl
+		}
+	}
+	for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+		nav := a.navigatorForPanel(panel)
+		if panel == nil || nav == nil {
+			continue
+		}
+		path := a.navigatorPath(nav)
+		if cf, ok := nav.Current().(models.ClusterFolder); ok && cf.Cluster() != nil && cf.Cluster().Key() == key {
+			// The renamed cluster is the new root; a deleted one is gone.
+			if renamedTo != nil {
+				path = clusterPath(path)
+			} else {
+				path = "/contexts"
+			}
+		}
+		a.reenterPanel(panel, path)
+	}
+}
+
+// renamedContexts pairs contexts that disappeared with the one context that
+// appeared in the same file with the same cluster, user and namespace.
+func renamedContexts(before, after map[kccluster.Key]*kubeconfig.Context) map[kccluster.Key]*kubeconfig.Context {
+	renamed := make(map[kccluster.Key]*kubeconfig.Context)
+	for key, old := range before {
+		if _, ok := after[key]; ok {
+			continue
+		}
+		var match *kubeconfig.Context
+		for newKey, ctx := range after {
+			if _, ok := before[newKey]; ok || newKey.KubeconfigPath != key.KubeconfigPath {
+				continue
+			}
+			if ctx.Cluster != old.Cluster || ctx.User != old.User || ctx.Namespace != old.Namespace {
+				continue
+			}
+			if match != nil {
+				match = nil
+				break
+			}
+			match = ctx
+		}
+		if match != nil {
+			renamed[key] = match
+		}
+	}
+	return renamed
+}
+
+// kubeContextKeys indexes the discovered contexts by pool key.
+func (a *App) kubeContextKeys() map[kccluster.Key]*kubeconfig.Context {
+	keys := make(map[kccluster.Key]*kubeconfig.Context)
+	for _, ctx := range a.kubeMgr.GetContexts() {
+		if ctx == nil || ctx.Kubeconfig == nil {
+			continue
+		}
+		keys[kccluster.Key{KubeconfigPath: ctx.Kubeconfig.Path, ContextName: ctx.Name}] = ctx
+	}
+	return keys
 }
 
 func (a *App) navigatorPath(nav *navui.Navigator) string {
@@ -307,13 +829,21 @@ func (a *App) navigatorPath(nav *navui.Navigator) string {
 	return nav.Path(ctx)
 }
 
-func (a *App) makeEnterContextFunc(cfg *appconfig.Config) func(string, []string) (models.Folder, error) {
-	return func(name string, basePath []string) (models.Folder, error) {
+func (a *App) makeEnterContextFunc(cfg *appconfig.Config) models.EnterContextFunc {
+	return func(kubeconfigPath, name string, basePath []string) (models.Folder, error) {
 		if a.kubeMgr == nil {
 			return nil, fmt.Errorf("no kubeconfig manager available")
 		}
-		target := a.kubeMgr.GetContextByName(name)
+		var target *kubeconfig.Context
+		if kubeconfigPath == "" {
+			target = a.kubeMgr.GetContextByName(name)
+		} else {
+			target = a.contextForKey(kccluster.Key{KubeconfigPath: kubeconfigPath, ContextName: name})
+		}
 		if target == nil {
+			if kubeconfigPath != "" {
+				return nil, fmt.Errorf("context %q not found in %s", name, kubeconfigPath)
+			}
 			return nil, fmt.Errorf("context %q not found", name)
 		}
 		if target.Kubeconfig == nil {
@@ -329,13 +859,20 @@ func (a *App) makeEnterContextFunc(cfg *appconfig.Config) func(string, []string)
 	}
 }
 
-// Update handles messages and updates the application state
+// Update handles messages and updates the application state. Commands queued
+// with enqueueCmd, before or while handling msg, run along with its result.
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
-	if len(a.pendingCmds) > 0 {
-		cmds = append(cmds, a.pendingCmds...)
-		a.pendingCmds = nil
+	model, cmd := a.update(msg)
+	if len(a.pendingCmds) == 0 {
+		return model, cmd
 	}
+	cmds := append([]tea.Cmd{cmd}, a.pendingCmds...)
+	a.pendingCmds = nil
+	return model, tea.Batch(cmds...)
+}
+
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
 
 	// Always adapt size
 	switch msg := msg.(type) {
@@ -378,6 +915,24 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.terminal = model.(*Terminal)
 			cmds = append(cmds, cmd)
 		}
+		if a.exec != nil {
+			a.exec.SetSize(msg.Width, msg.Height)
+		}
+	}
+
+	// An exec session takes all input until it ends; other messages keep
+	// flowing to the app, e.g. the output of the terminal shell.
+	if m, ok := msg.(execExitedMsg); ok {
+		return a, tea.Batch(append(cmds, a.closeExec(m))...)
+	}
+	if a.exec != nil {
+		switch msg.(type) {
+		case tea.KeyMsg:
+			return a, tea.Batch(append(cmds, a.exec.Update(msg))...)
+		case tea.MouseMsg:
+			return a, tea.Batch(cmds...)
+		}
+		cmds = append(cmds, a.exec.Update(msg))
 	}
 
 	// Handle modals first
@@ -590,7 +1145,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		if msg.Confirm {
-			if a.cl == nil {
+			if a.clusterForAction(a.panelByIndex(a.namespaceCreatePanel)).cl == nil {
 				if a.toastLogger != nil {
 					a.enqueueCmd(a.toastLogger.Errorf("Cluster not ready for namespace creation"))
 				}
@@ -602,15 +1157,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case namespaceCreatedMsg:
 		if msg.err != nil {
 			if a.toastLogger != nil {
-				a.enqueueCmd(a.toastLogger.Errorf("Create namespace %s failed: %v", msg.name, msg.err))
+				a.enqueueCmd(a.toastLogger.Errorf("Create namespace %s in %s failed: %v", msg.name, msg.context, msg.err))
 			} else {
 				a.enqueueCmd(a.ShowToast(fmt.Sprintf("Create namespace failed: %v", msg.err), 5*time.Second))
 			}
 			a.namespaceCreatePanel = -1
 			return a, nil
 		}
-		a.enqueueCmd(a.ShowToast(fmt.Sprintf("Namespace %s created", msg.name), 3*time.Second))
+		a.enqueueCmd(a.ShowToast(fmt.Sprintf("Namespace %s created in %s", msg.name, msg.context), 3*time.Second))
 		if a.namespaceCreatePanel == 0 || a.namespaceCreatePanel == 1 {
+			created := a.clusterForPanel(a.panelByIndex(a.namespaceCreatePanel))
 			a.refreshPanelAfterEdit(a.namespaceCreatePanel)
 			other := 1 - a.namespaceCreatePanel
 			if other == 0 || other == 1 {
@@ -620,7 +1176,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					otherPanel = a.rightPanel
 				}
-				if otherPanel != nil && otherPanel.GetCurrentPath() == "/namespaces" {
+				if otherPanel != nil && clusterPath(otherPanel.GetCurrentPath()) == "/namespaces" && a.clusterForPanel(otherPanel).key == created.key {
 					a.refreshPanelAfterEdit(other)
 				}
 			}
@@ -630,6 +1186,39 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		a.namespaceCreatePanel = -1
 		return a, nil
+	case PromptResultMsg:
+		if msg.Close {
+			a.modalManager.Hide()
+		}
+		onConfirm := a.pendingPrompt
+		a.pendingPrompt = nil
+		if msg.Confirm && onConfirm != nil {
+			return a, onConfirm(msg.Value)
+		}
+		return a, nil
+	case portForwardStartedMsg:
+		if msg.err != nil {
+			if a.toastLogger != nil {
+				a.enqueueCmd(a.toastLogger.Errorf("Port-forward %s failed: %v", msg.target, msg.err))
+			} else {
+				a.enqueueCmd(a.ShowToast(fmt.Sprintf("Port-forward failed: %v", msg.err), 5*time.Second))
+			}
+			return a, nil
+		}
+		a.enqueueCmd(a.ShowToast(fmt.Sprintf("Forwarding localhost:%d → %s", msg.info.LocalPort, msg.target), 3*time.Second))
+		return a, nil
+	case kubeconfigEditedMsg:
+		if msg.err != nil {
+			if a.toastLogger != nil {
+				a.enqueueCmd(a.toastLogger.Errorf("%s failed: %v", msg.action, msg.err))
+			} else {
+				a.enqueueCmd(a.ShowToast(fmt.Sprintf("%s failed: %v", msg.action, msg.err), 5*time.Second))
+			}
+			return a, nil
+		}
+		a.enqueueCmd(a.ShowToast(msg.action+" done", 3*time.Second))
+		a.reloadKubeconfigs()
+		return a, nil
 	case DeleteConfirmMsg:
 		if msg.Close {
 			a.modalManager.Hide()
@@ -662,20 +1251,40 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case resourceDeletedMsg:
 		if msg.err != nil {
 			if a.toastLogger != nil {
-				a.enqueueCmd(a.toastLogger.Errorf("Delete %s failed: %v", kubectlResourceRef(msg.target.gvr, msg.target.name), msg.err))
+				a.enqueueCmd(a.toastLogger.Errorf("Delete %s in %s failed: %v", kubectlResourceRef(msg.target.gvr, msg.target.name), msg.target.cluster.name(), msg.err))
 			} else {
 				a.enqueueCmd(a.ShowToast(fmt.Sprintf("Delete failed: %v", msg.err), 5*time.Second))
 			}
 			return a, nil
 		}
-		a.enqueueCmd(a.ShowToast(fmt.Sprintf("Deleted %s", kubectlResourceRef(msg.target.gvr, msg.target.name)), 3*time.Second))
+		a.enqueueCmd(a.ShowToast(fmt.Sprintf("Deleted %s in %s", kubectlResourceRef(msg.target.gvr, msg.target.name), msg.target.cluster.name()), 3*time.Second))
 		a.refreshPanelAfterEdit(msg.target.panelIdx)
 		return a, nil
 	case EscTimeoutMsg:
 		// Escape sequence timed out
 		a.escPressed = false
 		return a, nil
+	case kubeconfigChangedMsg:
+		a.reloadKubeconfigs()
+		return a, nil
+	case panelNavigatedMsg:
+		a.applyPanelNavigation(msg)
+		return a, nil
+	case clusterStateMsg:
+		a.rebindReplacedCluster(msg.key)
+		a.syncPanelClusterStates()
+		if msg.state.Phase == kccluster.PhaseFailed && a.toastLogger != nil {
+			for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+				if a.clusterForPanel(panel).key == msg.key {
+					a.enqueueCmd(a.toastLogger.Errorf("Cluster %s unreachable: %v", msg.key.ContextName, msg.state.Err))
+					break
+				}
+			}
+		}
+		return a, nil
 	case FolderTickMsg:
+		a.touchPanelClusters()
+		a.syncPanelClusterStates()
 		// Refresh only when current folders report dirty to avoid unnecessary redraws.
 		if a.leftNav != nil && a.leftPanel != nil {
 			if d, ok := a.leftNav.Current().(interface{ IsDirty() bool }); ok && d.IsDirty() {
@@ -701,6 +1310,19 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, a.showPanelModeModal(0)
 		case "alt+f2", "ctrl+2":
 			return a, a.showPanelModeModal(1)
+		case "alt+enter", "shift+enter":
+			if !a.showTerminal {
+				if cmd := a.openExecForPanel(a.activePanelRef()); cmd != nil {
+					return a, cmd
+				}
+			}
+		case "alt+p":
+			if !a.showTerminal {
+				// The prompt shows up without a command.
+				if cmd := a.startPortForwardForPanel(a.activePanelRef()); cmd != nil || a.modalManager.IsModalVisible() {
+					return a, cmd
+				}
+			}
 		case "ctrl+o":
 			// Toggle terminal mode
 			a.showTerminal = !a.showTerminal
@@ -1003,6 +1625,9 @@ func (a *App) shouldRouteToPanel(key string) bool {
 
 // View renders the application
 func (a *App) View() (string, *tea.Cursor) {
+	if a.exec != nil {
+		return a.exec.View()
+	}
 	// In fullscreen terminal mode, only show terminal
 	if a.showTerminal {
 		terminalView, terminalCursor := a.renderTerminalView()
@@ -1058,8 +1683,8 @@ func (a *App) renderMainView() (string, *tea.Cursor) {
 	frameHeight := panelHeight - footerHeight
 
 	// Create frames with proper dimensions, passing focus state
-	leftFramed := a.createFrameWithOverlayTitle(leftContentView, a.leftPanel.GetCurrentPath(), panelWidth, frameHeight, a.activePanel == 0)
-	rightFramed := a.createFrameWithOverlayTitle(rightContentView, a.rightPanel.GetCurrentPath(), panelWidth, frameHeight, a.activePanel == 1)
+	leftFramed := a.createFrameWithOverlayTitle(leftContentView, a.leftPanel.GetCurrentPath()+a.leftPanel.ClusterStateLabel(), panelWidth, frameHeight, a.activePanel == 0)
+	rightFramed := a.createFrameWithOverlayTitle(rightContentView, a.rightPanel.GetCurrentPath()+a.rightPanel.ClusterStateLabel(), panelWidth, frameHeight, a.activePanel == 1)
 
 	// Create framed footers with T-junction connection
 	leftFooter := a.createFramedFooter(leftFooterView, panelWidth)
@@ -1237,9 +1862,9 @@ func (a *App) renderFunctionKeys() string {
 			renderKey("F1", "Help", caps.HasHelp),
 			renderKey("F2", "Options", caps.HasOptions),
 			renderKey("F3", "View", caps.CanView),
-			renderKey("F4", "Edit", caps.CanEdit),
-			renderKey("F5", "Copy", false),
-			renderKey("F6", "Rename/Move", false),
+			renderKey("F4", editKeyLabel(caps), caps.CanEdit),
+			renderKey("F5", "Copy", caps.CanCopy),
+			renderKey("F6", "Rename/Move", caps.CanRename),
 			renderKey("F7", "Namespace", caps.CanCreateNS),
 			renderKey("F8", "Delete", caps.CanDelete),
 			renderKey("F9", "Menu", caps.HasContextMenu),
@@ -1256,6 +1881,14 @@ func (a *App) renderFunctionKeys() string {
 	return fullWidthStyle.Render(joined + " " + titleRendered)
 }
 
+// editKeyLabel names F4, which switches the current-context on contexts.
+func editKeyLabel(caps PanelCapabilities) string {
+	if caps.ContextActions {
+		return "Use"
+	}
+	return "Edit"
+}
+
 // handleFunctionKeyClick maps an x coordinate on the function key bar to a key action.
 func (a *App) handleFunctionKeyClick(x int) tea.Cmd {
 	if a.toastActive {
@@ -1312,9 +1945,9 @@ func (a *App) handleFunctionKeyClick(x int) tea.Cmd {
 			{makeLbl("F1", "Help", caps.HasHelp), caps.HasHelp, invoke(PanelActionHelp)},
 			{makeLbl("F2", "Options", caps.HasOptions), caps.HasOptions, invoke(PanelActionOptions)},
 			{makeLbl("F3", "View", caps.CanView), caps.CanView, invoke(PanelActionView)},
-			{makeLbl("F4", "Edit", caps.CanEdit), caps.CanEdit, invoke(PanelActionEdit)},
-			{makeLbl("F5", "Copy", false), false, a.copyItem},
-			{makeLbl("F6", "Rename/Move", false), false, a.renameMoveItem},
+			{makeLbl("F4", editKeyLabel(caps), caps.CanEdit), caps.CanEdit, invoke(PanelActionEdit)},
+			{makeLbl("F5", "Copy", caps.CanCopy), caps.CanCopy, invoke(PanelActionCopy)},
+			{makeLbl("F6", "Rename/Move", caps.CanRename), caps.CanRename, invoke(PanelActionRename)},
 			{makeLbl("F7", "Namespace", caps.CanCreateNS), caps.CanCreateNS, invoke(PanelActionCreateNamespace)},
 			{makeLbl("F8", "Delete", caps.CanDelete), caps.CanDelete, invoke(PanelActionDelete)},
 			{FunctionKeyStyle.Render("F9") + FunctionKeyDescriptionStyle.Render("Menu"), caps.HasContextMenu, invoke(PanelActionMenu)},
@@ -1395,6 +2028,13 @@ func (a *App) setupModals() {
 	a.modalManager.Register("delete_confirm", delModal)
 	a.deleteConfirm = delModel
 
+	// Single-line input prompt (configured on open)
+	promptModel := NewPromptModel()
+	promptModal := NewModal("", promptModel)
+	promptModal.SetCloseOnSingleEsc(true)
+	a.modalManager.Register("prompt", promptModal)
+	a.prompt = promptModel
+
 	for idx := 0; idx < 2; idx++ {
 		modeModel := NewPanelModeModel(idx, []PanelViewMode{PanelModeList}, PanelModeList)
 		modeModal := NewModal("Panel Mode", modeModel)
@@ -1404,7 +2044,6 @@ func (a *App) setupModals() {
 }
 
 func (a *App) setupPanelInputs() {
-	envSupplier := func() PanelEnvironment { return a.panelEnvironment() }
 	registerModes := func(panel *Panel, name string) {
 		if panel == nil {
 			return
@@ -1420,12 +2059,12 @@ func (a *App) setupPanelInputs() {
 		})
 	}
 	if a.leftPanel != nil {
-		a.leftPanel.SetEnvironmentSupplier(envSupplier)
+		a.leftPanel.SetEnvironmentSupplier(func() PanelEnvironment { return a.panelEnvironment(a.leftPanel) })
 		a.leftPanel.SetActionHandlers(a.panelActionHandlers())
 		registerModes(a.leftPanel, "Left panel")
 	}
 	if a.rightPanel != nil {
-		a.rightPanel.SetEnvironmentSupplier(envSupplier)
+		a.rightPanel.SetEnvironmentSupplier(func() PanelEnvironment { return a.panelEnvironment(a.rightPanel) })
 		a.rightPanel.SetActionHandlers(a.panelActionHandlers())
 		registerModes(a.rightPanel, "Right panel")
 	}
@@ -1443,29 +2082,59 @@ func (a *App) panelActionHandlers() PanelActionHandlers {
 			return a.openViewerForPanel(p)
 		},
 		PanelActionEdit: func(p *Panel) tea.Cmd {
+			if target, ok := a.selectedKubeconfigContext(p); ok {
+				return a.useKubeconfigContext(target)
+			}
 			return a.editSelectionForPanel(p)
 		},
 		PanelActionCreateNamespace: func(p *Panel) tea.Cmd {
+			if target, ok := a.selectedKubeconfigContext(p); ok {
+				return a.setKubeconfigContextNamespace(target)
+			}
 			return a.createNamespaceForPanel(p)
 		},
 		PanelActionDelete: func(p *Panel) tea.Cmd {
+			if target, ok := a.selectedKubeconfigContext(p); ok {
+				return a.deleteKubeconfigContext(p, target)
+			}
+			if id, ok := a.selectedPortForward(p); ok {
+				return a.stopPortForward(id)
+			}
 			return a.deleteResourceForPanel(p)
 		},
+		PanelActionCopy: func(p *Panel) tea.Cmd {
+			if target, ok := a.selectedKubeconfigContext(p); ok {
+				return a.copyKubeconfigContext(p, target)
+			}
+			return nil
+		},
+		PanelActionRename: func(p *Panel) tea.Cmd {
+			if target, ok := a.selectedKubeconfigContext(p); ok {
+				return a.renameKubeconfigContext(target)
+			}
+			return nil
+		},
 		PanelActionMenu: func(p *Panel) tea.Cmd {
 			return a.showContextMenuForPanel(p)
 		},
 	}
 }
 
-func (a *App) panelEnvironment() PanelEnvironment {
+func (a *App) panelEnvironment(panel *Panel) PanelEnvironment {
 	env := PanelEnvironment{}
-	if a.currentCtx != nil {
+	if a.snapshot != nil {
+		// Snapshots are read-only.
+		return env
+	}
+	env.AllowEditKubeconfigs = a.kubeMgr != nil
+	pc := a.clusterForPanel(panel)
+	if pc.ctx != nil {
 		env.AllowCreateNamespaces = true
-		if a.currentCtx.Kubeconfig != nil {
+		if pc.ctx.Kubeconfig != nil {
 			env.AllowEditObjects = true
 		}
 	}
-	if a.cl != nil {
+	if pc.cl != nil {
 		env.AllowDeleteObjects = true
 	}
 	return env
@@ -1678,19 +2347,19 @@ func (a *App) createNamespace() tea.Cmd {
 }
 
 func (a *App) createNamespaceForPanel(panel *Panel) tea.Cmd {
-	if a.currentCtx == nil {
-		if a.toastLogger != nil {
-			a.enqueueCmd(a.toastLogger.Errorf("No active context for namespace creation"))
-		}
-		return nil
-	}
 	if panel == nil {
 		panel = a.activePanelRef()
 	}
 	if panel == nil {
 		return nil
 	}
-	if panel.GetCurrentPath() != "/namespaces" {
+	if a.clusterForPanel(panel).ctx == nil {
+		if a.toastLogger != nil {
+			a.enqueueCmd(a.toastLogger.Errorf("No active context for namespace creation"))
+		}
+		return nil
+	}
+	if clusterPath(panel.GetCurrentPath()) != "/namespaces" {
 		if a.toastLogger != nil {
 			a.enqueueCmd(a.toastLogger.Errorf("Create namespace is only available at /namespaces"))
 		}
@@ -1762,6 +2431,7 @@ func (a *App) deleteResourceForPanel(panel *Panel) tea.Cmd {
 	namespace := obj.Namespace()
 	target := deleteTarget{
 		panelIdx:  panelIdx,
+		cluster:   a.clusterForAction(panel),
 		gvr:       gvr,
 		namespace: namespace,
 		name:      name,
@@ -1816,28 +2486,35 @@ func (a *App) createNamespaceWithName(name string) tea.Cmd {
 	if name == "" {
 		return nil
 	}
+	pc := a.clusterForAction(a.panelByIndex(a.namespaceCreatePanel))
 	return a.withBusy("Create namespace", 300*time.Millisecond, func() tea.Msg {
-		if a.cl == nil {
-			return namespaceCreatedMsg{name: name, err: fmt.Errorf("cluster not ready")}
+		if pc.cl == nil {
+			return namespaceCreatedMsg{name: name, context: pc.name(), err: fmt.Errorf("cluster not ready")}
 		}
 		ctx, cancel := context.WithTimeout(a.ctx, requestTimeout)
 		defer cancel()
 		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
-		if err := a.cl.GetClient().Create(ctx, ns); err != nil {
-			return namespaceCreatedMsg{name: name, err: err}
+		if err := pc.cl.GetClient().Create(ctx, ns); err != nil {
+			return namespaceCreatedMsg{name: name, context: pc.name(), err: err}
 		}
-		return namespaceCreatedMsg{name: name}
+		return namespaceCreatedMsg{name: name, context: pc.name()}
 	})
 }
 
 func (a *App) performDelete(target deleteTarget) tea.Cmd {
+	if t := target.kubeContext; t != nil {
+		return a.editKubeconfig(fmt.Sprintf("Delete context %s", t.name), func() error {
+			return kubeconfig.DeleteContext(t.path, t.name)
+		})
+	}
 	return a.withBusy("Delete", 300*time.Millisecond, func() tea.Msg {
-		if a.cl == nil {
+		cl := target.cluster.cl
+		if cl == nil {
 			return resourceDeletedMsg{target: target, err: fmt.Errorf("cluster not ready")}
 		}
 		ctx, cancel := context.WithTimeout(a.ctx, requestTimeout)
 		defer cancel()
-		kind, err := a.cl.RESTMapper().KindFor(target.gvr)
+		kind, err := cl.RESTMapper().KindFor(target.gvr)
 		if err != nil {
 			return resourceDeletedMsg{target: target, err: err}
 		}
@@ -1847,7 +2524,7 @@ func (a *App) performDelete(target deleteTarget) tea.Cmd {
 		if target.namespace != "" {
 			obj.SetNamespace(target.namespace)
 		}
-		if err := a.cl.GetClient().Delete(ctx, obj); err != nil {
+		if err := cl.GetClient().Delete(ctx, obj); err != nil {
 			return resourceDeletedMsg{target: target, err: err}
 		}
 		return resourceDeletedMsg{target: target}
@@ -1886,6 +2563,9 @@ func (a *App) openViewerForPanel(panel *Panel) tea.Cmd {
 	if _, isBack := item.(models.Back); isBack {
 		return nil
 	}
+	if logs, ok := item.(models.LogStreamable); ok && logs.LogStream() != nil {
+		return a.openLogViewer(item, logs)
+	}
 	viewable, ok := item.(models.Viewable)
 	if !ok {
 		type vc interface {
@@ -1940,6 +2620,81 @@ func (a *App) openViewerForPanel(panel *Panel) tea.Cmd {
 	return nil
 }
 
+// openLogViewer opens the streaming log viewer for item. The stream stops
+// when the modal closes.
+func (a *App) openLogViewer(item models.Item, logs models.LogStreamable) tea.Cmd {
+	if prev := a.modalManager.modals["log_viewer"]; prev != nil {
+		if lv, ok := prev.content.(*LogViewer); ok {
+			lv.Stop()
+		}
+	}
+	viewer := NewLogViewer(a.ctx, logs.LogTitle(), logs.LogStream(), func() tea.Cmd {
+		a.modalManager.Hide()
+		return nil
+	})
+	modalTitle := "/" + logs.LogTitle()
+	if pa, ok := item.(interface{ Path() []string }); ok {
+		if segs := pa.Path(); len(segs) > 0 {
+			modalTitle = "/" + strings.Join(segs, "/")
+		}
+	}
+	modal := NewModal(modalTitle, viewer)
+	modal.SetDimensions(a.width, a.height)
+	modal.SetCloseOnSingleEsc(false)
+	modal.SetOnClose(func() tea.Cmd {
+		viewer.Stop()
+		return nil
+	})
+	a.modalManager.Register("log_viewer", modal)
+	a.modalManager.Show("log_viewer")
+	return viewer.Start()
+}
+
+// openExecForPanel starts an exec session into the selected container,
+// running the configured command or the default shells.
+func (a *App) openExecForPanel(panel *Panel) tea.Cmd {
+	if panel == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	item, ok := panel.SelectedNavItem(ctx)
+	cancel()
+	if !ok || item == nil {
+		return nil
+	}
+	execable, ok := item.(models.Execable)
+	if !ok || execable.Exec() == nil {
+		return nil
+	}
+	session, cmd, err := NewExecSession(a.ctx, execable.ExecTitle(), execable.Exec(), a.cfg.Exec.Command, a.width, a.height)
+	if err != nil {
+		if a.toastLogger != nil {
+			return a.toastLogger.Errorf("Exec failed: %v", err)
+		}
+		return nil
+	}
+	if a.exec != nil {
+		a.exec.Close()
+	}
+	a.exec = session
+	return cmd
+}
+
+// closeExec returns to the panels once the exec session m reports ended.
+func (a *App) closeExec(m execExitedMsg) tea.Cmd {
+	m.session.Close()
+	if m.session != a.exec {
+		return nil
+	}
+	a.exec = nil
+	// The relay's exit may have been logged to the screen.
+	cmds := []tea.Cmd{tea.ClearScreen}
+	if m.err != nil && !isExitStatus(m.err) && a.toastLogger != nil {
+		cmds = append(cmds, a.toastLogger.Errorf("Exec %s failed: %v", m.session.title, m.err))
+	}
+	return tea.Batch(cmds...)
+}
+
 // showThemeSelector opens the theme selector modal and wires selection to save
 // config and re-highlight the currently open YAML viewer.
 func (a *App) showThemeSelector(v *TextViewer) tea.Cmd {
@@ -2030,7 +2785,8 @@ func (a *App) editSelectionForPanel(panel *Panel) tea.Cmd {
 }
 
 func (a *App) runKubectlEdit(panelIdx int, panelPath string, obj models.ObjectItem) tea.Cmd {
-	if a.currentCtx == nil || a.currentCtx.Kubeconfig == nil {
+	kctx := a.clusterForPanel(a.panelByIndex(panelIdx)).ctx
+	if kctx == nil || kctx.Kubeconfig == nil {
 		if a.toastLogger != nil {
 			return a.toastLogger.Errorf("kubectl edit: no active kubeconfig")
 		}
@@ -2039,10 +2795,10 @@ func (a *App) runKubectlEdit(panelIdx int, panelPath string, obj models.ObjectIt
 
 	log := ctrllog.FromContext(a.ctx).WithName("kubectl_edit")
 
-	kubeconfigPath := a.currentCtx.Kubeconfig.Path
+	kubeconfigPath := kctx.Kubeconfig.Path
 	tempConfigPath := ""
 	if kubeconfigPath == "" {
-		if a.currentCtx.Kubeconfig.Config == nil {
+		if kctx.Kubeconfig.Config == nil {
 			if a.toastLogger != nil {
 				return a.toastLogger.Errorf("kubectl edit: kubeconfig has no backing file")
 			}
@@ -2062,7 +2818,7 @@ func (a *App) runKubectlEdit(panelIdx int, panelPath string, obj models.ObjectIt
 			}
 			return a.ShowToast(fmt.Sprintf("kubectl edit failed: %v", err), 5*time.Second)
 		}
-		if err := clientcmd.WriteToFile(*a.currentCtx.Kubeconfig.Config, tmpFile.Name()); err != nil {
+		if err := clientcmd.WriteToFile(*kctx.Kubeconfig.Config, tmpFile.Name()); err != nil {
 			_ = os.Remove(tmpFile.Name())
 			if a.toastLogger != nil {
 				return a.toastLogger.Errorf("kubectl edit: write kubeconfig: %v", err)
@@ -2073,7 +2829,7 @@ func (a *App) runKubectlEdit(panelIdx int, panelPath string, obj models.ObjectIt
 		tempConfigPath = tmpFile.Name()
 	}
 
-	contextName := a.currentCtx.Name
+	contextName := kctx.Name
 	if contextName == "" {
 		if a.toastLogger != nil {
 			return a.toastLogger.Errorf("kubectl edit: context name empty")
@@ -2083,12 +2839,12 @@ func (a *App) runKubectlEdit(panelIdx int, panelPath string, obj models.ObjectIt
 
 	namespace := strings.TrimSpace(obj.Namespace())
 	if namespace == "" && panelPath != "" {
-		if ns, _, _, ok := parseNamespacedObjectPath(panelPath, obj.Name()); ok && ns != "" {
+		if ns, _, _, ok := parseNamespacedObjectPath(clusterPath(panelPath), obj.Name()); ok && ns != "" {
 			namespace = ns
 		}
 	}
 	if namespace == "" {
-		namespace = strings.TrimSpace(a.currentCtx.Namespace)
+		namespace = strings.TrimSpace(kctx.Namespace)
 	}
 
 	resourceRef := kubectlResourceRef(obj.GVR(), obj.Name())
@@ -2184,13 +2940,21 @@ func parseNamespacedObjectPath(path, currentName string) (ns, res, name string,
 }
 
 func (a *App) copyItem() tea.Cmd {
-	// TODO: Implement copy functionality (F5)
-	return nil
+	return a.invokeActivePanelAction(PanelActionCopy)
 }
 
 func (a *App) renameMoveItem() tea.Cmd {
-	// TODO: Implement rename/move functionality (F6)
-	return nil
+	return a.invokeActivePanelAction(PanelActionRename)
+}
+
+func (a *App) invokeActivePanelAction(action PanelAction) tea.Cmd {
+	panel := a.activePanelRef()
+	if panel == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	defer cancel()
+	return panel.invokeActionIfAllowed(ctx, action)
 }
 
 // createFrameWithOverlayTitle creates a frame with title overlaid on the top border
@@ -2333,10 +3097,12 @@ func (a *App) createFramedFooter(content string, width int) string {
 }
 
 // Run starts the application
-func Run(ctx context.Context) error {
+func Run(ctx context.Context, opts Options) error {
 	ctx = ctrllog.IntoContext(ctx, ctrllog.Log.WithName("startup"))
 	log := ctrllog.FromContext(ctx)
 	app := NewApp()
+	app.snapshotDir = opts.Snapshot
+	app.kubeconfigPath = opts.Kubeconfig
 
 	// Initialize data model (best-effort; UI can still run without it)
 	log.Info("initializing data")
@@ -2344,6 +3110,9 @@ func Run(ctx context.Context) error {
 		log.Error(err, "initialization warning")
 		fmt.Printf("Data init warning: %v\n", err)
 	}
+	if opts.Path != "" && app.leftNav != nil {
+		app.enqueueCmd(app.navigatePanel(app.leftPanel, app.newPanelNavigator(app.leftPanel), opts.Path, "", true))
+	}
 	log.Info("initialization complete, launching UI")
 
 	// Create program with proper options
@@ -2354,6 +3123,21 @@ func Run(ctx context.Context) error {
 		tea.WithoutSignalHandler(), // Handle signals ourselves
 	)
 
+	// Forward pool state changes so footers, breadcrumbs and toasts follow connectivity.
+	if app.clPool != nil {
+		unsubscribe := app.clPool.Subscribe(func(k kccluster.Key, st kccluster.State) {
+			p.Send(clusterStateMsg{key: k, state: st})
+		})
+		defer unsubscribe()
+	}
+
+	// Pick up kubeconfig edits (new, changed and removed contexts) without a restart.
+	if app.kubeMgr != nil && app.snapshot == nil {
+		if err := app.kubeMgr.Watch(app.ctx, func() { p.Send(kubeconfigChangedMsg{}) }); err != nil {
+			log.Error(err, "failed to watch kubeconfigs")
+		}
+	}
+
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -2374,6 +3158,12 @@ func Run(ctx context.Context) error {
 		if app.clPool != nil {
 			app.clPool.Stop()
 		}
+		if app.snapshot != nil {
+			_ = app.snapshot.Close()
+		}
+		if app.portForwards != nil {
+			app.portForwards.StopAll()
+		}
 		app.cancel()
 	}()
 
@@ -2390,14 +3180,26 @@ func (a *App) initData(ctx context.Context) error {
 	log := ctrllog.FromContext(ctx).WithName("init")
 	// Kubeconfig manager and discovery
 	a.kubeMgr = kubeconfig.NewManager()
-	log.Info("discovering kubeconfigs")
-	if err := a.kubeMgr.DiscoverKubeconfigs(); err != nil {
-		// Log and show toast
-		if a.toastLogger != nil {
-			a.enqueueCmd(a.toastLogger.Errorf("Kubeconfig discovery failed: %v", err))
+	if a.snapshotDir != "" {
+		log.Info("starting snapshot", "dir", a.snapshotDir)
+		if err := a.startSnapshot(); err != nil {
+			if a.toastLogger != nil {
+				a.enqueueCmd(a.toastLogger.Errorf("Snapshot failed: %v", err))
+			}
+			log.Error(err, "failed to start snapshot")
+			return fmt.Errorf("snapshot %s: %w", a.snapshotDir, err)
+		}
+	} else {
+		log.Info("discovering kubeconfigs")
+		a.kubeMgr.SetExplicitPath(a.kubeconfigPath)
+		if err := a.kubeMgr.DiscoverKubeconfigs(); err != nil {
+			// Log and show toast
+			if a.toastLogger != nil {
+				a.enqueueCmd(a.toastLogger.Errorf("Kubeconfig discovery failed: %v", err))
+			}
+			log.Error(err, "failed to discover kubeconfigs")
+			return fmt.Errorf("discover kubeconfigs: %w", err)
 		}
-		log.Error(err, "failed to discover kubeconfigs")
-		return fmt.Errorf("discover kubeconfigs: %w", err)
 	}
 	log.Info("kubeconfigs discovered", "count", len(a.kubeMgr.GetKubeconfigs()), "contexts", len(a.kubeMgr.GetContexts()))
 	// Select current context (prefer env KUBECONFIG first path)
@@ -2414,6 +3216,10 @@ func (a *App) initData(ctx context.Context) error {
 	// Prepare app context and cluster pool; cluster will be started via pool.Get
 	a.cancel()
 	a.ctx, a.cancel = context.WithCancel(ctx)
+	if a.snapshot == nil {
+		// Snapshots have no pods to forward to.
+		a.portForwards = portforward.NewManager(a.ctx)
+	}
 	a.clPool = kccluster.NewPool(2 * time.Minute)
 	log.Info("starting cluster pool")
 	a.clPool.Start()
@@ -2431,6 +3237,13 @@ func (a *App) initData(ctx context.Context) error {
 		log.Info("resource infos fetched", "count", len(infos))
 		a.leftPanel.SetResourceCatalog(infos)
 		a.rightPanel.SetResourceCatalog(infos)
+		if degraded := a.cl.DegradedGroups(); len(degraded) > 0 && a.toastLogger != nil {
+			names := make([]string, 0, len(degraded))
+			for _, g := range degraded {
+				names = append(names, g.GroupVersion.String())
+			}
+			a.enqueueCmd(a.toastLogger.Errorf("Discovery failed for %s; retrying on refresh", strings.Join(names, ", ")))
+		}
 	} else {
 		if a.toastLogger != nil {
 			a.enqueueCmd(a.toastLogger.Errorf("Discovery resources failed: %v", err))
@@ -2478,71 +3291,19 @@ func (a *App) initData(ctx context.Context) error {
 
 // Legacy builder helpers removed (replaced by self-sufficient folders).
 
-// goToNamespace programmatically navigates to /namespaces/<ns> and updates panels.
-// If ns is empty, uses "default". If the namespace does not exist, navigates to root.
+// goToNamespace shows the root folder in both panels and then navigates them
+// to /namespaces/<ns> in the background. If ns is empty, uses "default". If
+// the namespace does not exist, the panels stay at the root.
 func (a *App) goToNamespace(ns string) {
 	if ns == "" {
 		ns = "default"
 	}
-	leftCfg := a.ensurePanelConfig(a.leftPanel)
-	rightCfg := a.ensurePanelConfig(a.rightPanel)
-	a.syncPanelConfig(a.leftPanel)
-	a.syncPanelConfig(a.rightPanel)
-	currentName := ""
-	if a.currentCtx != nil {
-		currentName = a.currentCtx.Name
-	}
-	depsLeft := a.makeDeps(a.cl, leftCfg, currentName)
-	depsRight := a.makeDeps(a.cl, rightCfg, currentName)
-	enterLeft := a.makeEnterContextFunc(leftCfg)
-	enterRight := a.makeEnterContextFunc(rightCfg)
-	rootLeft := models.NewRootFolder(depsLeft, enterLeft)
-	rootRight := models.NewRootFolder(depsRight, enterRight)
-	a.leftNav = navui.NewNavigator(rootLeft)
-	a.rightNav = navui.NewNavigator(rootRight)
+	a.leftNav = a.newPanelNavigator(a.leftPanel)
+	a.rightNav = a.newPanelNavigator(a.rightPanel)
 	if a.namespaceExists(ns) {
-		// Left panel: remember selection when entering
-		a.leftNav.SetSelectionID("namespaces")
-		leftNSPath := append(append([]string{}, rootLeft.Path()...), "namespaces")
-		leftNS := models.NewClusterObjectsFolder(depsLeft, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}, leftNSPath)
-		a.enqueueCmd(a.withBusy("Namespaces", 800*time.Millisecond, func() tea.Msg {
-			ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
-			defer cancel()
-			_ = leftNS.Len(ctx)
-			return nil
-		}))
-		a.leftNav.Push(leftNS)
-		a.leftNav.SetSelectionID(ns)
-		leftGroupsPath := append(append([]string{}, leftNSPath...), ns)
-		leftGroups := models.NewNamespacedResourcesFolder(depsLeft, ns, leftGroupsPath)
-		a.enqueueCmd(a.withBusy("Resources", 800*time.Millisecond, func() tea.Msg {
-			ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
-			defer cancel()
-			_ = leftGroups.Len(ctx)
-			return nil
-		}))
-		a.leftNav.Push(leftGroups)
-		// Right panel: same
-		a.rightNav.SetSelectionID("namespaces")
-		rightNSPath := append(append([]string{}, rootRight.Path()...), "namespaces")
-		rightNS := models.NewClusterObjectsFolder(depsRight, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}, rightNSPath)
-		a.enqueueCmd(a.withBusy("Namespaces", 800*time.Millisecond, func() tea.Msg {
-			ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
-			defer cancel()
-			_ = rightNS.Len(ctx)
-			return nil
-		}))
-		a.rightNav.Push(rightNS)
-		a.rightNav.SetSelectionID(ns)
-		rightGroupsPath := append(append([]string{}, rightNSPath...), ns)
-		rightGroups := models.NewNamespacedResourcesFolder(depsRight, ns, rightGroupsPath)
-		a.enqueueCmd(a.withBusy("Resources", 800*time.Millisecond, func() tea.Msg {
-			ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
-			defer cancel()
-			_ = rightGroups.Len(ctx)
-			return nil
-		}))
-		a.rightNav.Push(rightGroups)
+		for _, panel := range []*Panel{a.leftPanel, a.rightPanel} {
+			a.enqueueCmd(a.navigatePanel(panel, a.newPanelNavigator(panel), "/namespaces/"+ns, "", false))
+		}
 	}
 	curL := a.leftNav.Current()
 	hasBackL := a.leftNav.HasBack()
@@ -2593,6 +3354,8 @@ func (a *App) handleFolderNav(back bool, selID string, next models.Folder) {
 	var panelSet func(context.Context, models.Folder, bool)
 	var panelSelectByID func(context.Context, string)
 	var panelReset func(context.Context)
+	// User navigation supersedes a pending background navigation.
+	a.navSeq[a.activePanel]++
 	if a.activePanel == 0 {
 		cfg := a.ensurePanelConfig(a.leftPanel)
 		a.syncPanelConfig(a.leftPanel)
@@ -2689,6 +3452,21 @@ func (a *App) namespaceExists(ns string) bool {
 
 //
 
+// startSnapshot serves the snapshot directory locally and registers its
+// kubeconfig as the only one, so the snapshot context becomes current.
+func (a *App) startSnapshot() error {
+	srv, err := snapshot.Start(a.snapshotDir)
+	if err != nil {
+		return err
+	}
+	if err := a.kubeMgr.LoadKubeconfig(srv.KubeconfigPath()); err != nil {
+		_ = srv.Close()
+		return err
+	}
+	a.snapshot = srv
+	return nil
+}
+
 // selectCurrentContext prefers $KUBECONFIG current-context, else any current-context, else first discovered.
 func (a *App) selectCurrentContext() *kubeconfig.Context {
 	if env := os.Getenv("KUBECONFIG"); env != "" {
@@ -2727,12 +3505,17 @@ func sameFilepath(a, b string) bool {
 	return ap == bp
 }
 
+// GetObject reads an object from the cluster of the active panel.
 func (a *App) GetObject(gvk schema.GroupVersionKind, namespace, name string) (map[string]interface{}, error) {
-	gvr, err := a.cl.GVKToGVR(gvk)
+	cl := a.clusterForAction(a.activePanelRef()).cl
+	if cl == nil {
+		return nil, fmt.Errorf("cluster not ready")
+	}
+	gvr, err := cl.GVKToGVR(gvk)
 	if err != nil {
 		return nil, err
 	}
-	obj, err := a.cl.GetByGVR(a.ctx, gvr, namespace, name)
+	obj, err := cl.GetByGVR(a.ctx, gvr, namespace, name)
 	if err != nil {
 		return nil, err
 	}
@@ -2740,5 +3523,7 @@ func (a *App) GetObject(gvk schema.GroupVersionKind, namespace, name string) (ma
 	return obj.Object, nil
 }
 
-// RESTMapper exposes the app's RESTMapper to viewers for resource→GVK resolution.
-func (a *App) RESTMapper() metamapper.RESTMapper { return a.cl.RESTMapper() }
+// RESTMapper exposes the active panel's RESTMapper to viewers for resource→GVK resolution.
+func (a *App) RESTMapper() metamapper.RESTMapper {
+	return a.clusterForPanel(a.activePanelRef()).cl.RESTMapper()
+}
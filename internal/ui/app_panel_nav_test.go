@@ -3,6 +3,7 @@ package ui
 import (
 	"testing"
 
+	kccluster "github.com/sttts/kc/internal/cluster"
 	"github.com/sttts/kc/internal/models"
 	modeltesting "github.com/sttts/kc/internal/models/testing"
 	nav "github.com/sttts/kc/internal/navigation"
@@ -53,3 +54,72 @@ func TestIndependentPanelNavigation(t *testing.T) {
 		t.Fatalf("left panel path changed to %q", got)
 	}
 }
+
+func TestPanelsResolveTheirOwnCluster(t *testing.T) {
+	a := NewApp()
+	startup, staging, prod := &kccluster.Cluster{}, &kccluster.Cluster{}, &kccluster.Cluster{}
+	a.cl = startup
+	a.leftNav = nav.NewNavigator(models.NewBaseFolder(models.Deps{Cl: staging}, nil, []string{"contexts", "staging"}))
+	a.rightNav = nav.NewNavigator(models.NewBaseFolder(models.Deps{Cl: prod}, nil, []string{"contexts", "prod"}))
+
+	if got := a.clusterForPanel(a.leftPanel).cl; got != staging {
+		t.Fatalf("left panel resolved to %p, want staging %p", got, staging)
+	}
+	if got := a.clusterForPanel(a.rightPanel).cl; got != prod {
+		t.Fatalf("right panel resolved to %p, want prod %p", got, prod)
+	}
+
+	a.rightNav = nav.NewNavigator(mkFolder("plain"))
+	if got := a.clusterForPanel(a.rightPanel).cl; got != startup {
+		t.Fatalf("expected fallback to the startup cluster, got %p", got)
+	}
+}
+
+func TestClusterPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"/namespaces":                                "/namespaces",
+		"/contexts/prod/namespaces":                  "/namespaces",
+		"/contexts/prod/namespaces/a/pods":           "/namespaces/a/pods",
+		"/contexts/prod":                             "/",
+		"/contexts":                                  "/contexts",
+		"/kubeconfigs/config/prod/namespaces/a/pods": "/namespaces/a/pods",
+		"/kubeconfigs/config/prod":                   "/",
+		"/kubeconfigs/config":                        "/kubeconfigs/config",
+	} {
+		if got := clusterPath(path); got != want {
+			t.Errorf("clusterPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestPanelNavigationDroppedAfterUserNavigation(t *testing.T) {
+	a := NewApp()
+	root := mkFolder("Root")
+	a.leftNav = nav.NewNavigator(root)
+	a.leftPanel.UseFolder(true)
+	a.leftPanel.SetFolder(t.Context(), root, false)
+	a.leftPanel.SetCurrentPath(a.navigatorPath(a.leftNav))
+
+	walked := nav.NewNavigator(mkFolder("Root"))
+	walked.Push(mkFolder("Walked"))
+	if cmd := a.navigatePanel(a.leftPanel, walked, "/Walked", "", false); cmd == nil {
+		t.Fatal("expected a navigation command")
+	}
+	stale := panelNavigatedMsg{panelIdx: 0, seq: a.navSeq[0], nav: walked, path: "/Walked"}
+
+	a.activePanel = 0
+	a.handleFolderNav(false, "", mkFolder("User"))
+	a.applyPanelNavigation(stale)
+	if got := a.leftPanel.GetCurrentPath(); got != "/User" {
+		t.Fatalf("left panel path = %q, want /User", got)
+	}
+
+	a.navSeq[0]++
+	a.applyPanelNavigation(panelNavigatedMsg{panelIdx: 0, seq: a.navSeq[0], nav: walked, path: "/Walked"})
+	if got := a.leftPanel.GetCurrentPath(); got != "/Walked" {
+		t.Fatalf("left panel path = %q, want /Walked", got)
+	}
+	if a.leftNav != walked {
+		t.Fatal("expected the panel to switch to the walked navigator")
+	}
+}
@@ -3,6 +3,10 @@ package ui
 import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"testing"
+
+	kccluster "github.com/sttts/kc/internal/cluster"
+	"github.com/sttts/kc/pkg/kubeconfig"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestNewApp(t *testing.T) {
@@ -96,3 +100,60 @@ func TestTerminalResizeViaWindowSize(t *testing.T) {
 		t.Errorf("Expected height to be 50, got %d", term.height)
 	}
 }
+
+func TestRenamedContexts(t *testing.T) {
+	file := &kubeconfig.Kubeconfig{Path: "/home/me/.kube/config"}
+	other := &kubeconfig.Kubeconfig{Path: "/home/me/.kube/other"}
+	index := func(ctxs ...*kubeconfig.Context) map[kccluster.Key]*kubeconfig.Context {
+		keys := make(map[kccluster.Key]*kubeconfig.Context)
+		for _, ctx := range ctxs {
+			keys[kccluster.Key{KubeconfigPath: ctx.Kubeconfig.Path, ContextName: ctx.Name}] = ctx
+		}
+		return keys
+	}
+	dev := &kubeconfig.Context{Name: "dev", Cluster: "dev", User: "me", Kubeconfig: file}
+	prod := &kubeconfig.Context{Name: "prod", Cluster: "prod", User: "me", Kubeconfig: file}
+	before := index(dev, prod)
+
+	staging := &kubeconfig.Context{Name: "staging", Cluster: "dev", User: "me", Kubeconfig: file}
+	renamed := renamedContexts(before, index(staging, prod))
+	if got := renamed[kccluster.Key{KubeconfigPath: file.Path, ContextName: "dev"}]; got != staging || len(renamed) != 1 {
+		t.Fatalf("expected dev renamed to staging, got %v", renamed)
+	}
+
+	// Deleted, moved to another file, or ambiguous: no rename.
+	moved := &kubeconfig.Context{Name: "staging", Cluster: "dev", User: "me", Kubeconfig: other}
+	twin := &kubeconfig.Context{Name: "qa", Cluster: "dev", User: "me", Kubeconfig: file}
+	for _, after := range []map[kccluster.Key]*kubeconfig.Context{index(prod), index(moved, prod), index(staging, twin, prod)} {
+		if renamed := renamedContexts(before, after); len(renamed) != 0 {
+			t.Fatalf("unexpected renames %v", renamed)
+		}
+	}
+}
+
+func TestChangedContexts(t *testing.T) {
+	load := func(server, token string) []*kubeconfig.Kubeconfig {
+		contexts := &kubeconfig.Kubeconfig{Path: "/home/me/.kube/config", Config: &clientcmdapi.Config{
+			Clusters: map[string]*clientcmdapi.Cluster{"dev": {Server: server}},
+		}}
+		users := &kubeconfig.Kubeconfig{Path: "/home/me/.kube/users", Config: &clientcmdapi.Config{
+			AuthInfos: map[string]*clientcmdapi.AuthInfo{"me": {Token: token}},
+		}}
+		return []*kubeconfig.Kubeconfig{contexts, users}
+	}
+	index := func(files []*kubeconfig.Kubeconfig) map[kccluster.Key]*kubeconfig.Context {
+		dev := &kubeconfig.Context{Name: "dev", Cluster: "dev", User: "me", Kubeconfig: files[0]}
+		return map[kccluster.Key]*kubeconfig.Context{{KubeconfigPath: files[0].Path, ContextName: "dev"}: dev}
+	}
+
+	before := load("https://dev", "a")
+	if changed := changedContexts(index(before), index(load("https://dev", "a")), before, load("https://dev", "a")); len(changed) != 0 {
+		t.Fatalf("unexpected changes %v", changed)
+	}
+	// The user lives in another file.
+	for _, after := range [][]*kubeconfig.Kubeconfig{load("https://prod", "a"), load("https://dev", "b")} {
+		if changed := changedContexts(index(before), index(after), before, after); len(changed) != 1 || changed[0].ContextName != "dev" {
+			t.Fatalf("expected dev to change, got %v", changed)
+		}
+	}
+}
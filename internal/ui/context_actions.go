@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	models "github.com/sttts/kc/internal/models"
+	"github.com/sttts/kc/pkg/kubeconfig"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// contextTarget identifies a context within one kubeconfig file.
+type contextTarget struct {
+	path string
+	name string
+}
+
+// kubeconfigEditedMsg reports the outcome of a kubeconfig context action.
+type kubeconfigEditedMsg struct {
+	action string
+	err    error
+}
+
+// selectedKubeconfigContext returns the context selected in panel, if any.
+func (a *App) selectedKubeconfigContext(panel *Panel) (contextTarget, bool) {
+	if panel == nil {
+		return contextTarget{}, false
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	item, ok := panel.SelectedNavItem(ctx)
+	cancel()
+	if !ok || item == nil {
+		return contextTarget{}, false
+	}
+	kctx, ok := item.(models.KubeconfigContext)
+	if !ok || kctx.KubeconfigPath() == "" {
+		return contextTarget{}, false
+	}
+	return contextTarget{path: kctx.KubeconfigPath(), name: kctx.ContextName()}, true
+}
+
+// editKubeconfig runs edit off the UI goroutine. The kubeconfigs are
+// reloaded once it reports back, without waiting for the file watcher.
+func (a *App) editKubeconfig(action string, edit func() error) tea.Cmd {
+	return func() tea.Msg {
+		return kubeconfigEditedMsg{action: action, err: edit()}
+	}
+}
+
+func (a *App) useKubeconfigContext(t contextTarget) tea.Cmd {
+	return a.editKubeconfig(fmt.Sprintf("Use context %s in %s", t.name, t.path), func() error {
+		return kubeconfig.UseContext(t.path, t.name)
+	})
+}
+
+func (a *App) renameKubeconfigContext(t contextTarget) tea.Cmd {
+	validate := func(name string) string {
+		if name == "" {
+			return "Name is required"
+		}
+		if name == t.name {
+			return "Name is unchanged"
+		}
+		return ""
+	}
+	return a.showPrompt("Rename Context", fmt.Sprintf("Rename context %s to", t.name), "Rename", t.name, validate, func(name string) tea.Cmd {
+		return a.editKubeconfig(fmt.Sprintf("Rename context %s to %s", t.name, name), func() error {
+			return kubeconfig.RenameContext(t.path, t.name, name)
+		})
+	})
+}
+
+func (a *App) setKubeconfigContextNamespace(t contextTarget) tea.Cmd {
+	current := ""
+	if kc := a.kubeMgr.GetKubeconfigByPath(t.path); kc != nil && kc.Config != nil {
+		if c := kc.Config.Contexts[t.name]; c != nil {
+			current = c.Namespace
+		}
+	}
+	validate := func(ns string) string {
+		if ns == "" {
+			return ""
+		}
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return errs[0]
+		}
+		return ""
+	}
+	return a.showPrompt("Context Namespace", fmt.Sprintf("Default namespace of %s (empty to unset)", t.name), "Set", current, validate, func(ns string) tea.Cmd {
+		return a.editKubeconfig(fmt.Sprintf("Set namespace of context %s", t.name), func() error {
+			return kubeconfig.SetContextNamespace(t.path, t.name, ns)
+		})
+	})
+}
+
+// copyKubeconfigContext asks for the destination file, suggesting the
+// kubeconfig the other panel is browsing.
+func (a *App) copyKubeconfigContext(panel *Panel, t contextTarget) tea.Cmd {
+	dest := ""
+	if idx := a.panelIndex(panel); idx >= 0 {
+		if nav := a.navigatorForPanel(a.panelByIndex(1 - idx)); nav != nil {
+			if f, ok := nav.Current().(interface{ KubeconfigPath() string }); ok && f.KubeconfigPath() != t.path {
+				dest = f.KubeconfigPath()
+			}
+		}
+	}
+	validate := func(path string) string {
+		if path == "" {
+			return "Path is required"
+		}
+		if filepath.Clean(expandHome(path)) == filepath.Clean(t.path) {
+			return "Choose a different kubeconfig file"
+		}
+		return ""
+	}
+	return a.showPrompt("Copy Context", fmt.Sprintf("Copy context %s to kubeconfig", t.name), "Copy", dest, validate, func(path string) tea.Cmd {
+		path = expandHome(path)
+		return a.editKubeconfig(fmt.Sprintf("Copy context %s to %s", t.name, path), func() error {
+			return kubeconfig.CopyContext(t.path, t.name, path)
+		})
+	})
+}
+
+func (a *App) deleteKubeconfigContext(panel *Panel, t contextTarget) tea.Cmd {
+	modal := a.modalManager.modals["delete_confirm"]
+	if modal == nil || a.deleteConfirm == nil {
+		return nil
+	}
+	a.pendingDelete = &deleteTarget{panelIdx: a.panelIndex(panel), name: t.name, kubeContext: &t}
+	a.deleteConfirm.Configure(fmt.Sprintf("context %s from %s", t.name, t.path), "")
+	a.deleteConfirm.SetDimensions(max(20, a.width-4), max(5, a.height-6))
+	modal.SetContent(a.deleteConfirm)
+	modal.SetDimensions(a.width, a.height)
+	bg, _ := a.renderMainView()
+	modal.SetWindowed(max(40, min(max(50, a.width/2), a.width-4)), max(6, min(8, a.height-4)), bg)
+	modal.SetOnClose(func() tea.Cmd {
+		a.pendingDelete = nil
+		return nil
+	})
+	a.modalManager.Show("delete_confirm")
+	return nil
+}
+
+// showPrompt opens the single-line input dialog and calls onConfirm with the
+// entered value.
+func (a *App) showPrompt(title, header, confirm, value string, validate func(string) string, onConfirm func(string) tea.Cmd) tea.Cmd {
+	modal := a.modalManager.modals["prompt"]
+	if modal == nil || a.prompt == nil {
+		return nil
+	}
+	a.prompt.Configure(header, confirm, value, validate)
+	a.prompt.SetDimensions(max(20, a.width-4), max(5, a.height-6))
+	a.pendingPrompt = onConfirm
+	modal.title = title
+	modal.SetContent(a.prompt)
+	modal.SetDimensions(a.width, a.height)
+	bg, _ := a.renderMainView()
+	modal.SetWindowed(max(40, min(max(60, a.width/2), a.width-4)), max(8, min(10, a.height-4)), bg)
+	modal.SetOnClose(func() tea.Cmd {
+		a.pendingPrompt = nil
+		return nil
+	})
+	a.modalManager.Show("prompt")
+	return nil
+}
+
+// expandHome expands a leading ~/ to the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
@@ -16,6 +16,13 @@ var (
 )
 
 func TestMain(m *testing.M) {
+    // Exec session tests start the test binary as their relay.
+    if ExecRelayRequested() {
+        if err := RunExecRelay(); err != nil {
+            os.Exit(1)
+        }
+        os.Exit(0)
+    }
     kctesting.SetupLogging()
     testEnv = &envtest.Environment{}
     cfg, err := testEnv.Start()
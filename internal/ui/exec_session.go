@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/term"
+	"github.com/sttts/kc/internal/models"
+	bubbleterm "github.com/taigrr/bubbleterm"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// execRelayEnv marks kc started as the relay of an exec session.
+const execRelayEnv = "KC_EXEC_RELAY"
+
+// execExitedMsg reports the end of an exec session.
+type execExitedMsg struct {
+	session *ExecSession
+	err     error
+}
+
+// ExecSession shows an interactive command running in a container in the
+// terminal area. bubbleterm only drives processes attached to its PTY, so the
+// remote streams are relayed by kc itself started in relay mode on that PTY:
+// it switches the PTY into raw mode and copies between it and two pipes.
+type ExecSession struct {
+	title         string
+	width, height int
+	term          *bubbleterm.Model
+	cancel        context.CancelFunc
+	sizes         *terminalSizeQueue
+	// out carries the remote output to the relay, in the keystrokes back.
+	out, in   *os.File
+	done      chan error
+	exited    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewExecSession starts command through exec, or the default shells when
+// command is empty, in a terminal of the given size including the status line.
+func NewExecSession(ctx context.Context, title string, fn models.ExecFunc, command []string, width, height int) (*ExecSession, tea.Cmd, error) {
+	width, height = max(1, width), max(2, height)
+	t, err := bubbleterm.New(width, height-1)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &ExecSession{
+		title:  title,
+		width:  width,
+		height: height,
+		term:   t,
+		cancel: cancel,
+		sizes:  newTerminalSizeQueue(ctx),
+		done:   make(chan error, 1),
+		exited: make(chan struct{}),
+	}
+	emu := t.GetEmulator()
+	emu.SetOnExit(func(string) { close(s.exited) })
+	if err := s.startRelay(); err != nil {
+		s.Close()
+		return nil, nil, err
+	}
+	s.sizes.set(width, height-1)
+
+	commands := models.DefaultExecShells
+	if len(command) > 0 {
+		commands = [][]string{command}
+	}
+	go func() {
+		err := models.ExecShell(ctx, fn, commands, s.in, s.out, s.sizes)
+		// The relay exits once the output ends.
+		_ = s.out.Close()
+		s.done <- err
+	}()
+	return s, tea.Batch(t.Init(), s.wait()), nil
+}
+
+func (s *ExecSession) startRelay() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return err
+	}
+	s.out, s.in = outW, inR
+	cmd := exec.Command(exe)
+	cmd.Env = append(os.Environ(), execRelayEnv+"=1")
+	cmd.ExtraFiles = []*os.File{outR, inW}
+	err = s.term.GetEmulator().StartCommand(cmd)
+	// The relay holds its own copies of these ends.
+	outR.Close()
+	inW.Close()
+	return err
+}
+
+// wait reports the end of the session once the relay has exited too.
+func (s *ExecSession) wait() tea.Cmd {
+	return func() tea.Msg {
+		err := <-s.done
+		<-s.exited
+		return execExitedMsg{session: s, err: err}
+	}
+}
+
+// Close ends the session and releases the terminal.
+func (s *ExecSession) Close() {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		if s.out != nil {
+			_ = s.out.Close()
+		}
+		if s.in != nil {
+			_ = s.in.Close()
+		}
+		_ = s.term.Close()
+	})
+}
+
+// SetSize resizes the terminal, including the status line, and the remote
+// terminal with it.
+func (s *ExecSession) SetSize(width, height int) {
+	s.width, s.height = max(1, width), max(2, height)
+	_ = s.term.GetEmulator().Resize(s.width, s.height-1)
+	s.sizes.set(s.width, s.height-1)
+}
+
+// Update feeds keys and terminal output to the session.
+func (s *ExecSession) Update(msg tea.Msg) tea.Cmd {
+	if k, ok := msg.(tea.KeyMsg); ok && k.String() == "ctrl+]" {
+		s.Close()
+		return nil
+	}
+	model, cmd := s.term.Update(msg)
+	s.term = model.(*bubbleterm.Model)
+	return cmd
+}
+
+// View renders the terminal above a status line naming the session.
+func (s *ExecSession) View() (string, *tea.Cursor) {
+	view, cursor := s.term.View()
+	lines := strings.Split(view, "\n")
+	rows := s.height - 1
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+	key := FunctionKeyStyle.Render("Ctrl+]") + FunctionKeyDescriptionStyle.Render("Close")
+	title := FunctionKeyTitleStyle.Render(s.title)
+	spacing := max(1, s.width-lipgloss.Width(key)-lipgloss.Width(title))
+	status := FunctionKeyBarStyle.Width(s.width).Render(key + strings.Repeat(" ", spacing) + title)
+	if cursor != nil && cursor.Y >= rows {
+		cursor.Y = rows - 1
+	}
+	return strings.Join(append(lines, status), "\n"), cursor
+}
+
+// isExitStatus reports whether err only carries the exit code of the remote
+// command, which is no failure of the session itself.
+func isExitStatus(err error) bool {
+	var exit interface{ ExitStatus() int }
+	return errors.As(err, &exit)
+}
+
+// terminalSizeQueue hands the latest terminal size to the exec stream.
+type terminalSizeQueue struct {
+	ctx context.Context
+	ch  chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue(ctx context.Context) *terminalSizeQueue {
+	return &terminalSizeQueue{ctx: ctx, ch: make(chan remotecommand.TerminalSize, 1)}
+}
+
+// Next blocks until the size changes and returns nil once the session ended.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.ch:
+		return &size
+	case <-q.ctx.Done():
+		return nil
+	}
+}
+
+// set replaces a size not picked up yet.
+func (q *terminalSizeQueue) set(width, height int) {
+	size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+	select {
+	case <-q.ch:
+	default:
+	}
+	select {
+	case q.ch <- size:
+	default:
+	}
+}
+
+// ExecRelayRequested reports whether kc was started as the relay of an exec
+// session.
+func ExecRelayRequested() bool { return os.Getenv(execRelayEnv) == "1" }
+
+// RunExecRelay switches the terminal into raw mode and copies the remote
+// output from fd 3 to it and the keystrokes from it to fd 4 until the output
+// ends.
+func RunExecRelay() error {
+	out := os.NewFile(3, "exec-output")
+	in := os.NewFile(4, "exec-input")
+	if state, err := term.MakeRaw(os.Stdin.Fd()); err == nil {
+		defer term.Restore(os.Stdin.Fd(), state)
+	}
+	go func() { _, _ = io.Copy(in, os.Stdin) }()
+	_, err := io.Copy(os.Stdout, out)
+	return err
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	kctesting "github.com/sttts/kc/internal/testing"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+func TestExecSession(t *testing.T) {
+	type result struct {
+		command []string
+		sizes   []remotecommand.TerminalSize
+		typed   string
+	}
+	results := make(chan result, 1)
+	resized := make(chan struct{})
+	// The fake shell greets, reports the terminal sizes and exits on "exit".
+	shell := func(_ context.Context, command []string, stdin io.Reader, stdout io.Writer, resize remotecommand.TerminalSizeQueue) error {
+		r := result{command: command}
+		r.sizes = append(r.sizes, *resize.Next())
+		if _, err := io.WriteString(stdout, "hello from web\r\n"); err != nil {
+			return err
+		}
+		close(resized)
+		r.sizes = append(r.sizes, *resize.Next())
+		line, err := bufio.NewReader(stdin).ReadString('\r')
+		if err != nil {
+			return err
+		}
+		r.typed = line
+		results <- r
+		return nil
+	}
+
+	s, _, err := NewExecSession(t.Context(), "exec:web/app", shell, nil, 40, 10)
+	if err != nil {
+		t.Skipf("no terminal available: %v", err)
+	}
+	defer s.Close()
+	kctesting.Eventually(t, 5*time.Second, 10*time.Millisecond, func() bool {
+		return strings.Contains(strings.Join(s.term.GetEmulator().GetScreen().Rows, "\n"), "hello from web")
+	})
+
+	<-resized
+	s.SetSize(60, 20)
+	for _, r := range "exit" {
+		if cmd := s.Update(tea.KeyPressMsg{Code: r, Text: string(r)}); cmd != nil {
+			cmd()
+		}
+	}
+	if cmd := s.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		cmd()
+	}
+
+	var r result
+	select {
+	case r = <-results:
+	case <-time.After(5 * time.Second):
+		t.Fatal("keystrokes did not reach the shell")
+	}
+	if len(r.command) != 1 || r.command[0] != "/bin/bash" {
+		t.Errorf("command %v, want the first default shell", r.command)
+	}
+	want := []remotecommand.TerminalSize{{Width: 40, Height: 9}, {Width: 60, Height: 19}}
+	if r.sizes[0] != want[0] || r.sizes[1] != want[1] {
+		t.Errorf("sizes %v, want %v without the status line", r.sizes, want)
+	}
+	if r.typed != "exit\r" {
+		t.Errorf("typed %q, want the raw keystrokes", r.typed)
+	}
+
+	// The session reports its end once the relay exited.
+	done := make(chan tea.Msg, 1)
+	go func() { done <- s.wait()() }()
+	select {
+	case msg := <-done:
+		if m, ok := msg.(execExitedMsg); !ok || m.session != s || m.err != nil {
+			t.Errorf("unexpected end %#v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("session did not end")
+	}
+
+	view, _ := s.View()
+	if lines := strings.Split(view, "\n"); len(lines) != 20 || !strings.Contains(lines[19], "exec:web/app") {
+		t.Errorf("want 19 terminal lines and the status line, got %d lines", len(lines))
+	}
+}
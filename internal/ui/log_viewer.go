@@ -0,0 +1,574 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sttts/kc/internal/models"
+)
+
+const (
+	// logViewerMaxLines bounds the lines kept by the log viewer; older lines
+	// are dropped.
+	logViewerMaxLines = 50000
+	// logViewerBatchLines bounds the lines delivered per update.
+	logViewerBatchLines = 1000
+)
+
+var (
+	logViewerSinceChoices = []time.Duration{0, 5 * time.Minute, time.Hour, 24 * time.Hour}
+	logViewerTailChoices  = []int64{200, 1000, 0}
+)
+
+// logLinesMsg delivers streamed lines to the log viewer. gen identifies the
+// stream so lines of a restarted stream are dropped.
+type logLinesMsg struct {
+	gen   int
+	lines []string
+	err   error
+	done  bool
+}
+
+// logChunk is a line or the final error read by the stream goroutine.
+type logChunk struct {
+	line string
+	err  error
+}
+
+// logFilterField identifies the regex filter being edited in the log viewer.
+type logFilterField int
+
+const (
+	logFilterNone logFilterField = iota
+	logFilterInclude
+	logFilterExclude
+)
+
+// LogViewer streams container logs with follow. Previous logs, timestamps,
+// the since window and the tail size can be toggled and restart the stream;
+// pausing freezes the view while lines keep arriving. Regex include and
+// exclude filters select the shown lines. The stream stops when the viewer is
+// closed.
+type LogViewer struct {
+	title  string
+	stream models.LogStreamFunc
+	parent context.Context
+
+	opts     models.LogOptions
+	sinceIdx int
+	tailIdx  int
+
+	lines   logRing
+	shown   logRing  // lines passing the filters
+	pending []string // received while paused
+	err     error
+	done    bool
+
+	include   *regexp.Regexp
+	exclude   *regexp.Regexp
+	editing   logFilterField
+	input     textInput
+	filterErr error
+
+	width   int
+	height  int
+	offset  int
+	hOffset int
+	follow  bool // keep the view at the end
+	paused  bool
+
+	gen    int
+	cancel context.CancelFunc
+	ch     chan logChunk
+
+	onClose func() tea.Cmd
+}
+
+// NewLogViewer creates a log viewer for stream. Call Start to open the stream;
+// streams are derived from ctx.
+func NewLogViewer(ctx context.Context, title string, stream models.LogStreamFunc, onClose func() tea.Cmd) *LogViewer {
+	v := &LogViewer{title: title, stream: stream, parent: ctx, follow: true, onClose: onClose}
+	v.applyChoices()
+	return v
+}
+
+func (v *LogViewer) applyChoices() {
+	v.opts.Follow = true
+	v.opts.SinceSeconds = int64(logViewerSinceChoices[v.sinceIdx] / time.Second)
+	v.opts.TailLines = logViewerTailChoices[v.tailIdx]
+}
+
+// Start (re)opens the stream with the current options and returns the
+// command delivering its lines.
+func (v *LogViewer) Start() tea.Cmd {
+	v.Stop()
+	v.gen++
+	v.lines, v.shown, v.pending, v.err, v.done = logRing{}, logRing{}, nil, nil, false
+	v.offset, v.follow = 0, true
+	ctx, cancel := context.WithCancel(v.parent)
+	v.cancel = cancel
+	v.ch = make(chan logChunk, logViewerBatchLines)
+	go readLogStream(ctx, v.stream, v.opts, v.ch)
+	return waitForLogLines(v.gen, v.ch)
+}
+
+// Stop cancels the stream, if any.
+func (v *LogViewer) Stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// Options returns the options of the current stream.
+func (v *LogViewer) Options() models.LogOptions { return v.opts }
+
+func readLogStream(ctx context.Context, stream models.LogStreamFunc, opts models.LogOptions, ch chan<- logChunk) {
+	defer close(ch)
+	send := func(c logChunk) bool {
+		select {
+		case ch <- c:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	rc, err := stream(ctx, opts)
+	if err != nil {
+		send(logChunk{err: err})
+		return
+	}
+	defer rc.Close()
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if !send(logChunk{line: scanner.Text()}) {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		send(logChunk{err: err})
+	}
+}
+
+// waitForLogLines blocks for the next line and returns it together with the
+// lines already buffered behind it.
+func waitForLogLines(gen int, ch <-chan logChunk) tea.Cmd {
+	return func() tea.Msg {
+		msg := logLinesMsg{gen: gen}
+		c, ok := <-ch
+		if !ok {
+			msg.done = true
+			return msg
+		}
+		for {
+			if c.err != nil {
+				msg.err = c.err
+			} else {
+				msg.lines = append(msg.lines, c.line)
+			}
+			if len(msg.lines) >= logViewerBatchLines {
+				return msg
+			}
+			select {
+			case c, ok = <-ch:
+				if !ok {
+					msg.done = true
+					return msg
+				}
+			default:
+				return msg
+			}
+		}
+	}
+}
+
+func (v *LogViewer) Init() tea.Cmd { return nil }
+
+func (v *LogViewer) SetDimensions(w, h int) {
+	v.width, v.height = w, h
+	if v.follow {
+		v.scrollToEnd()
+	}
+}
+
+// pageHeight is the number of log lines shown above the status line.
+func (v *LogViewer) pageHeight() int { return max(1, v.height-1) }
+
+func (v *LogViewer) maxOffset() int { return max(0, v.shown.Len()-v.pageHeight()) }
+
+func (v *LogViewer) scrollToEnd() { v.offset = v.maxOffset() }
+
+// matches reports whether line passes the filters. Filters see the line
+// without colors, so they match pod/container prefixes as displayed.
+func (v *LogViewer) matches(line string) bool {
+	if v.include == nil && v.exclude == nil {
+		return true
+	}
+	plain := ansi.Strip(line)
+	if v.include != nil && !v.include.MatchString(plain) {
+		return false
+	}
+	return v.exclude == nil || !v.exclude.MatchString(plain)
+}
+
+// appendLines keeps lines, dropping the oldest ones beyond
+// logViewerMaxLines. Only the new lines are filtered; dropped lines that were
+// shown leave the front of the shown lines.
+func (v *LogViewer) appendLines(lines []string) {
+	dropped := 0
+	for _, ln := range lines {
+		if old, ok := v.lines.push(ln, logViewerMaxLines); ok && v.matches(old) {
+			v.shown.dropFront(1)
+			dropped++
+		}
+		if v.matches(ln) {
+			v.shown.push(ln, logViewerMaxLines)
+		}
+	}
+	if v.follow {
+		v.scrollToEnd()
+	} else {
+		// Keep the view on the same lines.
+		v.offset = max(0, v.offset-dropped)
+	}
+}
+
+// logRing holds lines in a ring buffer so that dropping the oldest lines
+// does not copy the others.
+type logRing struct {
+	buf   []string
+	start int // index of the oldest line
+	n     int
+}
+
+// Len returns the number of lines held.
+func (r *logRing) Len() int { return r.n }
+
+// At returns the i-th oldest line.
+func (r *logRing) At(i int) string { return r.buf[(r.start+i)%len(r.buf)] }
+
+// push appends line. Once limit lines are held, the oldest line is
+// overwritten and returned.
+func (r *logRing) push(line string, limit int) (string, bool) {
+	if r.n == len(r.buf) {
+		if len(r.buf) >= limit {
+			old := r.buf[r.start]
+			r.buf[r.start] = line
+			r.start = (r.start + 1) % len(r.buf)
+			return old, true
+		}
+		grown := make([]string, min(limit, max(64, 2*len(r.buf))))
+		for i := range r.n {
+			grown[i] = r.At(i)
+		}
+		r.buf, r.start = grown, 0
+	}
+	r.buf[(r.start+r.n)%len(r.buf)] = line
+	r.n++
+	return "", false
+}
+
+// dropFront drops the k oldest lines.
+func (r *logRing) dropFront(k int) {
+	k = min(k, r.n)
+	for i := range k {
+		r.buf[(r.start+i)%len(r.buf)] = ""
+	}
+	if k > 0 {
+		r.start = (r.start + k) % len(r.buf)
+		r.n -= k
+	}
+}
+
+// refilter recomputes the shown lines, keeping the view at the end when
+// following and within bounds otherwise.
+func (v *LogViewer) refilter() {
+	v.shown = logRing{}
+	for i := range v.lines.Len() {
+		if ln := v.lines.At(i); v.matches(ln) {
+			v.shown.push(ln, logViewerMaxLines)
+		}
+	}
+	if v.follow {
+		v.scrollToEnd()
+	} else {
+		v.offset = min(v.offset, v.maxOffset())
+	}
+}
+
+// SetFilters sets the include and exclude regular expressions; empty
+// expressions disable the filter.
+func (v *LogViewer) SetFilters(include, exclude string) error {
+	inc, err := compileLogFilter(include)
+	if err != nil {
+		return err
+	}
+	exc, err := compileLogFilter(exclude)
+	if err != nil {
+		return err
+	}
+	v.include, v.exclude = inc, exc
+	v.refilter()
+	return nil
+}
+
+func compileLogFilter(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return regexp.Compile(expr)
+}
+
+func regexString(re *regexp.Regexp) string {
+	if re == nil {
+		return ""
+	}
+	return re.String()
+}
+
+// editFilter starts editing the include or exclude filter in the status line.
+func (v *LogViewer) editFilter(field logFilterField) {
+	v.editing = field
+	v.filterErr = nil
+	if field == logFilterInclude {
+		v.input.setValue(regexString(v.include))
+	} else {
+		v.input.setValue(regexString(v.exclude))
+	}
+}
+
+// updateFilterInput handles keys while a filter is edited: Enter applies,
+// Ctrl+C or Ctrl+G cancels.
+func (v *LogViewer) updateFilterInput(key tea.KeyMsg) {
+	switch key.String() {
+	case "ctrl+c", "ctrl+g":
+		v.editing = logFilterNone
+		return
+	case "ctrl+h":
+		v.input.deleteBackward()
+		return
+	}
+	k := key.Key()
+	if k.Code != tea.KeyEnter {
+		v.input.handleKey(k)
+		return
+	}
+	include, exclude := regexString(v.include), regexString(v.exclude)
+	if v.editing == logFilterInclude {
+		include = v.input.value()
+	} else {
+		exclude = v.input.value()
+	}
+	if err := v.SetFilters(include, exclude); err != nil {
+		v.filterErr = err
+		return
+	}
+	v.editing = logFilterNone
+}
+
+func (v *LogViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case logLinesMsg:
+		if m.gen != v.gen {
+			return v, nil
+		}
+		if v.paused {
+			// Like the kept lines, the buffer keeps only the newest ones.
+			v.pending = append(v.pending, m.lines...)
+			if drop := len(v.pending) - logViewerMaxLines; drop > 0 {
+				v.pending = v.pending[drop:]
+			}
+		} else {
+			v.appendLines(m.lines)
+		}
+		if m.err != nil {
+			v.err = m.err
+		}
+		if m.done {
+			v.done = true
+			return v, nil
+		}
+		return v, waitForLogLines(m.gen, v.ch)
+	case tea.KeyMsg:
+		if v.editing != logFilterNone {
+			v.updateFilterInput(m)
+			return v, nil
+		}
+		switch m.String() {
+		case "up":
+			if v.offset > 0 {
+				v.offset--
+				v.follow = false
+			}
+		case "down":
+			if v.offset < v.maxOffset() {
+				v.offset++
+			}
+			v.follow = v.offset == v.maxOffset()
+		case "left":
+			if v.hOffset > 0 {
+				v.hOffset--
+			}
+		case "right":
+			v.hOffset++
+		case "pgup":
+			v.offset = max(0, v.offset-(v.pageHeight()-1))
+			v.follow = false
+		case "pgdown":
+			v.offset = min(v.maxOffset(), v.offset+(v.pageHeight()-1))
+			v.follow = v.offset == v.maxOffset()
+		case "home":
+			v.offset = 0
+			v.follow = false
+		case "end":
+			v.follow = true
+			v.scrollToEnd()
+		case "ctrl+a":
+			v.hOffset = 0
+		case "ctrl+f":
+			v.follow = !v.follow
+			if v.follow {
+				v.scrollToEnd()
+			}
+		case "f2":
+			v.editFilter(logFilterInclude)
+		case "f3":
+			v.editFilter(logFilterExclude)
+		case "f5":
+			v.opts.Previous = !v.opts.Previous
+			return v, v.Start()
+		case "f6":
+			v.opts.Timestamps = !v.opts.Timestamps
+			return v, v.Start()
+		case "f7":
+			v.sinceIdx = (v.sinceIdx + 1) % len(logViewerSinceChoices)
+			v.applyChoices()
+			return v, v.Start()
+		case "f8":
+			v.tailIdx = (v.tailIdx + 1) % len(logViewerTailChoices)
+			v.applyChoices()
+			return v, v.Start()
+		case "f9", "space":
+			v.paused = !v.paused
+			if !v.paused {
+				pending := v.pending
+				v.pending = nil
+				v.appendLines(pending)
+			}
+		case "f10":
+			v.Stop()
+			if v.onClose != nil {
+				return v, v.onClose()
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *LogViewer) View() string {
+	if v.height <= 0 || v.width <= 0 {
+		return ""
+	}
+	end := min(v.shown.Len(), v.offset+v.pageHeight())
+	rows := make([]string, 0, v.height)
+	for i := min(v.offset, end); i < end; i++ {
+		rows = append(rows, sliceANSIByColumns(v.shown.At(i), v.hOffset, v.width))
+	}
+	for len(rows) < v.pageHeight() {
+		rows = append(rows, "")
+	}
+	if v.editing != logFilterNone {
+		label := "Include: "
+		if v.editing == logFilterExclude {
+			label = "Exclude: "
+		}
+		if v.filterErr != nil {
+			label = fmt.Sprintf("%v; %s", v.filterErr, label)
+		}
+		label = sliceANSIByColumns(label, 0, v.width)
+		rows = append(rows, label+v.input.render(max(1, v.width-runeWidth(ansi.Strip(label)))))
+	} else {
+		rows = append(rows, lipgloss.NewStyle().Faint(true).Render(sliceANSIByColumns(v.status(), 0, v.width)))
+	}
+	return PanelContentStyle.Width(v.width).Height(v.height).Render(strings.Join(rows, "\n"))
+}
+
+// status describes the stream state and options, e.g.
+// "following · tail 200 · since 5m · timestamps".
+func (v *LogViewer) status() string {
+	var parts []string
+	switch {
+	case v.err != nil:
+		parts = append(parts, fmt.Sprintf("error: %v", v.err))
+	case v.done:
+		parts = append(parts, "stream ended")
+	case v.paused:
+		parts = append(parts, fmt.Sprintf("paused (%d new)", len(v.pending)))
+	case v.follow:
+		parts = append(parts, "following")
+	default:
+		parts = append(parts, "scrolled")
+	}
+	if v.opts.Previous {
+		parts = append(parts, "previous")
+	}
+	if v.opts.TailLines > 0 {
+		parts = append(parts, fmt.Sprintf("tail %d", v.opts.TailLines))
+	} else {
+		parts = append(parts, "all lines")
+	}
+	if v.opts.SinceSeconds > 0 {
+		parts = append(parts, "since "+shortDuration(time.Duration(v.opts.SinceSeconds)*time.Second))
+	}
+	if v.opts.Timestamps {
+		parts = append(parts, "timestamps")
+	}
+	if v.include != nil {
+		parts = append(parts, fmt.Sprintf("include /%s/", v.include))
+	}
+	if v.exclude != nil {
+		parts = append(parts, fmt.Sprintf("exclude /%s/", v.exclude))
+	}
+	if v.include != nil || v.exclude != nil {
+		parts = append(parts, fmt.Sprintf("%d/%d lines", v.shown.Len(), v.lines.Len()))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// shortDuration renders whole hours and minutes compactly, e.g. "5m" or "24h".
+func shortDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+// FooterHints implements ModalFooterHints to show the log actions.
+func (v *LogViewer) FooterHints() [][2]string {
+	pause := "Pause"
+	if v.paused {
+		pause = "Resume"
+	}
+	if v.editing != logFilterNone {
+		return [][2]string{{"Enter", "Apply"}, {"Ctrl+G", "Cancel"}}
+	}
+	return [][2]string{
+		{"F2", "Include"},
+		{"F3", "Exclude"},
+		{"F5", "Previous"},
+		{"F6", "Timestamps"},
+		{"F7", "Since"},
+		{"F8", "Tail"},
+		{"F9", pause},
+		{"Ctrl+F", "Follow"},
+		{"F10", "Close"},
+	}
+}
@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sttts/kc/internal/models"
+)
+
+// fakeLogStream is a log stream whose lines are written by the test.
+type fakeLogStream struct {
+	opts models.LogOptions
+	ctx  context.Context
+	w    *io.PipeWriter
+}
+
+func (s *fakeLogStream) write(t *testing.T, lines ...string) {
+	t.Helper()
+	if _, err := io.WriteString(s.w, strings.Join(lines, "\n")+"\n"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeLogStreams returns a stream func handing every opened stream to the
+// returned channel. Streams end when their context is cancelled.
+func fakeLogStreams() (models.LogStreamFunc, <-chan *fakeLogStream) {
+	opened := make(chan *fakeLogStream, 4)
+	return func(ctx context.Context, opts models.LogOptions) (io.ReadCloser, error) {
+		r, w := io.Pipe()
+		go func() {
+			<-ctx.Done()
+			w.CloseWithError(ctx.Err())
+		}()
+		opened <- &fakeLogStream{opts: opts, ctx: ctx, w: w}
+		return r, nil
+	}, opened
+}
+
+func nextStream(t *testing.T, opened <-chan *fakeLogStream) *fakeLogStream {
+	t.Helper()
+	select {
+	case s := <-opened:
+		return s
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream not opened")
+		return nil
+	}
+}
+
+// deliver runs cmd and feeds its message to the viewer, returning the next
+// command.
+func deliver(t *testing.T, v *LogViewer, cmd tea.Cmd) tea.Cmd {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	_, next := v.Update(cmd())
+	return next
+}
+
+// all returns the lines of r, oldest first.
+func (r *logRing) all() []string {
+	lines := make([]string, 0, r.Len())
+	for i := range r.Len() {
+		lines = append(lines, r.At(i))
+	}
+	return lines
+}
+
+func TestLogViewerStreamsAndFollows(t *testing.T) {
+	streams, opened := fakeLogStreams()
+	v := NewLogViewer(t.Context(), "logs:web/app", streams, nil)
+	v.SetDimensions(40, 3)
+	cmd := v.Start()
+
+	s := nextStream(t, opened)
+	if want := (models.LogOptions{Follow: true, TailLines: 200}); s.opts != want {
+		t.Fatalf("options %+v, want %+v", s.opts, want)
+	}
+	s.write(t, "one", "two", "three")
+	cmd = deliver(t, v, cmd)
+	for v.lines.Len() < 3 {
+		cmd = deliver(t, v, cmd)
+	}
+	if v.lines.At(0) != "one" || v.lines.At(2) != "three" {
+		t.Fatalf("unexpected lines %q", v.lines.all())
+	}
+	// Two lines fit above the status line; following keeps the last ones visible.
+	if v.offset != v.lines.Len()-2 {
+		t.Errorf("offset %d, want the end %d", v.offset, v.lines.Len()-2)
+	}
+
+	// Scrolling up stops following; new lines do not move the view.
+	v.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	s.write(t, "four")
+	cmd = deliver(t, v, cmd)
+	if v.offset != 0 || v.follow {
+		t.Errorf("offset %d follow %v, want the view kept at the top", v.offset, v.follow)
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if !v.follow || v.offset != v.lines.Len()-2 {
+		t.Errorf("End should jump to the end and follow")
+	}
+
+	// Pausing buffers new lines until resumed.
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF9})
+	n := v.lines.Len()
+	s.write(t, "five")
+	cmd = deliver(t, v, cmd)
+	if v.lines.Len() != n || len(v.pending) == 0 {
+		t.Errorf("paused viewer took lines: %d lines, %d pending", v.lines.Len(), len(v.pending))
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF9})
+	if v.lines.At(v.lines.Len()-1) != "five" || len(v.pending) != 0 {
+		t.Errorf("resume should append the pending lines, got %q", v.lines.all())
+	}
+
+	// Toggling previous restarts the stream and drops the old one.
+	_, restart := v.Update(tea.KeyPressMsg{Code: tea.KeyF5})
+	if s.ctx.Err() == nil {
+		t.Error("old stream not cancelled")
+	}
+	if _, next := v.Update(cmd()); next != nil || v.lines.Len() != 0 {
+		t.Error("lines of the old stream must be dropped")
+	}
+	s2 := nextStream(t, opened)
+	if !s2.opts.Previous || !s2.opts.Follow {
+		t.Errorf("restart options %+v, want previous and follow", s2.opts)
+	}
+	s2.write(t, "previous")
+	deliver(t, v, restart)
+	if v.lines.Len() != 1 || v.lines.At(0) != "previous" {
+		t.Errorf("unexpected lines %q", v.lines.all())
+	}
+
+	v.Stop()
+	if s2.ctx.Err() == nil {
+		t.Error("Stop should cancel the stream")
+	}
+}
+
+func TestLogViewerPauseKeepsNewestLines(t *testing.T) {
+	v := NewLogViewer(t.Context(), "logs:web/app", nil, nil)
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF9})
+	lines := make([]string, logViewerMaxLines+10)
+	for i := range lines {
+		lines[i] = strconv.Itoa(i)
+	}
+	v.Update(logLinesMsg{gen: v.gen, lines: lines[:logViewerBatchLines]})
+	v.Update(logLinesMsg{gen: v.gen, lines: lines[logViewerBatchLines:]})
+	if len(v.pending) != logViewerMaxLines || v.pending[0] != "10" {
+		t.Fatalf("got %d pending lines starting at %q, want the newest %d", len(v.pending), v.pending[0], logViewerMaxLines)
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF9})
+	if v.lines.Len() != logViewerMaxLines || v.lines.At(v.lines.Len()-1) != lines[len(lines)-1] {
+		t.Errorf("resume kept %d lines", v.lines.Len())
+	}
+}
+
+func TestLogViewerDropsOldestLines(t *testing.T) {
+	v := NewLogViewer(t.Context(), "logs:web/app", nil, nil)
+	v.SetDimensions(40, 10)
+	if err := v.SetFilters("even", ""); err != nil {
+		t.Fatal(err)
+	}
+	batch := func(from, n int) []string {
+		lines := make([]string, 0, n)
+		for i := from; i < from+n; i++ {
+			kind := "odd"
+			if i%2 == 0 {
+				kind = "even"
+			}
+			lines = append(lines, fmt.Sprintf("%d %s", i, kind))
+		}
+		return lines
+	}
+	v.appendLines(batch(0, logViewerMaxLines))
+	v.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	v.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	top := v.shown.At(v.offset)
+
+	// Dropped lines leave the shown lines too, and the view stays on the
+	// same lines when not following.
+	v.appendLines(batch(logViewerMaxLines, 6))
+	if v.lines.Len() != logViewerMaxLines || v.lines.At(0) != "6 even" {
+		t.Fatalf("got %d lines starting at %q, want the newest %d", v.lines.Len(), v.lines.At(0), logViewerMaxLines)
+	}
+	if v.shown.Len() != logViewerMaxLines/2 || v.shown.At(0) != "6 even" || v.shown.At(v.shown.Len()-1) != fmt.Sprintf("%d even", logViewerMaxLines+4) {
+		t.Fatalf("got %d shown lines from %q to %q", v.shown.Len(), v.shown.At(0), v.shown.At(v.shown.Len()-1))
+	}
+	if got := v.shown.At(v.offset); got != top {
+		t.Errorf("view moved from %q to %q", top, got)
+	}
+
+	// Filters still apply to all kept lines.
+	if err := v.SetFilters("", "even"); err != nil {
+		t.Fatal(err)
+	}
+	if v.shown.Len() != logViewerMaxLines/2 || v.shown.At(0) != "7 odd" {
+		t.Errorf("got %d shown lines starting at %q", v.shown.Len(), v.shown.At(0))
+	}
+}
+
+func TestLogViewerOptionChoices(t *testing.T) {
+	streams, opened := fakeLogStreams()
+	v := NewLogViewer(t.Context(), "logs:web/app", streams, nil)
+	defer v.Stop()
+	v.Start()
+	nextStream(t, opened)
+
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF6})
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF7})
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF8})
+	// Restarted streams open concurrently; only the last one is still alive.
+	var s *fakeLogStream
+	for range 3 {
+		if next := nextStream(t, opened); next.ctx.Err() == nil {
+			s = next
+		}
+	}
+	if s == nil {
+		t.Fatal("no live stream")
+	}
+	if want := (models.LogOptions{Follow: true, Timestamps: true, SinceSeconds: 300, TailLines: 1000}); s.opts != want {
+		t.Errorf("options %+v, want %+v", s.opts, want)
+	}
+	if got, want := v.status(), "following · tail 1000 · since 5m · timestamps"; got != want {
+		t.Errorf("status %q, want %q", got, want)
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF8})
+	if s = nextStream(t, opened); s.opts.TailLines != 0 {
+		t.Errorf("tail %d, want all lines", s.opts.TailLines)
+	}
+}
+
+func TestLogViewerFilters(t *testing.T) {
+	v := NewLogViewer(t.Context(), "logs:web", nil, nil)
+	v.SetDimensions(40, 10)
+	v.appendLines([]string{
+		"\x1b[31mweb-1/app\x1b[39m GET /healthz",
+		"\x1b[32mweb-2/app\x1b[39m GET /api",
+		"\x1b[31mweb-1/app\x1b[39m error: boom",
+	})
+
+	// Filters match the uncolored line including the pod/container prefix.
+	if err := v.SetFilters("^web-1/", "healthz"); err != nil {
+		t.Fatal(err)
+	}
+	if v.shown.Len() != 1 || !strings.HasSuffix(v.shown.At(0), "error: boom") {
+		t.Fatalf("shown %q", v.shown.all())
+	}
+	v.appendLines([]string{"\x1b[31mweb-1/app\x1b[39m GET /api"})
+	if v.shown.Len() != 2 {
+		t.Errorf("new matching lines should be shown, got %q", v.shown.all())
+	}
+
+	// Filters are edited in the status line and applied with Enter; invalid
+	// expressions keep the editor open.
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF3})
+	if v.editing != logFilterExclude || v.input.value() != "healthz" {
+		t.Fatalf("editing %v with %q", v.editing, v.input.value())
+	}
+	v.input.setValue("(")
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if v.filterErr == nil || v.editing != logFilterExclude {
+		t.Error("invalid expression should be reported")
+	}
+	v.input.setValue("")
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if v.editing != logFilterNone || v.exclude != nil || v.shown.Len() != 3 {
+		t.Errorf("clearing the exclude filter should show all web-1 lines, got %q", v.shown.all())
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyF2})
+	v.Update(tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl})
+	if v.editing != logFilterNone || v.include == nil {
+		t.Error("Ctrl+G should cancel editing and keep the filter")
+	}
+}
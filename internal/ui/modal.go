@@ -224,6 +224,9 @@ func (m *Modal) View() string {
 	// For YAML viewers, drop left/right borders for easier copy/paste and allow full width content.
 	// Full-width content for viewers: detect via RequestTheme capability (viewers implement it)
 	_, isViewer := m.content.(interface{ RequestTheme() tea.Cmd })
+	if _, isLogs := m.content.(*LogViewer); isLogs {
+		isViewer = true
+	}
 	if isViewer {
 		contentW = m.width
 		// Viewers do not draw a bottom border; give them one extra row of
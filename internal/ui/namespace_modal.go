@@ -17,9 +17,8 @@ type NamespaceCreateResultMsg struct {
 
 // NamespaceCreateModel provides a minimal inline text input for namespace name.
 type NamespaceCreateModel struct {
+	textInput
 	width, height int
-	runes         []rune
-	cursor        int
 	err           string
 	buttons       []buttonRect
 }
@@ -34,54 +33,11 @@ func (m *NamespaceCreateModel) SetDimensions(w, h int) { m.width, m.height = w,
 
 // Reset clears the input state.
 func (m *NamespaceCreateModel) Reset() {
-	m.runes = m.runes[:0]
-	m.cursor = 0
+	m.setValue("")
 	m.err = ""
 	m.buttons = nil
 }
 
-func (m *NamespaceCreateModel) value() string { return string(m.runes) }
-
-func (m *NamespaceCreateModel) insertRunes(rs []rune) {
-	if len(rs) == 0 {
-		return
-	}
-	if m.cursor < 0 {
-		m.cursor = 0
-	}
-	if m.cursor > len(m.runes) {
-		m.cursor = len(m.runes)
-	}
-	before := append([]rune{}, m.runes[:m.cursor]...)
-	after := append([]rune{}, m.runes[m.cursor:]...)
-	m.runes = append(before, append(rs, after...)...)
-	m.cursor += len(rs)
-	m.err = ""
-}
-
-func (m *NamespaceCreateModel) deleteBackward() {
-	if m.cursor <= 0 || len(m.runes) == 0 {
-		return
-	}
-	m.runes = append(m.runes[:m.cursor-1], m.runes[m.cursor:]...)
-	m.cursor--
-}
-
-func (m *NamespaceCreateModel) deleteForward() {
-	if m.cursor < 0 || m.cursor >= len(m.runes) {
-		return
-	}
-	m.runes = append(m.runes[:m.cursor], m.runes[m.cursor+1:]...)
-}
-
-func (m *NamespaceCreateModel) clampCursor() {
-	if m.cursor < 0 {
-		m.cursor = 0
-	} else if m.cursor > len(m.runes) {
-		m.cursor = len(m.runes)
-	}
-}
-
 func (m *NamespaceCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch key := msg.(type) {
 	case tea.KeyMsg:
@@ -111,31 +67,9 @@ func (m *NamespaceCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					Close:   true,
 				}
 			}
-		case tea.KeyBackspace:
-			m.deleteBackward()
-			return m, nil
-		case tea.KeyDelete:
-			m.deleteForward()
-			return m, nil
-		case tea.KeyLeft:
-			m.cursor--
-			m.clampCursor()
-			return m, nil
-		case tea.KeyRight:
-			m.cursor++
-			m.clampCursor()
-			return m, nil
-		case tea.KeyHome:
-			m.cursor = 0
-			return m, nil
-		case tea.KeyEnd:
-			m.cursor = len(m.runes)
-			return m, nil
 		}
-		if text := k.Text; text != "" {
-			if k.Mod&(tea.ModCtrl|tea.ModAlt|tea.ModMeta|tea.ModSuper|tea.ModHyper) == 0 {
-				m.insertRunes([]rune(text))
-			}
+		if m.handleKey(k) {
+			m.err = ""
 		}
 		return m, nil
 	case tea.MouseMsg:
@@ -196,7 +130,7 @@ func (m *NamespaceCreateModel) View() string {
 	fieldWidth := max(24, innerWidth-6)
 	inputField := bg.Copy().
 		Align(lipgloss.Center).
-		Render(m.renderInput(fieldWidth))
+		Render(m.render(fieldWidth))
 
 	buttons := []string{
 		m.renderButton("Create"),
@@ -247,50 +181,6 @@ func (m *NamespaceCreateModel) renderButton(label string) string {
 		Render(label)
 }
 
-func (m *NamespaceCreateModel) renderInput(fieldWidth int) string {
-	if fieldWidth <= 0 {
-		fieldWidth = 1
-	}
-	cursorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorWhite)).
-		Background(lipgloss.Color(ColorModalSelBg)).
-		Bold(true)
-	textStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorWhite)).
-		Background(lipgloss.Color(ColorDarkGrey))
-	if m.cursor < 0 {
-		m.cursor = 0
-	}
-	if m.cursor > len(m.runes) {
-		m.cursor = len(m.runes)
-	}
-
-	display := m.runes
-	cursor := m.cursor
-	if len(display) > fieldWidth {
-		display = display[:fieldWidth]
-		if cursor > fieldWidth {
-			cursor = fieldWidth
-		}
-	}
-
-	var b strings.Builder
-	for i := 0; i < fieldWidth; i++ {
-		var ch string
-		if i < len(display) {
-			ch = string(display[i])
-		} else {
-			ch = " "
-		}
-		if i == cursor {
-			b.WriteString(cursorStyle.Render(ch))
-		} else {
-			b.WriteString(textStyle.Render(ch))
-		}
-	}
-	return b.String()
-}
-
 // FooterHints wires the modal footer hints.
 func (m *NamespaceCreateModel) FooterHints() [][2]string {
 	return [][2]string{{"Enter", "Create"}, {"Esc", "Cancel"}}
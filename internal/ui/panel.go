@@ -57,6 +57,9 @@ type Panel struct {
 	widgets         map[PanelViewMode]PanelWidget
 	widgetFactories map[PanelViewMode]PanelWidgetFactory
 	lastSelectionID string
+	// Connection state of the cluster behind the current folder.
+	clusterState    kccluster.State
+	hasClusterState bool
 }
 
 const panelContextTimeout = 250 * time.Millisecond
@@ -584,6 +587,15 @@ func (p *Panel) renderFooter(ctx context.Context) string {
 		footerText = fmt.Sprintf("%d/%d items", selectedCount, len(p.items))
 	}
 
+	if status, replace := p.clusterFooter(); replace {
+		footerText = status
+	} else if status != "" {
+		// Right-align the connection summary when it fits next to the details.
+		if gap := p.width - lipgloss.Width(footerText) - lipgloss.Width(status); gap >= 2 {
+			footerText += strings.Repeat(" ", gap) + status
+		}
+	}
+
 	if lipgloss.Width(footerText) > p.width {
 		if p.width >= 0 && p.width < len(footerText) {
 			footerText = footerText[:p.width]
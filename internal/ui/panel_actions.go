@@ -19,6 +19,8 @@ const (
 	PanelActionCreateNamespace
 	PanelActionDelete
 	PanelActionMenu
+	PanelActionCopy
+	PanelActionRename
 )
 
 // PanelActionHandler executes an action for a panel and may return a command.
@@ -32,6 +34,7 @@ type PanelEnvironment struct {
 	AllowEditObjects      bool
 	AllowDeleteObjects    bool
 	AllowCreateNamespaces bool
+	AllowEditKubeconfigs  bool
 }
 
 // PanelEnvironmentSupplier resolves the current environment prior to computing capabilities.
@@ -42,11 +45,16 @@ type PanelCapabilities struct {
 	CanView          bool
 	CanEdit          bool
 	CanDelete        bool
-	CanCreateNS      bool
+	CanCreateNS      bool // F7: create a namespace, or set a context's namespace
+	CanCopy          bool
+	CanRename        bool
 	HasOptions       bool
 	HasContextMenu   bool
 	HasHelp          bool
 	SupportsDescribe bool
+	// ContextActions reports a kubeconfig context selection, for which F4
+	// switches the file's current-context.
+	ContextActions bool
 }
 
 // SetActionHandlers installs the action handler map for the panel.
@@ -101,6 +109,17 @@ func (p *Panel) Capabilities(ctx context.Context) PanelCapabilities {
 					caps.CanDelete = true
 				}
 			}
+			if kctx, ok := item.(models.KubeconfigContext); ok && kctx.KubeconfigPath() != "" && env.AllowEditKubeconfigs {
+				caps.ContextActions = true
+				caps.CanEdit = true
+				caps.CanCopy = true
+				caps.CanRename = true
+				caps.CanCreateNS = true
+				caps.CanDelete = true
+			}
+			if _, ok := item.(models.PortForwardEntry); ok {
+				caps.CanDelete = true
+			}
 			// Describe/manifest widgets will use this flag when introduced.
 			if _, ok := item.(models.ObjectItem); ok {
 				caps.SupportsDescribe = true
@@ -109,7 +128,7 @@ func (p *Panel) Capabilities(ctx context.Context) PanelCapabilities {
 	}
 	// Namespace creation depends on both environment and location.
 	if env.AllowCreateNamespaces {
-		if strings.EqualFold(strings.TrimSpace(p.GetCurrentPath()), "/namespaces") {
+		if strings.EqualFold(clusterPath(strings.TrimSpace(p.GetCurrentPath())), "/namespaces") {
 			caps.CanCreateNS = true
 		}
 	}
@@ -155,6 +174,10 @@ func (p *Panel) actionAllowed(ctx context.Context, action PanelAction) bool {
 		return caps.CanDelete
 	case PanelActionMenu:
 		return caps.HasContextMenu
+	case PanelActionCopy:
+		return caps.CanCopy
+	case PanelActionRename:
+		return caps.CanRename
 	default:
 		return false
 	}
@@ -94,3 +94,57 @@ func TestPanelCapabilitiesWithActions(t *testing.T) {
 		t.Fatalf("expected 4 invocations, got %d", len(invoked))
 	}
 }
+
+func TestPanelCapabilitiesForKubeconfigContext(t *testing.T) {
+	item := models.NewContextItem("dev", []string{"dev"}, []string{"contexts", "dev"}, nil, nil)
+	panel := NewPanel("test")
+	panel.items = []Item{{Item: item, Name: "dev"}}
+	panel.selected = 0
+	panel.SetCurrentPath("/contexts")
+
+	env := PanelEnvironment{AllowEditKubeconfigs: true}
+	panel.SetEnvironmentSupplier(func() PanelEnvironment { return env })
+	ctx := context.Background()
+	if caps := panel.Capabilities(ctx); caps.ContextActions || caps.CanRename {
+		t.Fatalf("context without kubeconfig must not be editable: %+v", caps)
+	}
+
+	item.WithKubeconfig("/home/me/.kube/config")
+	caps := panel.Capabilities(ctx)
+	if !caps.ContextActions || !caps.CanEdit || !caps.CanCopy || !caps.CanRename || !caps.CanCreateNS || !caps.CanDelete {
+		t.Fatalf("context actions not enabled: %+v", caps)
+	}
+	if got := editKeyLabel(caps); got != "Use" {
+		t.Fatalf("F4 label = %q, want Use", got)
+	}
+
+	// Snapshot mode and the like disallow kubeconfig edits.
+	env = PanelEnvironment{}
+	if caps := panel.Capabilities(ctx); caps.ContextActions || caps.CanCopy {
+		t.Fatalf("context actions enabled without permission: %+v", caps)
+	}
+}
+
+type stubPortForward struct{}
+
+func (stubPortForward) Columns() (string, []string, []*lipgloss.Style, bool) {
+	return "7", []string{"localhost:8080"}, nil, true
+}
+
+func (stubPortForward) Details() string    { return "" }
+func (stubPortForward) Path() []string     { return nil }
+func (stubPortForward) PortForwardID() int { return 7 }
+
+func TestPanelCapabilitiesForPortForward(t *testing.T) {
+	var item models.PortForwardEntry = stubPortForward{}
+	panel := NewPanel("test")
+	panel.items = []Item{{Item: item, Name: "localhost:8080"}}
+	panel.selected = 0
+	panel.SetCurrentPath("/port-forwards")
+
+	// Forwards stop with F8 regardless of the cluster permissions.
+	panel.SetEnvironmentSupplier(func() PanelEnvironment { return PanelEnvironment{} })
+	if caps := panel.Capabilities(context.Background()); !caps.CanDelete {
+		t.Fatalf("port-forward not stoppable: %+v", caps)
+	}
+}
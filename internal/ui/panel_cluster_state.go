@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	kccluster "github.com/sttts/kc/internal/cluster"
+)
+
+// SetClusterState records the connection state of the cluster the panel is
+// browsing. ok=false clears it, e.g. when the cluster is not pooled.
+func (p *Panel) SetClusterState(st kccluster.State, ok bool) {
+	p.clusterState = st
+	p.hasClusterState = ok
+}
+
+// ClusterStateLabel returns a breadcrumb suffix for clusters that are not
+// fully usable, or "" when the cluster is synced or its state is unknown.
+func (p *Panel) ClusterStateLabel() string {
+	if !p.hasClusterState || p.clusterState.Phase == kccluster.PhaseSynced {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", p.clusterState.Phase)
+}
+
+// clusterFooter returns the connection summary for the footer. replace reports
+// whether it should take the place of the selection details because the
+// cluster cannot serve the panel.
+func (p *Panel) clusterFooter() (text string, replace bool) {
+	if !p.hasClusterState {
+		return "", false
+	}
+	st := p.clusterState
+	switch st.Phase {
+	case kccluster.PhaseConnecting:
+		return "connecting…", true
+	case kccluster.PhaseFailed:
+		if st.Err != nil {
+			return fmt.Sprintf("failed: %v", st.Err), true
+		}
+		return "failed", true
+	case kccluster.PhaseDegraded:
+		return fmt.Sprintf("%s degraded", st.ServerVersion), false
+	default:
+		return fmt.Sprintf("%s %s", st.ServerVersion, st.RTT.Round(time.Millisecond)), false
+	}
+}
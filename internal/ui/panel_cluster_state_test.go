@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	kccluster "github.com/sttts/kc/internal/cluster"
+)
+
+func TestPanelClusterState(t *testing.T) {
+	p := NewPanel("test")
+	if label := p.ClusterStateLabel(); label != "" {
+		t.Fatalf("expected no label without state, got %q", label)
+	}
+
+	p.SetClusterState(kccluster.State{Phase: kccluster.PhaseSynced, ServerVersion: "v1.29.0", RTT: 12 * time.Millisecond}, true)
+	if label := p.ClusterStateLabel(); label != "" {
+		t.Fatalf("expected no label for synced clusters, got %q", label)
+	}
+	if text, replace := p.clusterFooter(); replace || text != "v1.29.0 12ms" {
+		t.Fatalf("unexpected synced footer %q (replace=%v)", text, replace)
+	}
+
+	p.SetClusterState(kccluster.State{Phase: kccluster.PhaseFailed, Err: errors.New("connection refused")}, true)
+	if label := p.ClusterStateLabel(); label != " [failed]" {
+		t.Fatalf("unexpected failed label %q", label)
+	}
+	if text, replace := p.clusterFooter(); !replace || !strings.Contains(text, "connection refused") {
+		t.Fatalf("expected failed footer to surface the error, got %q (replace=%v)", text, replace)
+	}
+}
@@ -160,6 +160,10 @@ func (w *listWidget) handleKey(ctx context.Context, m tea.KeyMsg) (tea.Cmd, bool
 		return p.invokeActionIfAllowed(ctx, PanelActionView), true
 	case "f4":
 		return p.invokeActionIfAllowed(ctx, PanelActionEdit), true
+	case "f5":
+		return p.invokeActionIfAllowed(ctx, PanelActionCopy), true
+	case "f6":
+		return p.invokeActionIfAllowed(ctx, PanelActionRename), true
 	case "f7":
 		return p.invokeActionIfAllowed(ctx, PanelActionCreateNamespace), true
 	case "f8":
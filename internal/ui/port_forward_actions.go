@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	models "github.com/sttts/kc/internal/models"
+	"github.com/sttts/kc/internal/portforward"
+)
+
+// portForwardStartedMsg reports the outcome of starting a port-forward.
+type portForwardStartedMsg struct {
+	target portforward.Target
+	info   portforward.Info
+	err    error
+}
+
+// startPortForwardForPanel prompts for the ports to forward to the pod or
+// service selected in panel, kubectl style as [local:]remote.
+func (a *App) startPortForwardForPanel(panel *Panel) tea.Cmd {
+	if panel == nil || a.portForwards == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	defer cancel()
+	item, ok := panel.SelectedNavItem(ctx)
+	if !ok || item == nil {
+		return nil
+	}
+	obj, ok := item.(models.ObjectItem)
+	if !ok || obj.GVR().Group != "" {
+		return nil
+	}
+	kind := portforward.KindPod
+	switch obj.GVR().Resource {
+	case "pods":
+	case "services":
+		kind = portforward.KindService
+	default:
+		return nil
+	}
+	fail := func(format string, args ...any) tea.Cmd {
+		if a.toastLogger == nil {
+			return nil
+		}
+		return a.toastLogger.Errorf(format, args...)
+	}
+	pc := a.clusterForAction(panel)
+	if pc.cl == nil {
+		return fail("Cluster not ready for port-forward")
+	}
+	u, err := pc.cl.GetByGVR(ctx, obj.GVR(), obj.Namespace(), obj.Name())
+	if err == nil && u == nil {
+		err = fmt.Errorf("not found")
+	}
+	if err != nil {
+		return fail("Port-forward %s/%s failed: %v", kind, obj.Name(), err)
+	}
+	ports, err := portforward.DeclaredPorts(u)
+	if err != nil {
+		return fail("Port-forward %s/%s failed: %v", kind, obj.Name(), err)
+	}
+	if kind == portforward.KindService && len(ports) == 0 {
+		return fail("Service %s has no TCP ports", obj.Name())
+	}
+
+	target := portforward.Target{Context: pc.name(), Namespace: obj.Namespace(), Kind: kind, Name: obj.Name()}
+	// The prompt header is a single line, so only the first ports are listed.
+	header := fmt.Sprintf("Forward [local:]remote to %s/%s", kind, obj.Name())
+	value := ""
+	if len(ports) > 0 {
+		names := make([]string, 0, 3)
+		for _, p := range ports[:min(3, len(ports))] {
+			names = append(names, p.String())
+		}
+		if len(ports) > 3 {
+			names = append(names, "…")
+		}
+		header += " (" + strings.Join(names, ", ") + ")"
+		value = strconv.Itoa(int(ports[0].Port))
+		if ports[0].Port < 1024 {
+			// Privileged local ports usually cannot be bound, so let
+			// the system pick one.
+			value = ":" + value
+		}
+	}
+	declared := func(remote string) bool {
+		return slices.ContainsFunc(ports, func(p portforward.Port) bool {
+			return p.Name == remote || strconv.Itoa(int(p.Port)) == remote
+		})
+	}
+	validate := func(v string) string {
+		_, remote, err := portforward.ParsePorts(v)
+		if err != nil {
+			return err.Error()
+		}
+		if _, numErr := strconv.Atoi(remote); (kind == portforward.KindService || numErr != nil) && !declared(remote) {
+			return fmt.Sprintf("%s/%s has no port %s", kind, obj.Name(), remote)
+		}
+		return ""
+	}
+	cfg := pc.cl.GetConfig()
+	return a.showPrompt("Port Forward", header, "Forward", value, validate, func(v string) tea.Cmd {
+		local, remote, err := portforward.ParsePorts(v)
+		if err != nil {
+			return nil
+		}
+		target.LocalPort, target.Port = local, remote
+		return a.withBusy("Port-forward", 300*time.Millisecond, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(a.ctx, requestTimeout)
+			defer cancel()
+			info, err := a.portForwards.Start(ctx, cfg, target)
+			return portForwardStartedMsg{target: target, info: info, err: err}
+		})
+	})
+}
+
+// selectedPortForward returns the port-forward selected in panel, if any.
+func (a *App) selectedPortForward(panel *Panel) (int, bool) {
+	if panel == nil {
+		return 0, false
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, panelContextTimeout)
+	item, ok := panel.SelectedNavItem(ctx)
+	cancel()
+	if !ok || item == nil {
+		return 0, false
+	}
+	entry, ok := item.(models.PortForwardEntry)
+	if !ok {
+		return 0, false
+	}
+	return entry.PortForwardID(), true
+}
+
+// stopPortForward ends the port-forward with the given ID.
+func (a *App) stopPortForward(id int) tea.Cmd {
+	if a.portForwards == nil {
+		return nil
+	}
+	forwards := a.portForwards.List()
+	i := slices.IndexFunc(forwards, func(info portforward.Info) bool { return info.ID == id })
+	if i < 0 || !a.portForwards.Stop(id) {
+		return nil
+	}
+	info := forwards[i]
+	return a.ShowToast(fmt.Sprintf("Stopped forwarding localhost:%d → %s", info.LocalPort, info.Target), 3*time.Second)
+}
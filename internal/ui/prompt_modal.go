@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// PromptResultMsg signals the outcome of a prompt dialog.
+type PromptResultMsg struct {
+	Value   string
+	Confirm bool
+	Close   bool
+}
+
+// PromptModel asks for a single line of text, e.g. a new context name.
+type PromptModel struct {
+	textInput
+	width, height int
+	header        string
+	confirm       string
+	validate      func(string) string
+	err           string
+	buttons       []buttonRect
+}
+
+// NewPromptModel constructs an unconfigured prompt dialog model.
+func NewPromptModel() *PromptModel {
+	return &PromptModel{confirm: "OK"}
+}
+
+func (m *PromptModel) Init() tea.Cmd          { return nil }
+func (m *PromptModel) SetDimensions(w, h int) { m.width, m.height = w, h }
+
+// Configure sets the question, the confirm button label and the initial
+// value. validate returns an error message for values that cannot be
+// confirmed; nil accepts everything.
+func (m *PromptModel) Configure(header, confirm, value string, validate func(string) string) {
+	m.header = header
+	m.confirm = confirm
+	m.validate = validate
+	m.err = ""
+	m.buttons = nil
+	m.setValue(value)
+}
+
+func (m *PromptModel) submit() tea.Cmd {
+	value := strings.TrimSpace(m.value())
+	if m.validate != nil {
+		if msg := m.validate(value); msg != "" {
+			m.err = msg
+			return nil
+		}
+	}
+	return func() tea.Msg { return PromptResultMsg{Value: value, Confirm: true, Close: true} }
+}
+
+func (m *PromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch key := msg.(type) {
+	case tea.KeyMsg:
+		switch key.String() {
+		case "ctrl+c", "ctrl+g", "esc":
+			return m, func() tea.Msg { return PromptResultMsg{Close: true} }
+		case "ctrl+h":
+			m.deleteBackward()
+			return m, nil
+		}
+		k := key.Key()
+		if k.Code == tea.KeyEnter {
+			return m, m.submit()
+		}
+		if m.handleKey(k) {
+			m.err = ""
+		}
+		return m, nil
+	case tea.MouseMsg:
+		mouse := key.Mouse()
+		if mouse.Button != tea.MouseLeft {
+			return m, nil
+		}
+		if _, ok := msg.(tea.MouseReleaseMsg); !ok {
+			return m, nil
+		}
+		for idx, r := range m.buttons {
+			if !r.contains(mouse.X, mouse.Y) {
+				continue
+			}
+			if idx == 0 {
+				return m, m.submit()
+			}
+			return m, func() tea.Msg { return PromptResultMsg{Close: true} }
+		}
+	}
+	return m, nil
+}
+
+func (m *PromptModel) View() string {
+	innerWidth := max(30, m.width-4)
+	bg := lipgloss.NewStyle().
+		Background(lipgloss.Color(ColorModalBg)).
+		Foreground(lipgloss.Color(ColorModalFg)).
+		Width(innerWidth)
+
+	header := bg.Copy().
+		Bold(true).
+		Align(lipgloss.Center).
+		Render(m.header)
+
+	fieldWidth := max(24, innerWidth-6)
+	inputField := bg.Copy().
+		Align(lipgloss.Center).
+		Render(m.render(fieldWidth))
+
+	buttonStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorModalFg)).
+		Background(lipgloss.Color(ColorModalBg)).
+		Padding(0, 3).
+		Align(lipgloss.Center)
+	buttons := []string{buttonStyle.Render(m.confirm), buttonStyle.Render("Cancel")}
+	separator := lipgloss.NewStyle().
+		Background(lipgloss.Color(ColorModalBg)).
+		Render(" ")
+	buttonRow := lipgloss.JoinHorizontal(lipgloss.Center, buttons[0], separator, buttons[1])
+	leftPad := max(0, (innerWidth-lipgloss.Width(buttonRow))/2)
+	buttonLine := 4 // header (0), blank (1), input (2), blank (3), buttons (4)
+	m.buttons = []buttonRect{
+		{x: leftPad, y: buttonLine, w: lipgloss.Width(buttons[0]), h: 1},
+		{x: leftPad + lipgloss.Width(buttons[0]) + lipgloss.Width(separator), y: buttonLine, w: lipgloss.Width(buttons[1]), h: 1},
+	}
+
+	help := bg.Copy().
+		Faint(true).
+		Align(lipgloss.Center).
+		Render("Enter: " + m.confirm + " • Esc: Cancel")
+
+	lines := []string{
+		header,
+		bg.Copy().Render(""),
+		inputField,
+		bg.Copy().Render(""),
+		bg.Copy().Align(lipgloss.Center).Render(buttonRow),
+		bg.Copy().Render(""),
+		help,
+	}
+	if m.err != "" {
+		errLine := bg.Copy().
+			Foreground(lipgloss.Color(ColorModalSelBg)).
+			Render(m.err)
+		lines = append(lines, bg.Copy().Render(""), errLine)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// FooterHints wires the modal footer hints.
+func (m *PromptModel) FooterHints() [][2]string {
+	return [][2]string{{"Enter", m.confirm}, {"Esc", "Cancel"}}
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func TestPromptModelEditsInitialValue(t *testing.T) {
+	model := NewPromptModel()
+	model.Configure("Rename context dev to", "Rename", "dev", func(v string) string {
+		if v == "dev" {
+			return "Name is unchanged"
+		}
+		return ""
+	})
+
+	// The unchanged value is rejected.
+	_, cmd := model.Update(press(tea.KeyEnter, "", 0))
+	if cmd != nil || model.err == "" {
+		t.Fatalf("expected a validation error, got cmd %v err %q", cmd, model.err)
+	}
+
+	model.Update(press('2', "2", 0))
+	_, cmd = model.Update(press(tea.KeyEnter, "", 0))
+	if cmd == nil {
+		t.Fatalf("expected command on enter")
+	}
+	res, ok := cmd().(PromptResultMsg)
+	if !ok || !res.Confirm || !res.Close || res.Value != "dev2" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
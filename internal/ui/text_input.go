@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// textInput is the single-line input field shared by the input dialogs.
+type textInput struct {
+	runes  []rune
+	cursor int
+}
+
+func (t *textInput) value() string { return string(t.runes) }
+
+// setValue replaces the content and moves the cursor to its end.
+func (t *textInput) setValue(s string) {
+	t.runes = []rune(s)
+	t.cursor = len(t.runes)
+}
+
+func (t *textInput) insertRunes(rs []rune) {
+	if len(rs) == 0 {
+		return
+	}
+	t.clampCursor()
+	before := append([]rune{}, t.runes[:t.cursor]...)
+	after := append([]rune{}, t.runes[t.cursor:]...)
+	t.runes = append(before, append(rs, after...)...)
+	t.cursor += len(rs)
+}
+
+func (t *textInput) deleteBackward() {
+	if t.cursor <= 0 || len(t.runes) == 0 {
+		return
+	}
+	t.runes = append(t.runes[:t.cursor-1], t.runes[t.cursor:]...)
+	t.cursor--
+}
+
+func (t *textInput) deleteForward() {
+	if t.cursor < 0 || t.cursor >= len(t.runes) {
+		return
+	}
+	t.runes = append(t.runes[:t.cursor], t.runes[t.cursor+1:]...)
+}
+
+func (t *textInput) clampCursor() {
+	if t.cursor < 0 {
+		t.cursor = 0
+	} else if t.cursor > len(t.runes) {
+		t.cursor = len(t.runes)
+	}
+}
+
+// handleKey applies editing and cursor keys and reports whether k was one.
+func (t *textInput) handleKey(k tea.Key) bool {
+	switch k.Code {
+	case tea.KeyBackspace:
+		t.deleteBackward()
+		return true
+	case tea.KeyDelete:
+		t.deleteForward()
+		return true
+	case tea.KeyLeft:
+		t.cursor--
+		t.clampCursor()
+		return true
+	case tea.KeyRight:
+		t.cursor++
+		t.clampCursor()
+		return true
+	case tea.KeyHome:
+		t.cursor = 0
+		return true
+	case tea.KeyEnd:
+		t.cursor = len(t.runes)
+		return true
+	}
+	if text := k.Text; text != "" {
+		if k.Mod&(tea.ModCtrl|tea.ModAlt|tea.ModMeta|tea.ModSuper|tea.ModHyper) == 0 {
+			t.insertRunes([]rune(text))
+			return true
+		}
+	}
+	return false
+}
+
+// render draws the field fieldWidth cells wide with the cursor highlighted.
+func (t *textInput) render(fieldWidth int) string {
+	if fieldWidth <= 0 {
+		fieldWidth = 1
+	}
+	cursorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorWhite)).
+		Background(lipgloss.Color(ColorModalSelBg)).
+		Bold(true)
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorWhite)).
+		Background(lipgloss.Color(ColorDarkGrey))
+	t.clampCursor()
+
+	display := t.runes
+	cursor := t.cursor
+	if len(display) > fieldWidth {
+		display = display[:fieldWidth]
+		if cursor > fieldWidth {
+			cursor = fieldWidth
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < fieldWidth; i++ {
+		var ch string
+		if i < len(display) {
+			ch = string(display[i])
+		} else {
+			ch = " "
+		}
+		if i == cursor {
+			b.WriteString(cursorStyle.Render(ch))
+		} else {
+			b.WriteString(textStyle.Render(ch))
+		}
+	}
+	return b.String()
+}
@@ -81,6 +81,8 @@ type ResourcesViewConfig struct {
 	ObjectsOrder string `json:"objectsOrder"`
 	// PeekInterval throttles how often empty-resource peeks hit the API (default 30s).
 	PeekInterval metav1.Duration `json:"peekInterval"`
+	// ShowGroups exposes the /groups/<group>/<version> hierarchy at the root, listing every served API version.
+	ShowGroups bool `json:"showGroups"`
 }
 
 type Config struct {
@@ -90,6 +92,13 @@ type Config struct {
 	Kubernetes KubernetesConfig    `json:"kubernetes"`
 	Resources  ResourcesViewConfig `json:"resources"`
 	Objects    ObjectsConfig       `json:"objects"`
+	Exec       ExecConfig          `json:"exec"`
+}
+
+// ExecConfig controls interactive exec sessions in containers.
+type ExecConfig struct {
+	// Command runs in the container instead of the first shell found of /bin/bash and /bin/sh.
+	Command []string `json:"command"`
 }
 
 // ObjectsConfig controls object-list specific options.
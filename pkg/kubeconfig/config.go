@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"k8s.io/client-go/rest"
@@ -40,6 +41,9 @@ type Manager struct {
 	kubeconfigs []*Kubeconfig
 	contexts    []*Context
 	clusters    []*Cluster
+
+	explicitPath string   // like kubectl's --kubeconfig; wins over $KUBECONFIG
+	loaded       []string // files added via LoadKubeconfig, kept across rediscovery
 }
 
 // NewManager creates a new kubeconfig manager
@@ -51,70 +55,119 @@ func NewManager() *Manager {
 	}
 }
 
-// DiscoverKubeconfigs discovers all kubeconfig files in ~/.kube
+// SetExplicitPath restricts discovery to a single kubeconfig file, like
+// kubectl's --kubeconfig flag. An empty path restores the default rules.
+func (m *Manager) SetExplicitPath(path string) {
+	m.explicitPath = path
+}
+
+// DiscoverKubeconfigs (re)discovers kubeconfig files following kubectl's
+// loading rules: the explicit path if set, else the files listed in
+// $KUBECONFIG, else every kubeconfig file in ~/.kube. Missing files and a
+// missing ~/.kube are skipped; an explicit path must be loadable. Calling it
+// again replaces the previous result, e.g. after the files changed on disk.
 func (m *Manager) DiscoverKubeconfigs() error {
-	homeDir, err := os.UserHomeDir()
+	paths, strict, err := m.discoveryPaths()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
+	kubeconfigs := make([]*Kubeconfig, 0, len(paths)+len(m.loaded))
+	seen := make(map[string]bool, len(paths))
+	for _, path := range append(paths, m.loaded...) {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		config, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			if strict || slices.Contains(m.loaded, path) {
+				return fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+			}
+			// Missing or not a kubeconfig, skip
+			continue
+		}
+		kubeconfigs = append(kubeconfigs, &Kubeconfig{
+			Path:    path,
+			Config:  config,
+			Context: m.selectedContext(path),
+		})
+	}
+	m.kubeconfigs = kubeconfigs
+	return m.buildContextsAndClusters()
+}
 
-	kubeDir := filepath.Join(homeDir, ".kube")
-
-	// Check if .kube directory exists
-	if _, err := os.Stat(kubeDir); os.IsNotExist(err) {
-		return fmt.Errorf("kube directory does not exist: %s", kubeDir)
+// selectedContext keeps a context chosen via SetCurrentContext across rediscovery.
+func (m *Manager) selectedContext(path string) string {
+	if kc := m.GetKubeconfigByPath(path); kc != nil {
+		return kc.Context
 	}
+	return ""
+}
 
-	// Load the main kubeconfig
-	mainConfigPath := filepath.Join(kubeDir, "config")
-	if _, err := os.Stat(mainConfigPath); err == nil {
-		config, err := clientcmd.LoadFromFile(mainConfigPath)
-		if err != nil {
-			return fmt.Errorf("failed to load main kubeconfig: %w", err)
+// discoveryPaths lists the candidate kubeconfig files. strict reports whether
+// every listed file must load.
+func (m *Manager) discoveryPaths() (paths []string, strict bool, err error) {
+	if m.explicitPath != "" {
+		return []string{m.explicitPath}, true, nil
+	}
+	if env := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); env != "" {
+		for _, p := range filepath.SplitList(env) {
+			if p = strings.TrimSpace(p); p != "" {
+				paths = append(paths, p)
+			}
 		}
+		return paths, false, nil
+	}
 
-		kubeconfig := &Kubeconfig{
-			Path:   mainConfigPath,
-			Config: config,
-		}
-		m.kubeconfigs = append(m.kubeconfigs, kubeconfig)
+	kubeDir, err := defaultKubeDir()
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := os.Stat(kubeDir); os.IsNotExist(err) {
+		return nil, false, nil
 	}
 
-	// Discover additional kubeconfig files
+	// The main kubeconfig comes first, followed by additional files
+	mainConfigPath := filepath.Join(kubeDir, "config")
+	paths = append(paths, mainConfigPath)
 	err = filepath.Walk(kubeDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip directories and hidden files
-		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
-			return nil
-		}
-
-		// Skip the main config file (already loaded)
-		if path == mainConfigPath {
-			return nil
-		}
-
-		// Try to load as kubeconfig
-		config, err := clientcmd.LoadFromFile(path)
-		if err != nil {
-			// Not a valid kubeconfig, skip
+		// Skip directories, hidden files and the main config (already listed)
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") || path == mainConfigPath {
 			return nil
 		}
-
-		kubeconfig := &Kubeconfig{
-			Path:   path,
-			Config: config,
-		}
-		m.kubeconfigs = append(m.kubeconfigs, kubeconfig)
-
+		paths = append(paths, path)
 		return nil
 	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to walk kube directory: %w", err)
+	}
+	return paths, false, nil
+}
 
+func defaultKubeDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("failed to walk kube directory: %w", err)
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
+	return filepath.Join(homeDir, ".kube"), nil
+}
+
+// LoadKubeconfig adds a single kubeconfig file, e.g. one not living in ~/.kube
+func (m *Manager) LoadKubeconfig(path string) error {
+	config, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	m.kubeconfigs = append(m.kubeconfigs, &Kubeconfig{
+		Path:   path,
+		Config: config,
+	})
+	m.loaded = append(m.loaded, path)
 
 	return m.buildContextsAndClusters()
 }
@@ -3,7 +3,10 @@ package kubeconfig
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"testing"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd/api"
 )
@@ -353,18 +356,15 @@ func TestGetNamespacesForContext(t *testing.T) {
 func TestDiscoverKubeconfigs_NoKubeDir(t *testing.T) {
 	// Create a temporary directory without .kube
 	tempDir := t.TempDir()
-	originalHome := os.Getenv("HOME")
-	defer func() {
-		os.Setenv("HOME", originalHome)
-	}()
-
-	os.Setenv("HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+	t.Setenv("KUBECONFIG", "")
 
 	manager := NewManager()
-	err := manager.DiscoverKubeconfigs()
-
-	if err == nil {
-		t.Error("Expected error when .kube directory doesn't exist")
+	if err := manager.DiscoverKubeconfigs(); err != nil {
+		t.Errorf("DiscoverKubeconfigs() failed without .kube directory: %v", err)
+	}
+	if len(manager.kubeconfigs) != 0 {
+		t.Errorf("Expected 0 kubeconfigs, got %d", len(manager.kubeconfigs))
 	}
 }
 
@@ -383,6 +383,7 @@ func TestDiscoverKubeconfigs_EmptyKubeDir(t *testing.T) {
 	}()
 
 	os.Setenv("HOME", tempDir)
+	t.Setenv("KUBECONFIG", "")
 
 	manager := NewManager()
 	err = manager.DiscoverKubeconfigs()
@@ -395,3 +396,143 @@ func TestDiscoverKubeconfigs_EmptyKubeDir(t *testing.T) {
 		t.Errorf("Expected 0 kubeconfigs, got %d", len(manager.kubeconfigs))
 	}
 }
+
+func TestLoadKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.kubeconfig")
+	content := `apiVersion: v1
+kind: Config
+current-context: snap
+clusters:
+- name: snap
+  cluster:
+    server: http://127.0.0.1:1
+contexts:
+- name: snap
+  context:
+    cluster: snap
+    namespace: shop
+users: []
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager()
+	if err := manager.LoadKubeconfig(path); err != nil {
+		t.Fatalf("LoadKubeconfig() error = %v", err)
+	}
+	ctx := manager.GetContextByName("snap")
+	if ctx == nil || ctx.Namespace != "shop" || ctx.Kubeconfig.Path != path {
+		t.Fatalf("unexpected context %+v", ctx)
+	}
+	if got := manager.GetCurrentContext(manager.GetKubeconfigByPath(path)); got != ctx {
+		t.Errorf("GetCurrentContext() = %v, want %v", got, ctx)
+	}
+}
+
+func writeKubeconfig(t *testing.T, path string, contexts ...string) {
+	t.Helper()
+	content := "apiVersion: v1\nkind: Config\nclusters:\n- name: c\n  cluster:\n    server: http://127.0.0.1:1\ncontexts:\n"
+	for _, name := range contexts {
+		content += "- name: " + name + "\n  context:\n    cluster: c\n"
+	}
+	if len(contexts) > 0 {
+		content += "current-context: " + contexts[0] + "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func contextNames(m *Manager) []string {
+	var names []string
+	for _, ctx := range m.GetContexts() {
+		names = append(names, filepath.Base(ctx.Kubeconfig.Path)+":"+ctx.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestDiscoverKubeconfigs_LoadingRules(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".kube"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeKubeconfig(t, filepath.Join(home, ".kube", "config"), "home")
+
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	writeKubeconfig(t, a, "dev", "prod")
+	writeKubeconfig(t, b, "dev")
+
+	// $KUBECONFIG lists files in order; missing entries are skipped.
+	t.Setenv("KUBECONFIG", a+string(os.PathListSeparator)+filepath.Join(dir, "missing")+string(os.PathListSeparator)+b)
+	manager := NewManager()
+	if err := manager.DiscoverKubeconfigs(); err != nil {
+		t.Fatalf("DiscoverKubeconfigs() error = %v", err)
+	}
+	if got, want := contextNames(manager), []string{"a:dev", "a:prod", "b:dev"}; !slices.Equal(got, want) {
+		t.Errorf("contexts = %v, want %v", got, want)
+	}
+
+	// Rediscovery replaces the previous result.
+	writeKubeconfig(t, a, "dev")
+	if err := manager.DiscoverKubeconfigs(); err != nil {
+		t.Fatalf("DiscoverKubeconfigs() error = %v", err)
+	}
+	if got, want := contextNames(manager), []string{"a:dev", "b:dev"}; !slices.Equal(got, want) {
+		t.Errorf("contexts after rediscovery = %v, want %v", got, want)
+	}
+
+	// An explicit path wins over $KUBECONFIG and must exist.
+	manager.SetExplicitPath(b)
+	if err := manager.DiscoverKubeconfigs(); err != nil {
+		t.Fatalf("DiscoverKubeconfigs() error = %v", err)
+	}
+	if got, want := contextNames(manager), []string{"b:dev"}; !slices.Equal(got, want) {
+		t.Errorf("contexts with explicit path = %v, want %v", got, want)
+	}
+	manager.SetExplicitPath(filepath.Join(dir, "missing"))
+	if err := manager.DiscoverKubeconfigs(); err == nil {
+		t.Error("expected an error for a missing explicit kubeconfig")
+	}
+}
+
+func TestWatchReportsChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	writeKubeconfig(t, path, "dev")
+	t.Setenv("KUBECONFIG", path)
+
+	manager := NewManager()
+	if err := manager.DiscoverKubeconfigs(); err != nil {
+		t.Fatalf("DiscoverKubeconfigs() error = %v", err)
+	}
+	changed := make(chan struct{}, 10)
+	if err := manager.Watch(t.Context(), func() { changed <- struct{}{} }); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Unrelated files in the same directory are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "other"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changed:
+		t.Fatal("unexpected change for an unrelated file")
+	case <-time.After(2 * watchDebounce):
+	}
+
+	// Tools replace the file via rename.
+	tmp := filepath.Join(dir, "config.tmp")
+	writeKubeconfig(t, tmp, "dev", "prod")
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported after the kubeconfig was replaced")
+	}
+}
@@ -0,0 +1,193 @@
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// BackupPath returns where the content of path is saved before kc rewrites
+// it. The backup is a hidden sibling, so discovery and watching ignore it.
+func BackupPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".kc-backup")
+}
+
+// UseContext sets the current-context of the kubeconfig file at path.
+func UseContext(path, name string) error {
+	return editFile(path, false, func(cfg *api.Config) error {
+		if _, ok := cfg.Contexts[name]; !ok {
+			return fmt.Errorf("context %q not found in %s", name, path)
+		}
+		cfg.CurrentContext = name
+		return nil
+	})
+}
+
+// RenameContext renames a context of the kubeconfig file at path, following
+// it with the current-context.
+func RenameContext(path, oldName, newName string) error {
+	return editFile(path, false, func(cfg *api.Config) error {
+		ctx, ok := cfg.Contexts[oldName]
+		if !ok {
+			return fmt.Errorf("context %q not found in %s", oldName, path)
+		}
+		if _, ok := cfg.Contexts[newName]; ok {
+			return fmt.Errorf("context %q already exists in %s", newName, path)
+		}
+		delete(cfg.Contexts, oldName)
+		cfg.Contexts[newName] = ctx
+		if cfg.CurrentContext == oldName {
+			cfg.CurrentContext = newName
+		}
+		return nil
+	})
+}
+
+// DeleteContext removes a context from the kubeconfig file at path. Like
+// `kubectl config delete-context`, the cluster and user it references stay.
+func DeleteContext(path, name string) error {
+	return editFile(path, false, func(cfg *api.Config) error {
+		if _, ok := cfg.Contexts[name]; !ok {
+			return fmt.Errorf("context %q not found in %s", name, path)
+		}
+		delete(cfg.Contexts, name)
+		if cfg.CurrentContext == name {
+			cfg.CurrentContext = ""
+		}
+		return nil
+	})
+}
+
+// SetContextNamespace sets the default namespace of a context. An empty
+// namespace removes it.
+func SetContextNamespace(path, name, namespace string) error {
+	return editFile(path, false, func(cfg *api.Config) error {
+		ctx, ok := cfg.Contexts[name]
+		if !ok {
+			return fmt.Errorf("context %q not found in %s", name, path)
+		}
+		ctx.Namespace = namespace
+		return nil
+	})
+}
+
+// CopyContext copies a context together with its cluster and user from the
+// kubeconfig file srcPath into dstPath, creating dstPath if needed. Relative
+// file references are made absolute so they keep working from dstPath. The
+// copy fails rather than overwrite a different cluster or user of the same
+// name; identical ones are shared.
+func CopyContext(srcPath, name, dstPath string) error {
+	src, err := clientcmd.LoadFromFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig %s: %w", srcPath, err)
+	}
+	if err := clientcmd.ResolveLocalPaths(src); err != nil {
+		return fmt.Errorf("failed to resolve paths in %s: %w", srcPath, err)
+	}
+	ctx, ok := src.Contexts[name]
+	if !ok {
+		return fmt.Errorf("context %q not found in %s", name, srcPath)
+	}
+	return editFile(dstPath, true, func(dst *api.Config) error {
+		if _, ok := dst.Contexts[name]; ok {
+			return fmt.Errorf("context %q already exists in %s", name, dstPath)
+		}
+		if cluster, ok := src.Clusters[ctx.Cluster]; ok {
+			cluster = cluster.DeepCopy()
+			cluster.LocationOfOrigin = ""
+			if existing, ok := dst.Clusters[ctx.Cluster]; !ok {
+				dst.Clusters[ctx.Cluster] = cluster
+			} else if !reflect.DeepEqual(existing, cluster) {
+				return fmt.Errorf("a different cluster %q already exists in %s", ctx.Cluster, dstPath)
+			}
+		}
+		if user, ok := src.AuthInfos[ctx.AuthInfo]; ok {
+			user = user.DeepCopy()
+			user.LocationOfOrigin = ""
+			if existing, ok := dst.AuthInfos[ctx.AuthInfo]; !ok {
+				dst.AuthInfos[ctx.AuthInfo] = user
+			} else if !reflect.DeepEqual(existing, user) {
+				return fmt.Errorf("a different user %q already exists in %s", ctx.AuthInfo, dstPath)
+			}
+		}
+		ctx = ctx.DeepCopy()
+		ctx.LocationOfOrigin = ""
+		dst.Contexts[name] = ctx
+		return nil
+	})
+}
+
+// RedactedContext renders the stanza of context name in cfg, i.e. the
+// context with its cluster and user, with credentials and embedded data
+// redacted.
+func RedactedContext(cfg *api.Config, name string) ([]byte, error) {
+	ctx, ok := cfg.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("context %q not found", name)
+	}
+	stanza := api.NewConfig()
+	stanza.CurrentContext = cfg.CurrentContext
+	stanza.Contexts[name] = ctx.DeepCopy()
+	if cluster, ok := cfg.Clusters[ctx.Cluster]; ok {
+		stanza.Clusters[ctx.Cluster] = cluster.DeepCopy()
+	}
+	if user, ok := cfg.AuthInfos[ctx.AuthInfo]; ok {
+		stanza.AuthInfos[ctx.AuthInfo] = user.DeepCopy()
+	}
+	api.ShortenConfig(stanza)
+	if err := api.RedactSecrets(stanza); err != nil {
+		return nil, fmt.Errorf("failed to redact context %q: %w", name, err)
+	}
+	// RedactSecrets only knows the fields tagged as secret; auth provider
+	// settings and exec plugin environments carry tokens and keys too.
+	for _, user := range stanza.AuthInfos {
+		if user.AuthProvider != nil {
+			for k := range user.AuthProvider.Config {
+				user.AuthProvider.Config[k] = redacted
+			}
+		}
+		if user.Exec != nil {
+			for i := range user.Exec.Env {
+				user.Exec.Env[i].Value = redacted
+			}
+		}
+	}
+	return clientcmd.Write(*stanza)
+}
+
+// redacted replaces sensitive values, as api.RedactSecrets does.
+const redacted = "REDACTED"
+
+// editFile loads the kubeconfig file at path, applies edit and writes it
+// back, saving the original content to BackupPath first. With create, a
+// missing file starts out empty.
+func editFile(path string, create bool, edit func(*api.Config) error) error {
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && create:
+		data = nil
+	case err != nil:
+		return fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+	}
+	cfg, err := clientcmd.Load(data)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+	if err := edit(cfg); err != nil {
+		return err
+	}
+	if data != nil {
+		if err := os.WriteFile(BackupPath(path), data, 0o600); err != nil {
+			return fmt.Errorf("failed to back up kubeconfig %s: %w", path, err)
+		}
+	}
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestEditContexts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	writeKubeconfig(t, path, "dev", "prod")
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	load := func(t *testing.T, path string) *api.Config {
+		t.Helper()
+		cfg, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+
+	if err := RenameContext(path, "dev", "development"); err != nil {
+		t.Fatalf("RenameContext() error = %v", err)
+	}
+	backup, err := os.ReadFile(BackupPath(path))
+	if err != nil {
+		t.Fatalf("missing backup: %v", err)
+	}
+	if string(backup) != string(original) {
+		t.Errorf("backup differs from the original file")
+	}
+	cfg := load(t, path)
+	if _, ok := cfg.Contexts["dev"]; ok {
+		t.Error("old context name still present")
+	}
+	if cfg.CurrentContext != "development" {
+		t.Errorf("current-context = %q, want it to follow the rename", cfg.CurrentContext)
+	}
+	if err := RenameContext(path, "prod", "development"); err == nil {
+		t.Error("expected an error renaming onto an existing context")
+	}
+
+	if err := SetContextNamespace(path, "prod", "team-a"); err != nil {
+		t.Fatalf("SetContextNamespace() error = %v", err)
+	}
+	if err := UseContext(path, "prod"); err != nil {
+		t.Fatalf("UseContext() error = %v", err)
+	}
+	cfg = load(t, path)
+	if cfg.Contexts["prod"].Namespace != "team-a" || cfg.CurrentContext != "prod" {
+		t.Errorf("unexpected prod context %+v, current %q", cfg.Contexts["prod"], cfg.CurrentContext)
+	}
+
+	if err := DeleteContext(path, "prod"); err != nil {
+		t.Fatalf("DeleteContext() error = %v", err)
+	}
+	cfg = load(t, path)
+	if _, ok := cfg.Contexts["prod"]; ok || cfg.CurrentContext != "" {
+		t.Errorf("prod not deleted: contexts %v, current %q", cfg.Contexts, cfg.CurrentContext)
+	}
+	if _, ok := cfg.Clusters["c"]; !ok {
+		t.Error("DeleteContext removed the cluster")
+	}
+	if err := UseContext(path, "missing"); err == nil {
+		t.Error("expected an error for a missing context")
+	}
+}
+
+func TestCopyContext(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster:
+    server: https://example.com
+    certificate-authority: ca.crt
+users:
+- name: u
+  user:
+    token: secret-token
+contexts:
+- name: dev
+  context:
+    cluster: c
+    user: u
+`
+	if err := os.WriteFile(src, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "sub", "dst")
+	if err := CopyContext(src, "dev", dst); err != nil {
+		t.Fatalf("CopyContext() error = %v", err)
+	}
+	cfg, err := clientcmd.LoadFromFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Contexts["dev"] == nil || cfg.AuthInfos["u"] == nil || cfg.AuthInfos["u"].Token != "secret-token" {
+		t.Fatalf("context not copied with its user: %+v", cfg)
+	}
+	if got, want := cfg.Clusters["c"].CertificateAuthority, filepath.Join(dir, "ca.crt"); got != want {
+		t.Errorf("certificate-authority = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(BackupPath(dst)); !os.IsNotExist(err) {
+		t.Errorf("unexpected backup of a new file: %v", err)
+	}
+
+	// Copying again conflicts with the context itself.
+	if err := CopyContext(src, "dev", dst); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+	// A different user of the same name is not overwritten.
+	if err := RenameContext(dst, "dev", "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte(strings.Replace(content, "secret-token", "other-token", 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyContext(src, "dev", dst); err == nil || !strings.Contains(err.Error(), `user "u"`) {
+		t.Errorf("expected a user conflict, got %v", err)
+	}
+}
+
+func TestRedactedContext(t *testing.T) {
+	cfg := api.NewConfig()
+	cfg.Clusters["c"] = &api.Cluster{Server: "https://example.com", CertificateAuthorityData: []byte("ca")}
+	cfg.Clusters["other"] = &api.Cluster{Server: "https://other.example.com"}
+	cfg.AuthInfos["u"] = &api.AuthInfo{
+		Token:         "secret-token",
+		ClientKeyData: []byte("key"),
+		AuthProvider:  &api.AuthProviderConfig{Name: "oidc", Config: map[string]string{"id-token": "secret-id-token", "refresh-token": "secret-refresh"}},
+		Exec:          &api.ExecConfig{Command: "login-helper", Env: []api.ExecEnvVar{{Name: "API_KEY", Value: "secret-api-key"}}},
+	}
+	cfg.Contexts["dev"] = &api.Context{Cluster: "c", AuthInfo: "u", Namespace: "ns"}
+
+	out, err := RedactedContext(cfg, "dev")
+	if err != nil {
+		t.Fatalf("RedactedContext() error = %v", err)
+	}
+	s := string(out)
+	for _, secret := range []string{"secret-token", "secret-id-token", "secret-refresh", "secret-api-key", "other.example.com"} {
+		if strings.Contains(s, secret) {
+			t.Errorf("stanza contains %q:\n%s", secret, s)
+		}
+	}
+	for _, want := range []string{"https://example.com", "namespace: ns", "REDACTED", "id-token", "name: API_KEY", "login-helper"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("stanza misses %q:\n%s", want, s)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// watchDebounce coalesces the bursts of events a single kubeconfig write
+// produces (truncate, write, chmod, or rename of a temp file).
+var watchDebounce = 250 * time.Millisecond
+
+// Watch calls onChange after kubeconfig files changed on disk, until ctx is
+// done. It watches the directories of the candidate files rather than the
+// files themselves, as tools like `aws eks update-kubeconfig` replace them via
+// rename. onChange runs on the watcher goroutine; callers rediscover with
+// DiscoverKubeconfigs on their own goroutine.
+func (m *Manager) Watch(ctx context.Context, onChange func()) error {
+	dirs, match, err := m.watchTargets()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch kubeconfigs: %w", err)
+	}
+	watched := 0
+	for _, dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			_ = w.Close()
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		watched++
+	}
+	if watched == 0 {
+		return w.Close()
+	}
+
+	go func() {
+		defer w.Close()
+		var fire <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if match(filepath.Clean(ev.Name)) {
+					fire = time.After(watchDebounce)
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+			case <-fire:
+				fire = nil
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
+
+// watchTargets returns the directories to watch and a filter for the file
+// events that may change the discovery result.
+func (m *Manager) watchTargets() (dirs []string, match func(string) bool, err error) {
+	paths, _, err := m.discoveryPaths()
+	if err != nil {
+		return nil, nil, err
+	}
+	seen := map[string]bool{}
+	addDir := func(dir string) {
+		if dir = filepath.Clean(dir); !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if m.explicitPath != "" || os.Getenv(clientcmd.RecommendedConfigPathEnvVar) != "" {
+		files := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			files[filepath.Clean(p)] = true
+			addDir(filepath.Dir(p))
+		}
+		return dirs, func(name string) bool { return files[name] }, nil
+	}
+
+	// Default rules: any visible file in ~/.kube or a directory holding a
+	// discovered kubeconfig may add, change or remove contexts.
+	kubeDir, err := defaultKubeDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	addDir(kubeDir)
+	for _, kc := range m.kubeconfigs {
+		if strings.HasPrefix(filepath.Clean(kc.Path), filepath.Clean(kubeDir)+string(filepath.Separator)) {
+			addDir(filepath.Dir(kc.Path))
+		}
+	}
+	return dirs, func(name string) bool {
+		return seen[filepath.Dir(name)] && !strings.HasPrefix(filepath.Base(name), ".")
+	}, nil
+}